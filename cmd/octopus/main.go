@@ -2,18 +2,22 @@ package main
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2/terminal"
 	version "github.com/OctopusDeploy/cli"
-	"github.com/briandowns/spinner"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"io"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/OctopusDeploy/cli/pkg/config"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/usage"
 
@@ -29,7 +33,7 @@ func main() {
 
 	if err := config.Setup(viper.GetViper()); err != nil {
 		fmt.Println(err)
-		os.Exit(3)
+		os.Exit(constants.ExitCodeConfiguration)
 	}
 	arg := os.Args[1:]
 	cmdToRun := ""
@@ -40,9 +44,12 @@ func main() {
 	// initialize our wrapper around survey, which is also used as a flag for whether
 	// we are in interactive mode or automation mode
 	askProvider := question.NewAskProvider(survey.AskOne)
-	_, ci := os.LookupEnv("CI")
-	// TODO move this to some other function and have it look for GITHUB_ACTIONS etc as we learn more about it
-	if ci {
+
+	// the client factory is built, and some messages printed, before cobra parses the command line, so we have
+	// to find the flags they depend on ourselves
+	forcePrompt := applyEarlyArgs(arg)
+
+	if !forcePrompt && question.IsRunningInCI(os.LookupEnv) {
 		askProvider.DisableInteractive()
 	}
 
@@ -51,16 +58,24 @@ func main() {
 	clientFactory, err := apiclient.NewClientFactoryFromConfig(askProvider)
 	if err != nil {
 		// a small subset of commands can function even if the app doesn't have valid configuration information
-		if cmdToRun == "config" || cmdToRun == "version" || cmdToRun == "help" {
+		// tab completion still works for commands and flags; anything it would look up on the server just isn't offered
+		if cmdToRun == "config" || cmdToRun == "version" || cmdToRun == "help" || cmdToRun == "completion" || cmdToRun == cobra.ShellCompRequestCmd || cmdToRun == cobra.ShellCompNoDescRequestCmd {
 			clientFactory = apiclient.NewStubClientFactory()
 		} else {
 			// can't possibly work
 			fmt.Println(err)
-			os.Exit(3)
+			os.Exit(constants.ExitCodeConfiguration)
 		}
 	}
 
-	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond, spinner.WithColor("cyan"))
+	if viper.GetBool(constants.ConfigSkipTlsVerify) {
+		fmt.Fprintln(os.Stderr, output.Yellow("Warning: TLS certificate verification is disabled. Anyone who can intercept your connection to the Octopus Server can read and change it, including your API key."))
+	}
+
+	var s factory.Spinner = factory.NoSpinner
+	if !output.IsQuiet {
+		s = apiclient.NewSpinner()
+	}
 
 	f := factory.New(clientFactory, askProvider, s, buildVersion)
 
@@ -70,15 +85,68 @@ func main() {
 	cmd.SetOut(terminal.NewAnsiStdout(os.Stdout))
 	cmd.SetErr(terminal.NewAnsiStderr(os.Stderr))
 
-	if err := cmd.Execute(); err != nil {
-		cmd.PrintErr(err)
-		cmd.Println()
+	if err := root.Execute(cmd, s); err != nil {
+		if errors.Is(err, root.ErrInterrupted) {
+			// finish the line the prompt or ^C was left on, so the shell's prompt starts on a fresh one
+			fmt.Fprintln(os.Stderr)
+			os.Exit(constants.ExitCodeInterrupted)
+		}
+		err = apiclient.ExplainError(clientFactory, err)
+		output.PrintError(cmd, err)
 
-		if usageError, ok := err.(*usage.UsageError); ok {
+		if usageError, ok := err.(*usage.UsageError); ok && !output.IsJsonErrorFormat(cmd) {
 			// if the code returns a UsageError, print the usage information
 			cmd.Println(usageError.Command().UsageString())
 		}
 
-		os.Exit(1)
+		var apiKeyExpiredError *apiclient.ApiKeyExpiredError
+		if errors.As(err, &apiKeyExpiredError) {
+			os.Exit(constants.ExitCodeAuth)
+		}
+		os.Exit(constants.ExitCodeError)
+	}
+}
+
+// applyEarlyArgs returns true if --prompt was given
+func applyEarlyArgs(args []string) bool {
+	flags := pflag.NewFlagSet(constants.ExecutableName, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.Usage = func() {}
+	flags.SetOutput(io.Discard)
+	profile := flags.String(constants.FlagProfile, "", "")
+	server := flags.String(constants.FlagServer, "", "")
+	apiKey := flags.String(constants.FlagApiKey, "", "")
+	caCert := flags.String(constants.FlagCACert, "", "")
+	skipTlsVerify := flags.Bool(constants.FlagSkipTlsVerify, false, "")
+	noColor := flags.Bool(constants.FlagNoColor, false, "")
+	debug := flags.Bool(constants.FlagDebug, false, "")
+	quiet := flags.Bool(constants.FlagQuiet, false, "")
+	prompt := flags.Bool(constants.FlagPrompt, false, "")
+	_ = flags.Parse(args) // anything we don't understand is cobra's problem, not ours
+
+	if *profile != "" {
+		viper.Set(constants.ConfigProfile, *profile)
+	}
+	if *server != "" {
+		apiclient.ServerOverride = *server
+	}
+	if *apiKey != "" {
+		apiclient.ApiKeyOverride = *apiKey
+	}
+	if *caCert != "" {
+		viper.Set(constants.ConfigCACert, *caCert)
+	}
+	if *skipTlsVerify {
+		viper.Set(constants.ConfigSkipTlsVerify, true)
+	}
+	if *debug {
+		viper.Set(constants.ConfigDebug, true)
+	}
+	if *noColor {
+		output.IsColorEnabled = false
+	}
+	if *quiet {
+		output.IsQuiet = true
 	}
+	return *prompt
 }
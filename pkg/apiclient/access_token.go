@@ -0,0 +1,31 @@
+package apiclient
+
+import (
+	"net/http"
+
+	octopusConstants "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/constants"
+)
+
+// AccessTokenPlaceholderApiKey is what the SDK is given as the API key when we authenticate with an access token
+// instead. The SDK won't make a client without something shaped like an API key, but AccessTokenRoundTripper
+// takes it off every request, so it never reaches the server.
+const AccessTokenPlaceholderApiKey = "API-ACCESSTOKENPLACEHOLDER0000000000"
+
+// AccessTokenRoundTripper authenticates with a bearer access token, such as a short-lived OIDC token, rather than
+// an API key. It swaps the API key header the SDK adds for an Authorization header carrying the token.
+type AccessTokenRoundTripper struct {
+	AccessToken string
+	Next        http.RoundTripper
+}
+
+func NewAccessTokenRoundTripper(accessToken string, next http.RoundTripper) *AccessTokenRoundTripper {
+	return &AccessTokenRoundTripper{AccessToken: accessToken, Next: next}
+}
+
+func (a *AccessTokenRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	// a RoundTripper mustn't modify the request it's given
+	r = r.Clone(r.Context())
+	r.Header.Del(octopusConstants.ClientAPIKeyHTTPHeader)
+	r.Header.Set("Authorization", "Bearer "+a.AccessToken)
+	return a.Next.RoundTrip(r)
+}
@@ -0,0 +1,58 @@
+package apiclient_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTokenRoundTripper(t *testing.T) {
+	api := testutil.NewMockHttpServer()
+	httpClient := testutil.NewMockHttpClientWithTransport(apiclient.NewAccessTokenRoundTripper("eyJhbGciOiJSUzI1NiJ9.token", api))
+	factory, err := apiclient.NewClientFactory(httpClient, serverUrl, apiclient.AccessTokenPlaceholderApiKey, "", qa)
+	testutil.RequireSuccess(t, err)
+
+	clientReceiver := testutil.GoBegin2(func() (*octopusApiClient.Client, error) {
+		defer api.Close()
+		return factory.GetSystemClient(&apiclient.FakeRequesterContext{})
+	})
+
+	req := api.ExpectRequest(t, "GET", "/api")
+	assert.Equal(t, "Bearer eyJhbGciOiJSUzI1NiJ9.token", req.Request.Header.Get("Authorization"))
+	// the placeholder the SDK insists on must never reach the server
+	assert.Equal(t, "", req.Request.Header.Get("X-Octopus-ApiKey"))
+	req.RespondWith(root)
+
+	_, err = testutil.ReceivePair(clientReceiver)
+	assert.Nil(t, err)
+}
+
+func TestValidateMandatoryEnvironment(t *testing.T) {
+	assert.Nil(t, apiclient.ValidateMandatoryEnvironment(serverUrl, placeholderApiKey, ""))
+	assert.Nil(t, apiclient.ValidateMandatoryEnvironment(serverUrl, "", "eyJhbGciOiJSUzI1NiJ9.token"))
+	assert.ErrorContains(t, apiclient.ValidateMandatoryEnvironment(serverUrl, "", ""), "OCTOPUS_ACCESS_TOKEN")
+	assert.NotNil(t, apiclient.ValidateMandatoryEnvironment("", placeholderApiKey, ""))
+}
+
+func TestValidateMandatoryEnvironment_ListsEachMissingSetting(t *testing.T) {
+	err := apiclient.ValidateMandatoryEnvironment("", "", "")
+	assert.EqualError(t, err, heredoc.Doc(`
+		To get started with octopus, please set these environment variables:
+		  * OCTOPUS_URL: the address of your Octopus Server, e.g. https://octopus.example.com; or run 'octopus config set Url'
+		  * OCTOPUS_API_KEY: an API key, which you can create from your profile in the Octopus web portal; or run 'octopus config set ApiKey'. In CI, you can set OCTOPUS_ACCESS_TOKEN to an access token instead`))
+
+	// it's still a multierror, for anything which wants to look at the settings one at a time
+	var missing *multierror.Error
+	assert.True(t, errors.As(err, &missing))
+	assert.Len(t, missing.Errors, 2)
+
+	err = apiclient.ValidateMandatoryEnvironment("", placeholderApiKey, "")
+	assert.EqualError(t, err, "To get started with octopus, please set these environment variables:\n"+
+		"  * OCTOPUS_URL: the address of your Octopus Server, e.g. https://octopus.example.com; or run 'octopus config set Url'")
+}
@@ -0,0 +1,99 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+)
+
+// ApiKeyExpiredError is raised when the Octopus Server rejects the API key because it has passed its expiry date
+type ApiKeyExpiredError struct {
+	ServerMessage string
+}
+
+func (e *ApiKeyExpiredError) Error() string {
+	return "the Octopus API key has expired (" + e.ServerMessage + ").\n" +
+		"Create a new API key from your profile in the Octopus Deploy web portal, and update OCTOPUS_API_KEY or your config with it."
+}
+
+// ApiKeyExpiryRoundTripper watches for the Octopus Server telling us the API key has expired.
+// The SDK turns every 401 into a plain "unauthorized" error, so rather than failing the request ourselves we
+// remember what the server said, and ExplainError swaps in an ApiKeyExpiredError once the command has failed.
+type ApiKeyExpiryRoundTripper struct {
+	Next http.RoundTripper
+
+	// the expiry reported by the server; nil until we see one
+	Expired *ApiKeyExpiredError
+}
+
+func NewApiKeyExpiryRoundTripper(next http.RoundTripper) *ApiKeyExpiryRoundTripper {
+	return &ApiKeyExpiryRoundTripper{Next: next}
+}
+
+func (c *ApiKeyExpiryRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := c.Next.RoundTrip(r)
+	if err != nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) || resp.Body == nil {
+		return resp, err
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	// put the body back so the SDK sees exactly what the server sent
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return resp, nil
+	}
+	if message := apiKeyExpiredMessage(body); message != "" {
+		c.Expired = &ApiKeyExpiredError{ServerMessage: message}
+	}
+	return resp, nil
+}
+
+// apiKeyExpiredMessage returns the server's error message if body is an Octopus error response saying the
+// API key has expired, or an empty string otherwise. The server doesn't give us an error code for this, so
+// the best we can do is look for it in the message text.
+func apiKeyExpiredMessage(body []byte) string {
+	var apiError core.APIError
+	if json.Unmarshal(body, &apiError) != nil {
+		return ""
+	}
+	for _, message := range append([]string{apiError.ErrorMessage}, apiError.Errors...) {
+		lower := strings.ToLower(message)
+		if strings.Contains(lower, "api key") && strings.Contains(lower, "expired") {
+			return message
+		}
+	}
+	return ""
+}
+
+// ExplainError replaces err with an ApiKeyExpiredError if the client factory saw the server reject our API key
+// as expired while the command was running, or a ConnectionError if the server couldn't be reached, as that is
+// almost certainly why the command failed.
+func ExplainError(clientFactory ClientFactory, err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiKeyExpiredError *ApiKeyExpiredError
+	if errors.As(err, &apiKeyExpiredError) {
+		return err
+	}
+	c, ok := clientFactory.(*Client)
+	if !ok {
+		return err
+	}
+	if c.ApiKeyExpiry != nil && c.ApiKeyExpiry.Expired != nil {
+		return c.ApiKeyExpiry.Expired
+	}
+	// running out of time already has its own explanation
+	var timeoutError *TimeoutError
+	if c.Connection != nil && c.Connection.Failed != nil && !errors.As(err, &timeoutError) {
+		c.debugf("connecting to %s failed: %v", c.Connection.Failed.Host, c.Connection.Failed.Err)
+		return c.Connection.Failed
+	}
+	return err
+}
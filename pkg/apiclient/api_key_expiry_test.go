@@ -0,0 +1,58 @@
+package apiclient_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyExpiry(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		response    *core.APIError
+		wantExpired bool
+	}{
+		{"expired API key", 401, &core.APIError{ErrorMessage: "The API key you provided has expired. Please create a new API key."}, true},
+		{"expired API key in the error list", 401, &core.APIError{ErrorMessage: "Unauthorized", Errors: []string{"This API key expired on 2026-01-01."}}, true},
+		{"invalid API key", 401, &core.APIError{ErrorMessage: "The API key you provided was not valid. Please double-check your API key and try again."}, false},
+		{"forbidden for another reason", 403, &core.APIError{ErrorMessage: "You do not have permission to perform this action."}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			apiKeyExpiry := apiclient.NewApiKeyExpiryRoundTripper(api)
+			factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(apiKeyExpiry), serverUrl, placeholderApiKey, "", qa)
+			testutil.RequireSuccess(t, err)
+			factory.(*apiclient.Client).ApiKeyExpiry = apiKeyExpiry
+
+			clientReceiver := testutil.GoBegin2(func() (*octopusApiClient.Client, error) {
+				defer api.Close()
+				return factory.GetSystemClient(&apiclient.FakeRequesterContext{})
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWithStatus(test.statusCode, "", test.response)
+
+			_, err = testutil.ReceivePair(clientReceiver)
+			assert.NotNil(t, err)
+
+			err = apiclient.ExplainError(factory, err)
+			var apiKeyExpiredError *apiclient.ApiKeyExpiredError
+			assert.Equal(t, test.wantExpired, errors.As(err, &apiKeyExpiredError))
+			if test.wantExpired {
+				assert.Contains(t, err.Error(), "Create a new API key")
+			}
+		})
+	}
+
+	t.Run("leaves other errors alone", func(t *testing.T) {
+		err := errors.New("boom")
+		assert.Same(t, err, apiclient.ExplainError(apiclient.NewStubClientFactory(), err))
+		assert.Nil(t, apiclient.ExplainError(apiclient.NewStubClientFactory(), nil))
+	})
+}
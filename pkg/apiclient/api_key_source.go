@@ -0,0 +1,68 @@
+package apiclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/kballard/go-shellquote"
+)
+
+// ApiKeyFilePrefix marks an API key setting which names a file to read the key from, e.g. OCTOPUS_API_KEY=@/run/secrets/octopus
+const ApiKeyFilePrefix = "@"
+
+// ResolveApiKey works out the actual API key, so that it needn't sit in the environment where process listings and
+// shell history can see it. If apiKeyCommand is set, it is run, like a docker credential helper, and whatever it
+// prints is the key; this wins over apiKey. Otherwise, if apiKey is @ followed by a path, the key is read from that
+// file. Anything else is the key itself. Trailing whitespace, such as the newline at the end of a file, is trimmed.
+func ResolveApiKey(apiKey string, apiKeyCommand string) (string, error) {
+	if apiKeyCommand != "" {
+		return runApiKeyCommand(apiKeyCommand)
+	}
+	if strings.HasPrefix(apiKey, ApiKeyFilePrefix) {
+		path := strings.TrimPrefix(apiKey, ApiKeyFilePrefix)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("cannot read the API key from '%s': %w", path, err)
+		}
+		key := strings.TrimRightFunc(string(data), unicode.IsSpace)
+		if key == "" {
+			return "", fmt.Errorf("the API key file '%s' is empty", path)
+		}
+		return key, nil
+	}
+	return apiKey, nil
+}
+
+func runApiKeyCommand(apiKeyCommand string) (string, error) {
+	args, err := shellquote.Split(apiKeyCommand)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse %s: %w", constants.EnvOctopusApiKeyCommand, err)
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("%s is blank", constants.EnvOctopusApiKeyCommand)
+	}
+	stdout := &bytes.Buffer{}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = stdout
+	// the helper may need to tell the user something, e.g. to unlock a keychain, so let it use the terminal
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("the API key command '%s' failed with exit code %d", args[0], exitErr.ExitCode())
+		}
+		return "", fmt.Errorf("cannot run the API key command '%s': %w", args[0], err)
+	}
+	key := strings.TrimRightFunc(stdout.String(), unicode.IsSpace)
+	if key == "" {
+		return "", fmt.Errorf("the API key command '%s' didn't print an API key", args[0])
+	}
+	return key, nil
+}
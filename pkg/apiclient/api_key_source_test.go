@@ -0,0 +1,66 @@
+package apiclient_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveApiKey(t *testing.T) {
+	t.Run("uses a plain key as it is", func(t *testing.T) {
+		key, err := apiclient.ResolveApiKey(placeholderApiKey, "")
+		assert.Nil(t, err)
+		assert.Equal(t, placeholderApiKey, key)
+	})
+
+	t.Run("reads the key from a file, trimming the trailing newline", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "octopus-key")
+		assert.Nil(t, os.WriteFile(path, []byte(placeholderApiKey+"\r\n"), 0600))
+
+		key, err := apiclient.ResolveApiKey("@"+path, "")
+		assert.Nil(t, err)
+		assert.Equal(t, placeholderApiKey, key)
+	})
+
+	t.Run("explains when the file can't be read", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing")
+		_, err := apiclient.ResolveApiKey("@"+path, "")
+		assert.ErrorContains(t, err, "cannot read the API key from '"+path+"'")
+	})
+
+	t.Run("rejects an empty file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "octopus-key")
+		assert.Nil(t, os.WriteFile(path, []byte("\n"), 0600))
+		_, err := apiclient.ResolveApiKey("@"+path, "")
+		assert.EqualError(t, err, "the API key file '"+path+"' is empty")
+	})
+
+	t.Run("runs the command, which wins over the key", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("uses echo")
+		}
+		key, err := apiclient.ResolveApiKey("API-FROMTHEENVIRONMENTXXXXXXXXX", "echo '"+placeholderApiKey+"'")
+		assert.Nil(t, err)
+		assert.Equal(t, placeholderApiKey, key)
+	})
+
+	t.Run("explains when the command fails", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("uses false")
+		}
+		_, err := apiclient.ResolveApiKey("", "false")
+		assert.EqualError(t, err, "the API key command 'false' failed with exit code 1")
+	})
+
+	t.Run("rejects a command which prints nothing", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("uses true")
+		}
+		_, err := apiclient.ResolveApiKey("", "true")
+		assert.EqualError(t, err, "the API key command 'true' didn't print an API key")
+	})
+}
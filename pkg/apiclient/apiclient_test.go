@@ -1,13 +1,33 @@
 package apiclient_test
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
 	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/cli/test/testutil"
 	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
 	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 const serverUrl = "http://server"
@@ -111,7 +131,56 @@ func TestClient_GetSpacedClient_NoPrompt(t *testing.T) {
 
 		apiClient, err := testutil.ReceivePair(clientReceiver)
 		assert.Nil(t, apiClient)
-		assert.Equal(t, "cannot find space 'Integrations'", err.Error()) // some strongly-typed errors would probably be nicer
+		assert.Equal(t, "cannot find space 'Integrations'", err.Error())
+		var notFound *cliErrors.SpaceNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "Integrations", notFound.SpaceNameOrID)
+	})
+
+	t.Run("GetSpacedClient suggests the closest spaces when the name looks like a typo", func(t *testing.T) {
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Defualt", qa)
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		defaultSpace := spaces.NewSpace("Default")
+		defaultSpace.ID = "Spaces-1"
+		defaultsSpace := spaces.NewSpace("Defaults")
+		defaultsSpace.ID = "Spaces-2"
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{cloudSpace, defaultsSpace, defaultSpace})
+
+		apiClient, err := testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, apiClient)
+		assert.EqualError(t, err, "cannot find space 'Defualt'; did you mean one of 'Default', 'Defaults'?")
+		var notFound *cliErrors.SpaceNotFoundError
+		assert.ErrorAs(t, err, &notFound)
+		assert.Equal(t, []string{"Default", "Defaults"}, notFound.Suggestions)
+	})
+
+	t.Run("GetSpacedClient explains when the space exists but the API key cannot access it", func(t *testing.T) {
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Spaces-7", qa)
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+
+		// the space isn't listed because the key has no permissions in it, but the server admits it exists
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{cloudSpace})
+		api.ExpectRequest(t, "GET", "/api/spaces/Spaces-7").RespondWithStatus(http.StatusForbidden, "403 Forbidden", map[string]string{
+			"ErrorMessage": "You do not have permission to perform this action.",
+		})
+
+		apiClient, err := testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, apiClient)
+		assert.EqualError(t, err, "space 'Spaces-7' exists, but your API key does not have access to it; ask an Octopus administrator to add you to a team in that space, or use a different space")
 	})
 
 	t.Run("GetSpacedClient works when the Space ID is directly specified", func(t *testing.T) {
@@ -242,6 +311,110 @@ func TestClient_GetSpacedClient_NoPrompt(t *testing.T) {
 		assert.NotNil(t, apiClient)
 	})
 
+	t.Run("GetSpacedClient explains how it matched the space with --debug", func(t *testing.T) {
+		missedSpace := spaces.NewSpace("Missed")
+		missedSpace.ID = "Spaces-7"
+
+		spaces7space := spaces.NewSpace("Spaces-7")
+		spaces7space.ID = "Spaces-209"
+
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Spaces-7", qa)
+		testutil.RequireSuccess(t, err)
+		debugOut := &bytes.Buffer{}
+		factory.(*apiclient.Client).DebugOut = debugOut
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{missedSpace, spaces7space})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-209").RespondWith(spaces7space)
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+		assert.Equal(t, heredoc.Doc(`
+			[debug] space "Spaces-7": considering 2 spaces: Missed (Spaces-7), Spaces-7 (Spaces-209)
+			[debug] space "Spaces-7": matched the name of Spaces-209
+			[debug] space "Spaces-7": using Spaces-209
+		`), debugOut.String())
+	})
+
+	t.Run("GetSpacedClient uses the first space of a list that exists", func(t *testing.T) {
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "MyTeam, cloud,Integrations", qa)
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{integrationsSpace, cloudSpace})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-39").RespondWith(cloudSpace)
+
+		apiClient, err := testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+		assert.NotNil(t, apiClient)
+		assert.Equal(t, "Spaces-39", factory.GetActiveSpace().ID)
+	})
+
+	t.Run("GetSpacedClient prefers a space whose name has a comma in it", func(t *testing.T) {
+		commaSpace := spaces.NewSpace("Cloud, EMEA")
+		commaSpace.ID = "Spaces-40"
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Cloud, EMEA", qa)
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{cloudSpace, commaSpace})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-40").RespondWith(commaSpace)
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+		assert.Equal(t, "Spaces-40", factory.GetActiveSpace().ID)
+	})
+
+	t.Run("GetSpacedClient returns an error when no space in the list exists", func(t *testing.T) {
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "MyTeam,Default", qa)
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{integrationsSpace, cloudSpace})
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		assert.EqualError(t, err, "cannot find space 'MyTeam,Default'")
+	})
+
+	t.Run("GetSpacedClient suggests the closest spaces to each one in the list", func(t *testing.T) {
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Clod,Integratoins", qa)
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{integrationsSpace, cloudSpace})
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		assert.EqualError(t, err, "cannot find space 'Clod,Integratoins'; did you mean one of 'Cloud', 'Integrations'?")
+	})
+
 	t.Run("GetSpacedClient called twice returns the same client instance without additional requests", func(t *testing.T) {
 		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Integrations", qa)
 		testutil.RequireSuccess(t, err)
@@ -273,3 +446,341 @@ func TestClient_GetSpacedClient_NoPrompt(t *testing.T) {
 		assert.Same(t, apiClient, apiClient2)
 	})
 }
+
+func TestClient_GetSpacedClient_SpaceCache(t *testing.T) {
+	integrationsSpace := spaces.NewSpace("Integrations")
+	integrationsSpace.ID = "Spaces-7"
+
+	api := testutil.NewMockHttpServer()
+
+	newFactory := func(t *testing.T, spaceCache *apiclient.SpaceCache) apiclient.ClientFactory {
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "", qa)
+		testutil.RequireSuccess(t, err)
+		factory.(*apiclient.Client).SpaceCache = spaceCache
+		return factory
+	}
+
+	t.Run("GetSpacedClient caches the space it looked up, and uses it next time", func(t *testing.T) {
+		spaceCache := apiclient.NewSpaceCache(filepath.Join(t.TempDir(), "space_cache.json"))
+
+		factory := newFactory(t, spaceCache)
+		factory.SetSpaceNameOrId("Integrations")
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{integrationsSpace})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-7").RespondWith(integrationsSpace)
+		_, err := testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+
+		// a brand new factory, as if this were the next invocation of the CLI; note there's no /api/spaces/all
+		factory = newFactory(t, spaceCache)
+		factory.SetSpaceNameOrId("integrations")
+		clientReceiver = testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-7").RespondWith(integrationsSpace)
+		apiClient, err := testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+		assert.NotNil(t, apiClient)
+		assert.Equal(t, "Spaces-7", factory.GetActiveSpace().ID)
+	})
+
+	t.Run("SetSpaceNameOrId invalidates the cache when the space isn't in it", func(t *testing.T) {
+		spaceCache := apiclient.NewSpaceCache(filepath.Join(t.TempDir(), "space_cache.json"))
+		spaceCache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+
+		factory := newFactory(t, spaceCache)
+		factory.SetSpaceNameOrId("Integrations")
+		assert.NotNil(t, spaceCache.Get(serverUrl, "Integrations"))
+
+		factory.SetSpaceNameOrId("Cloud")
+		assert.Nil(t, spaceCache.Get(serverUrl, "Integrations"))
+	})
+
+	t.Run("DisableSpaceCache always goes to the server", func(t *testing.T) {
+		spaceCache := apiclient.NewSpaceCache(filepath.Join(t.TempDir(), "space_cache.json"))
+		spaceCache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+
+		factory := newFactory(t, spaceCache)
+		factory.DisableSpaceCache()
+		factory.SetSpaceNameOrId("Integrations")
+		clientReceiver := testutil.GoBegin2(
+			func() (*octopusApiClient.Client, error) {
+				return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+			})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{integrationsSpace})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-7").RespondWith(integrationsSpace)
+		_, err := testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+	})
+}
+
+func TestClient_SetCommandTimeout(t *testing.T) {
+	factory, err := apiclient.NewClientFactory(&http.Client{}, serverUrl, placeholderApiKey, "", qa)
+	testutil.RequireSuccess(t, err)
+	client := factory.(*apiclient.Client)
+	client.Deadline = apiclient.NewDeadlineRoundTripper(http.DefaultTransport)
+	debugOut := &bytes.Buffer{}
+	client.DebugOut = debugOut
+
+	client.SetCommandTimeout(90 * time.Second)
+	assert.Equal(t, "[debug] requests to the Octopus Server time out after 1m30s\n", debugOut.String())
+}
+
+func TestParseHttpTimeout(t *testing.T) {
+	timeout, err := apiclient.ParseHttpTimeout("")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), timeout)
+
+	timeout, err = apiclient.ParseHttpTimeout("90s")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, timeout)
+
+	_, err = apiclient.ParseHttpTimeout("soon")
+	assert.EqualError(t, err, "OCTOPUS_HTTP_TIMEOUT environment variable has an invalid value 'soon'; it must be a positive duration such as 30s or 2m")
+
+	_, err = apiclient.ParseHttpTimeout("-5s")
+	assert.EqualError(t, err, "OCTOPUS_HTTP_TIMEOUT environment variable has an invalid value '-5s'; it must be a positive duration such as 30s or 2m")
+}
+
+func TestParseHostUrl(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"myserver", "https://myserver"},
+		{"myserver:8080", "https://myserver:8080"},
+		{"myserver/", "https://myserver"},
+		{"http://myserver", "http://myserver"},
+		{"http://myserver/", "http://myserver"},
+		{"https://myserver:8080/octopus//", "https://myserver:8080/octopus"},
+		{" HTTPS://myserver ", "https://myserver"},
+	}
+	for _, test := range tests {
+		hostUrl, err := apiclient.ParseHostUrl(test.host)
+		assert.Nil(t, err, test.host)
+		assert.Equal(t, test.want, hostUrl.String(), test.host)
+	}
+
+	_, err := apiclient.ParseHostUrl("ftp://myserver")
+	assert.EqualError(t, err, "the Octopus Server address 'ftp://myserver' isn't valid; it must start with http:// or https://. Check OCTOPUS_URL, --server or the Url config setting")
+
+	_, err = apiclient.ParseHostUrl("https://")
+	assert.EqualError(t, err, "the Octopus Server address 'https://' isn't valid; it has no host name. Check OCTOPUS_URL, --server or the Url config setting")
+
+	_, err = apiclient.ParseHostUrl("my server")
+	assert.EqualError(t, err, "the Octopus Server address 'my server' isn't valid; it should look like https://octopus.example.com. Check OCTOPUS_URL, --server or the Url config setting")
+
+	_, err = apiclient.ParseHostUrl("myserver:port")
+	assert.NotNil(t, err)
+
+	_, err = apiclient.ParseHostUrl("https://myserver/app#/Spaces-1")
+	assert.EqualError(t, err, "the Octopus Server address 'https://myserver/app#/Spaces-1' isn't valid; it should be just the server's address, e.g. https://octopus.example.com. Check OCTOPUS_URL, --server or the Url config setting")
+}
+
+func TestClient_BasePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		apiPath string
+	}{
+		{"hosted at the root", "http://server", "/api"},
+		{"hosted at the root, with a trailing slash", "http://server/", "/api"},
+		{"hosted under a path", "http://server/octopus", "/octopus/api"},
+		{"hosted under a path, with a trailing slash", "http://server/octopus/", "/octopus/api"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), test.host, placeholderApiKey, "", qa)
+			testutil.RequireSuccess(t, err)
+			assert.Equal(t, strings.TrimSuffix(test.host, "/"), factory.GetHostUrl())
+
+			clientReceiver := testutil.GoBegin2(
+				func() (*octopusApiClient.Client, error) {
+					defer api.Close()
+					return factory.GetSystemClient(&apiclient.FakeRequesterContext{})
+				})
+
+			api.ExpectRequest(t, "GET", test.apiPath).RespondWith(root)
+
+			_, err = testutil.ReceivePair(clientReceiver)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestValidateApiKey(t *testing.T) {
+	assert.Nil(t, apiclient.ValidateApiKey(placeholderApiKey))
+	assert.Nil(t, apiclient.ValidateApiKey("API-ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"))
+
+	err := apiclient.ValidateApiKey("XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+	assert.EqualError(t, err, "the API key doesn't look right; Octopus API keys start with 'API-'. Check the value of OCTOPUS_API_KEY or the ApiKey config setting. If your Octopus Server issues keys in a different format, set OCTOPUS_SKIP_API_KEY_CHECK=true to skip this check")
+
+	err = apiclient.ValidateApiKey("API-ABCDEFGHIJ")
+	assert.EqualError(t, err, "the API key doesn't look right; after 'API-' it should have at least 20 letters and numbers and nothing else, but it has 10 characters. It may have been cut short or picked up extra characters when it was copied. If your Octopus Server issues keys in a different format, set OCTOPUS_SKIP_API_KEY_CHECK=true to skip this check")
+
+	// a stray newline from copying out of a terminal
+	err = apiclient.ValidateApiKey(placeholderApiKey + "\n")
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), placeholderApiKey)
+}
+
+func TestNewHttpTransport(t *testing.T) {
+	transport, err := apiclient.NewHttpTransport(false, "")
+	assert.Nil(t, err)
+	assert.NotNil(t, transport.Proxy)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Nil(t, transport.TLSClientConfig.RootCAs)
+
+	transport, err = apiclient.NewHttpTransport(true, "")
+	assert.Nil(t, err)
+	assert.NotNil(t, transport.Proxy)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+
+	caCertPath := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caCertPath, newCACertPEM(t), 0600))
+	transport, err = apiclient.NewHttpTransport(false, caCertPath)
+	assert.Nil(t, err)
+	assert.NotNil(t, transport.TLSClientConfig.RootCAs)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestLoadCACertPool(t *testing.T) {
+	dir := t.TempDir()
+
+	caCertPath := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, os.WriteFile(caCertPath, newCACertPEM(t), 0600))
+	certPool, err := apiclient.LoadCACertPool(caCertPath)
+	assert.Nil(t, err)
+	assert.NotNil(t, certPool)
+
+	missingPath := filepath.Join(dir, "missing.pem")
+	_, err = apiclient.LoadCACertPool(missingPath)
+	assert.ErrorContains(t, err, "cannot read the CA certificate file '"+missingPath+"'")
+
+	garbagePath := filepath.Join(dir, "garbage.pem")
+	assert.Nil(t, os.WriteFile(garbagePath, []byte("not a certificate"), 0600))
+	_, err = apiclient.LoadCACertPool(garbagePath)
+	assert.EqualError(t, err, "the CA certificate file '"+garbagePath+"' does not contain any PEM encoded certificates")
+}
+
+func newCACertPEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	testutil.RequireSuccess(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Internal CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	testutil.RequireSuccess(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestClient_GetSpacedClient_Prompt(t *testing.T) {
+	newSpace := func(i int) *spaces.Space {
+		space := spaces.NewSpace(fmt.Sprintf("Space %d", i))
+		space.ID = fmt.Sprintf("Spaces-%d", i)
+		return space
+	}
+	spaceNames := func(items []*spaces.Space) []string {
+		return util.SliceTransform(items, func(s *spaces.Space) string { return s.Name })
+	}
+
+	t.Run("offers every space when there are only a few", func(t *testing.T) {
+		api, qa := testutil.NewMockServerAndAsker()
+		allSpaces := []*spaces.Space{newSpace(1), newSpace(2)}
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "", question.NewAskProvider(qa.AsAsker()))
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(func() (*octopusApiClient.Client, error) {
+			defer testutil.Close(api, qa)
+			return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces?take=50").RespondWith(&resources.Resources[*spaces.Space]{Items: allSpaces, PagedResults: resources.PagedResults{TotalResults: 2}})
+		q := qa.ExpectQuestion(t, &survey.Select{
+			Message: "You have not specified a Space. Please select one:",
+			Options: []string{"Space 1", "Space 2"},
+		})
+		// typing part of a name narrows the list
+		assert.True(t, q.Options.PromptConfig.Filter("spc2", "Space 2", 1))
+		assert.False(t, q.Options.PromptConfig.Filter("spc2", "Space 1", 0))
+		_ = q.AnswerWith("Space 2")
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-2").RespondWith(allSpaces[1])
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+		assert.Equal(t, "Spaces-2", factory.GetActiveSpace().ID)
+	})
+
+	t.Run("returns a NoSpacesError when the server has no spaces", func(t *testing.T) {
+		api, qa := testutil.NewMockServerAndAsker()
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "", question.NewAskProvider(qa.AsAsker()))
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(func() (*octopusApiClient.Client, error) {
+			defer testutil.Close(api, qa)
+			return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces?take=50").RespondWith(&resources.Resources[*spaces.Space]{Items: []*spaces.Space{}})
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		var noSpacesErr *cliErrors.NoSpacesError
+		assert.ErrorAs(t, err, &noSpacesErr)
+	})
+
+	t.Run("only loads the first page on a huge instance, and searches the server for the rest", func(t *testing.T) {
+		api, qa := testutil.NewMockServerAndAsker()
+		var firstPage []*spaces.Space
+		for i := 1; i <= apiclient.SpacePromptPageSize; i++ {
+			firstPage = append(firstPage, newSpace(i))
+		}
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "", question.NewAskProvider(qa.AsAsker()))
+		testutil.RequireSuccess(t, err)
+
+		clientReceiver := testutil.GoBegin2(func() (*octopusApiClient.Client, error) {
+			defer testutil.Close(api, qa)
+			return factory.GetSpacedClient(&apiclient.FakeRequesterContext{})
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/spaces?take=50").RespondWith(&resources.Resources[*spaces.Space]{Items: firstPage, PagedResults: resources.PagedResults{TotalResults: 5000}})
+		q := qa.ExpectQuestion(t, &survey.Select{
+			Message: "You have not specified a Space. Please select one (showing 50 of 5000):",
+			Options: append(spaceNames(firstPage), "Search for another space..."),
+		})
+		// the way out to a search is never filtered away
+		assert.True(t, q.Options.PromptConfig.Filter("4321", "Search for another space...", 50))
+		assert.False(t, q.Options.PromptConfig.Filter("4321", "Space 1", 0))
+		_ = q.AnswerWith("Search for another space...")
+		_ = qa.ExpectQuestion(t, &survey.Input{Message: "Space name, or part of it"}).AnswerWith("space 4321")
+		// a partial match comes first, but the exact name match is the one we want
+		api.ExpectRequest(t, "GET", "/api/spaces?partialName=space+4321&take=50").RespondWith(&resources.Resources[*spaces.Space]{
+			Items:        []*spaces.Space{newSpace(43210), newSpace(4321)},
+			PagedResults: resources.PagedResults{TotalResults: 2},
+		})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		api.ExpectRequest(t, "GET", "/api/Spaces-4321").RespondWith(newSpace(4321))
+
+		_, err = testutil.ReceivePair(clientReceiver)
+		assert.Nil(t, err)
+		assert.Equal(t, "Spaces-4321", factory.GetActiveSpace().ID)
+	})
+}
@@ -1,15 +1,27 @@
 package apiclient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/config"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/hashicorp/go-multierror"
 	"github.com/spf13/viper"
 
 	"net/http"
@@ -32,14 +44,32 @@ type ClientFactory interface {
 	// if you have not yet done so then it may return nil
 	GetActiveSpace() *spaces.Space
 
+	// HasSpace tells you whether a space has been given (by name or ID) or already selected, without looking
+	// anything up. If it's false, GetSpacedClient would have to prompt for a space, or fail
+	HasSpace() bool
+
 	// SetSpaceNameOrId replaces whichever space name or ID was picked up from the environment or selected
 	// interactively. This resets the internal cache inside the ClientFactory, meaning that the next time
-	// someone calls GetSpacedClient we will have to query the Octopus Server to look up spaceNameOrId,
-	// and any calls to GetActiveSpace before that will return nil
+	// someone calls GetSpacedClient we will have to look up spaceNameOrId (in the SpaceCache if it's there,
+	// otherwise on the Octopus Server), and any calls to GetActiveSpace before that will return nil
 	SetSpaceNameOrId(spaceNameOrId string)
 
 	// GetHostUrl returns the current set API URL as a string
 	GetHostUrl() string
+
+	// DisableSpaceCache stops GetSpacedClient from using the on-disk cache of space lookups,
+	// so the space is always looked up on the Octopus Server
+	DisableSpaceCache()
+
+	// SetCommandTimeout makes requests to the Octopus Server fail once timeout has passed from now
+	SetCommandTimeout(timeout time.Duration)
+
+	// CancelRequestsOn makes requests to the Octopus Server stop as soon as done is closed
+	CancelRequestsOn(done <-chan struct{})
+
+	// GetServerVersion returns the version of the Octopus Server, e.g. "2022.4.8471". It is read from the
+	// server's root document the first time it's asked for, and remembered after that
+	GetServerVersion(requester Requester) (string, error)
 }
 
 type Client struct {
@@ -55,11 +85,14 @@ type Client struct {
 	// Octopus API Client scoped to the current space. nullable, lazily created by Get()
 	SpaceScopedClient *octopusApiClient.Client
 
-	// the Server URL, obtained from OCTOPUS_URL
+	// the Server URL, obtained from OCTOPUS_URL. ParseHostUrl keeps any base path the server is hosted under,
+	// but never leaves a trailing slash, so that the SDK's URLs and our links come out the same either way
 	ApiUrl *url.URL
 	// the Octopus API Key, obtained from OCTOPUS_API_KEY
 	ApiKey string
-	// the Octopus SpaceNameOrID to work within. Obtained from OCTOPUS_SPACE (TODO: or --space=XYZ on the command line??)
+	// the Octopus SpaceNameOrID to work within. Obtained from OCTOPUS_SPACE, the profile or the config file,
+	// and replaced by SetSpaceNameOrId when --space=XYZ is given on the command line.
+	// May be a comma separated list, e.g. "MyTeam,Default", in which case the first space that exists is used
 	// Required for commands that need a space, but may be omitted for server-wide commands such as listing teams
 	SpaceNameOrID string
 
@@ -67,7 +100,25 @@ type Client struct {
 	// May be nil if we haven't done space lookup yet
 	ActiveSpace *spaces.Space
 
+	// Remembers space lookups between invocations. nil means no caching
+	SpaceCache *SpaceCache
+
+	// Notices if the server says our API key has expired, so ExplainError can tell the user. May be nil
+	ApiKeyExpiry *ApiKeyExpiryRoundTripper
+
+	// Notices if the server can't be reached, so ExplainError can say so plainly. May be nil
+	Connection *ConnectionRoundTripper
+
+	// Enforces the command's timeout on every request. May be nil, in which case there is no timeout
+	Deadline *DeadlineRoundTripper
+
 	Ask question.AskProvider
+
+	// With --debug, how the space was chosen is written here. nil means it isn't
+	DebugOut io.Writer
+
+	// the Octopus Server's version, once GetServerVersion has looked it up; empty until then
+	serverVersion string
 }
 
 func NewClientFactory(httpClient *http.Client, host string, apiKey string, spaceNameOrID string, ask question.AskProvider) (ClientFactory, error) {
@@ -83,7 +134,7 @@ func NewClientFactory(httpClient *http.Client, host string, apiKey string, space
 		return nil, cliErrors.NewArgumentNullOrEmptyError("ask")
 	}
 
-	hostUrl, err := url.Parse(host)
+	hostUrl, err := ParseHostUrl(host)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +152,13 @@ func NewClientFactory(httpClient *http.Client, host string, apiKey string, space
 	return clientImpl, nil
 }
 
+// ServerOverride and ApiKeyOverride are set by main from --server and --api-key, as given; an @path API key is read
+// when the client factory is made. They win over the environment variables, the profile and the config file.
+var (
+	ServerOverride string
+	ApiKeyOverride string
+)
+
 // NewClientFactoryFromConfig Creates a new Client wrapper structure by reading the viper config.
 // specifies nil for the HTTP Client, so this is not for unit tests; use NewClientFactory(... instead)
 func NewClientFactoryFromConfig(ask question.AskProvider) (ClientFactory, error) {
@@ -108,34 +166,255 @@ func NewClientFactoryFromConfig(ask question.AskProvider) (ClientFactory, error)
 	apiKey := viper.GetString(constants.ConfigApiKey)
 	spaceNameOrID := viper.GetString(constants.ConfigSpace)
 
-	errs := ValidateMandatoryEnvironment(host, apiKey)
+	if profileName := viper.GetString(constants.ConfigProfile); profileName != "" {
+		profile, err := config.LoadProfile(profileName)
+		if err != nil {
+			return nil, err
+		}
+		// environment variables still win over the profile, so existing automation keeps working
+		host = envOrDefault(constants.EnvOctopusUrl, profile.Url)
+		apiKey = envOrDefault(constants.EnvOctopusApiKey, profile.ApiKey)
+		spaceNameOrID = envOrDefault(constants.EnvOctopusSpace, profile.Space)
+	}
+	if ServerOverride != "" {
+		host = ServerOverride
+	}
+
+	// an access token is used instead of the API key when there is one, so there's no need to work the key out
+	accessToken := viper.GetString(constants.ConfigAccessToken)
+	if ApiKeyOverride != "" {
+		// asking for a particular key on the command line beats any other way of authenticating, including
+		// the API key command, but it may still name a file to read the key from
+		var err error
+		apiKey, err = ResolveApiKey(ApiKeyOverride, "")
+		if err != nil {
+			return nil, err
+		}
+		accessToken = ""
+	} else if accessToken == "" {
+		var err error
+		apiKey, err = ResolveApiKey(apiKey, viper.GetString(constants.ConfigApiKeyCommand))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	errs := ValidateMandatoryEnvironment(host, apiKey, accessToken)
 	if errs != nil {
 		return nil, errs
 	}
+	if accessToken != "" {
+		apiKey = AccessTokenPlaceholderApiKey
+	} else if !viper.GetBool(constants.ConfigSkipApiKeyCheck) {
+		if err := ValidateApiKey(apiKey); err != nil {
+			return nil, err
+		}
+	}
 
-	var httpClient *http.Client
-	if ask.IsInteractive() {
-		// spinner round-tripper only needed for interactive mode
-		httpClient = &http.Client{
-			Transport: NewSpinnerRoundTripper(),
+	retryCount, err := ParseRetryCount(viper.GetString(constants.ConfigRetryCount))
+	if err != nil {
+		return nil, err
+	}
+	httpTransport, err := NewHttpTransport(viper.GetBool(constants.ConfigSkipTlsVerify), viper.GetString(constants.ConfigCACert))
+	if err != nil {
+		return nil, err
+	}
+	var baseTransport http.RoundTripper = httpTransport
+	if viper.GetBool(constants.ConfigDebug) {
+		// innermost, so each retry is logged, along with the credentials (masked) actually sent
+		baseTransport = NewDebugRoundTripper(os.Stderr, httpTransport)
+	}
+	var authTransport http.RoundTripper = baseTransport
+	if accessToken != "" {
+		// inside the retries, so that every retry carries the token too
+		authTransport = NewAccessTokenRoundTripper(accessToken, baseTransport)
+	}
+	retryRoundTripper := NewRetryRoundTripper(authTransport)
+	retryRoundTripper.MaxAttempts = retryCount + 1
+
+	// outside the retries, so only a request which still couldn't get through after them counts
+	connectionRoundTripper := NewConnectionRoundTripper(retryRoundTripper)
+	apiKeyExpiryRoundTripper := NewApiKeyExpiryRoundTripper(connectionRoundTripper)
+	// the deadline goes outside the retries, so we don't keep retrying once the command has run out of time
+	deadlineRoundTripper := NewDeadlineRoundTripper(apiKeyExpiryRoundTripper)
+
+	var transport http.RoundTripper = deadlineRoundTripper
+	if ask.IsInteractive() && !output.IsQuiet {
+		// spinner round-tripper only needed for interactive mode, and not wanted under --quiet; it wraps the retries so it keeps spinning between them
+		spinnerRoundTripper := NewSpinnerRoundTripper()
+		spinnerRoundTripper.Next = transport
+		transport = spinnerRoundTripper
+	}
+	httpTimeout, err := ParseHttpTimeout(viper.GetString(constants.ConfigHttpTimeout))
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   httpTimeout, // zero means no timeout, which is what we've always done
+	}
+
+	clientFactory, err := NewClientFactory(httpClient, host, apiKey, spaceNameOrID, ask)
+	if err != nil {
+		return nil, err
+	}
+	clientFactory.(*Client).ApiKeyExpiry = apiKeyExpiryRoundTripper
+	clientFactory.(*Client).Connection = connectionRoundTripper
+	clientFactory.(*Client).Deadline = deadlineRoundTripper
+	if viper.GetBool(constants.ConfigDebug) {
+		clientFactory.(*Client).DebugOut = os.Stderr
+	}
+	if spaceCachePath, err := config.GetSpaceCachePath(); err == nil {
+		clientFactory.(*Client).SpaceCache = NewSpaceCache(spaceCachePath)
+	}
+	return clientFactory, nil
+}
+
+// NewHttpTransport returns the transport we talk to the Octopus Server with. It goes through the proxy given by
+// HTTP_PROXY/HTTPS_PROXY (honouring NO_PROXY).
+// If caCertPath is given, certificates issued by the CAs in that PEM file are trusted as well as the system ones.
+// If skipTlsVerify is set it will accept any TLS certificate at all, which is the insecure last resort.
+func NewHttpTransport(skipTlsVerify bool, caCertPath string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	if caCertPath != "" {
+		certPool, err := LoadCACertPool(caCertPath)
+		if err != nil {
+			return nil, err
 		}
+		transport.TLSClientConfig.RootCAs = certPool
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = skipTlsVerify
+	return transport, nil
+}
+
+// LoadCACertPool returns the system's trusted certificates plus those in the PEM file at path.
+func LoadCACertPool(path string) (*x509.CertPool, error) {
+	pemData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the CA certificate file '%s': %w", path, err)
+	}
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+	if !certPool.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("the CA certificate file '%s' does not contain any PEM encoded certificates", path)
+	}
+	return certPool, nil
+}
+
+// ParseHostUrl parses the address of the Octopus Server. A bare host such as octopus.example.com or octopus:8080
+// is taken to mean https, and trailing slashes are dropped so that the links we build from it don't have // in them.
+func ParseHostUrl(host string) (*url.URL, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("the Octopus Server address '%s' isn't valid; %s. Check %s, --%s or the %s config setting", host, reason, constants.EnvOctopusUrl, constants.FlagServer, constants.ConfigUrl)
+	}
+	value := strings.TrimSpace(host)
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	hostUrl, err := url.Parse(value)
+	if err != nil {
+		return nil, invalid("it should look like https://octopus.example.com")
 	}
+	if hostUrl.Scheme != "http" && hostUrl.Scheme != "https" {
+		return nil, invalid("it must start with http:// or https://")
+	}
+	if hostUrl.Hostname() == "" {
+		return nil, invalid("it has no host name")
+	}
+	if hostUrl.RawQuery != "" || hostUrl.Fragment != "" || hostUrl.User != nil {
+		return nil, invalid("it should be just the server's address, e.g. https://octopus.example.com")
+	}
+	hostUrl.Path = strings.TrimRight(hostUrl.Path, "/")
+	hostUrl.RawPath = ""
+	return hostUrl, nil
+}
 
-	return NewClientFactory(httpClient, host, apiKey, spaceNameOrID, ask)
+// ParseHttpTimeout parses the value of OCTOPUS_HTTP_TIMEOUT, which is a duration string such as "30s" or "2m".
+// An empty value means no timeout.
+func ParseHttpTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, &cliErrors.OsEnvironmentInvalidError{
+			EnvironmentVariable: constants.EnvHttpTimeout,
+			Value:               value,
+			Reason:              "it must be a positive duration such as 30s or 2m",
+		}
+	}
+	return timeout, nil
 }
 
-func ValidateMandatoryEnvironment(host string, apiKey string) error {
+// ParseRetryCount parses the value of OCTOPUS_RETRY_COUNT, which is how many times we retry a request
+// after a transient failure. An empty value means DefaultRetryCount, and 0 turns retrying off.
+func ParseRetryCount(value string) (int, error) {
+	if value == "" {
+		return DefaultRetryCount, nil
+	}
+	retryCount, err := strconv.Atoi(value)
+	if err != nil || retryCount < 0 {
+		return 0, &cliErrors.OsEnvironmentInvalidError{
+			EnvironmentVariable: constants.EnvRetryCount,
+			Value:               value,
+			Reason:              "it must be a whole number of zero or more",
+		}
+	}
+	return retryCount, nil
+}
 
-	if host == "" || apiKey == "" {
-		err := heredoc.Docf(`
-          To get started with Octopus CLI, please populate the %s and %s environment variables
-          Alternatively you can run:
-            octopus config set %s
-            octopus config set %s
-    `, constants.EnvOctopusUrl, constants.EnvOctopusApiKey, constants.ConfigUrl, constants.ConfigApiKey)
-		return fmt.Errorf(err)
+func envOrDefault(envVar string, defaultValue string) string {
+	if value := os.Getenv(envVar); value != "" {
+		return value
 	}
+	return defaultValue
+}
 
+// ValidateMandatoryEnvironment checks that we know which server to talk to, and have something to authenticate
+// with; either an API key or an access token will do. Everything that's missing is reported together, as one
+// error listing each setting with how to provide it.
+func ValidateMandatoryEnvironment(host string, apiKey string, accessToken string) error {
+	missing := &multierror.Error{ErrorFormat: formatMissingEnvironment}
+	if host == "" {
+		missing = multierror.Append(missing, fmt.Errorf("%s: the address of your Octopus Server, e.g. https://octopus.example.com; or run '%s config set %s'", constants.EnvOctopusUrl, constants.ExecutableName, constants.ConfigUrl))
+	}
+	if apiKey == "" && accessToken == "" {
+		missing = multierror.Append(missing, fmt.Errorf("%s: an API key, which you can create from your profile in the Octopus web portal; or run '%s config set %s'. In CI, you can set %s to an access token instead", constants.EnvOctopusApiKey, constants.ExecutableName, constants.ConfigApiKey, constants.EnvOctopusAccessToken))
+	}
+	return missing.ErrorOrNil()
+}
+
+// formatMissingEnvironment lists the settings ValidateMandatoryEnvironment found missing, one per line, rather
+// than multierror's default "N errors occurred" dump
+func formatMissingEnvironment(errs []error) string {
+	lines := []string{fmt.Sprintf("To get started with %s, please set these environment variables:", constants.ExecutableName)}
+	for _, err := range errs {
+		lines = append(lines, "  * "+err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Octopus API keys are API- followed by letters and numbers; they're 32 characters after the prefix, but we only
+// insist on enough of them to catch a truncated paste
+var apiKeyRE = regexp.MustCompile(`^API-[A-Za-z0-9]{20,}$`)
+
+// ValidateApiKey checks that apiKey at least looks like an Octopus API key, so that a mangled value fails
+// up front rather than as an unexplained 401 part way through a command. The key itself is never included
+// in the error.
+func ValidateApiKey(apiKey string) error {
+	override := fmt.Sprintf("If your Octopus Server issues keys in a different format, set %s=true to skip this check", constants.EnvSkipApiKeyCheck)
+	if !strings.HasPrefix(apiKey, "API-") {
+		return fmt.Errorf("the API key doesn't look right; Octopus API keys start with 'API-'. Check the value of %s or the %s config setting. %s", constants.EnvOctopusApiKey, constants.ConfigApiKey, override)
+	}
+	if !apiKeyRE.MatchString(apiKey) {
+		return fmt.Errorf("the API key doesn't look right; after 'API-' it should have at least 20 letters and numbers and nothing else, but it has %d characters. It may have been cut short or picked up extra characters when it was copied. %s", len(apiKey)-len("API-"), override)
+	}
 	return nil
 }
 
@@ -143,6 +422,10 @@ func (c *Client) GetActiveSpace() *spaces.Space {
 	return c.ActiveSpace
 }
 
+func (c *Client) HasSpace() bool {
+	return c.SpaceNameOrID != "" || c.ActiveSpace != nil
+}
+
 func (c *Client) GetHostUrl() string {
 	return c.ApiUrl.String()
 }
@@ -156,6 +439,28 @@ func (c *Client) SetSpaceNameOrId(spaceNameOrId string) {
 	c.SpaceScopedClient = nil
 	c.ActiveSpace = nil
 	c.SpaceNameOrID = spaceNameOrId
+
+	// the space may have been renamed or deleted since we cached it, so a miss means we start afresh
+	if c.SpaceCache.Get(c.GetHostUrl(), spaceNameOrId) == nil {
+		c.SpaceCache.Invalidate(c.GetHostUrl())
+	}
+}
+
+func (c *Client) SetCommandTimeout(timeout time.Duration) {
+	if c.Deadline != nil {
+		c.Deadline.SetTimeout(timeout)
+		c.debugf("requests to the Octopus Server time out after %s", timeout)
+	}
+}
+
+func (c *Client) CancelRequestsOn(done <-chan struct{}) {
+	if c.Deadline != nil {
+		c.Deadline.CancelOn(done)
+	}
+}
+
+func (c *Client) DisableSpaceCache() {
+	c.SpaceCache = nil
 }
 
 func (c *Client) GetSpacedClient(requester Requester) (*octopusApiClient.Client, error) {
@@ -163,46 +468,52 @@ func (c *Client) GetSpacedClient(requester Requester) (*octopusApiClient.Client,
 		return c.SpaceScopedClient, nil
 	}
 
+	var foundSpaceID string
+	// a previous invocation may have already looked this space up, which saves us loading every space from the server
+	if c.SpaceNameOrID != "" {
+		cachedSpace := c.SpaceCache.Get(c.GetHostUrl(), c.SpaceNameOrID)
+		if candidates := spaceCandidates(c.SpaceNameOrID); cachedSpace == nil && len(candidates) > 1 {
+			// only the first of a list can come from the cache; a miss doesn't tell us that space doesn't exist
+			cachedSpace = c.SpaceCache.Get(c.GetHostUrl(), candidates[0])
+		}
+		if cachedSpace != nil {
+			c.debugf("space %q: found %s (%s) in the space cache", c.SpaceNameOrID, cachedSpace.Name, cachedSpace.ID)
+			c.ActiveSpace = cachedSpace
+			c.SpaceNameOrID = cachedSpace.ID
+			foundSpaceID = cachedSpace.ID
+		}
+	}
+
 	// logic here is a bit fiddly:
 	// We could have been given either a space name, or a space ID, so we need to use the SystemClient to go look it up.
-	systemClient, err := c.GetSystemClient(requester)
-	if err != nil {
-		return nil, err
+	var systemClient *octopusApiClient.Client
+	if foundSpaceID == "" {
+		var err error
+		systemClient, err = c.GetSystemClient(requester)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// if the caller has not specified a space, prompt interactively
-	var foundSpaceID string
 	// if c.Ask is nil it means we're in automation mode.
 	if c.SpaceNameOrID == "" {
 		if !c.Ask.IsInteractive() {
 			return nil, errors.New("space must be specified when not running interactively; please set the OCTOPUS_SPACE environment variable or specify --space on the command line")
 		}
 
-		allSpaces, err := systemClient.Spaces.GetAll()
+		selectedSpace, allSpaces, err := promptForSpace(c.Ask.Ask, systemClient)
 		if err != nil {
 			return nil, err
 		}
-
-		switch len(allSpaces) {
-		case 0:
-			return nil, errors.New("no spaces found")
-		case 1:
-			selectedSpace := allSpaces[0]
-			c.ActiveSpace = selectedSpace
-			c.SpaceNameOrID = selectedSpace.ID
-			foundSpaceID = selectedSpace.ID
-		default:
-			selectedSpace, err := question.SelectMap(
-				c.Ask.Ask,
-				"You have not specified a Space. Please select one:", allSpaces, func(item *spaces.Space) string { return item.GetName() })
-
-			if err != nil {
-				return nil, err
-			}
-			c.ActiveSpace = selectedSpace
-			c.SpaceNameOrID = selectedSpace.ID
-			foundSpaceID = selectedSpace.ID
+		// only a complete list can replace what's cached; a partial one would forget spaces we know about
+		if allSpaces != nil {
+			c.SpaceCache.Refresh(c.GetHostUrl(), allSpaces)
 		}
+		c.debugf("space: %s (%s) was chosen at the prompt", selectedSpace.Name, selectedSpace.ID)
+		c.ActiveSpace = selectedSpace
+		c.SpaceNameOrID = selectedSpace.ID
+		foundSpaceID = selectedSpace.ID
 	}
 
 	if foundSpaceID == "" {
@@ -213,26 +524,50 @@ func (c *Client) GetSpacedClient(requester Requester) (*octopusApiClient.Client,
 		if err != nil {
 			return nil, fmt.Errorf("cannot load spaces. Error: %v", err)
 		}
+		c.SpaceCache.Refresh(c.GetHostUrl(), allSpaces)
 
-		var foundSpace *spaces.Space = nil
-		var foundSpaceByID *spaces.Space = nil // second-tier match, only use this if foundSpace is nilt
-		for _, space := range allSpaces {
-			if strings.EqualFold(space.Name, c.SpaceNameOrID) { // direct hit on the name, this is the one we want
-				foundSpace = space
-				break
-			}
-			if strings.EqualFold(space.ID, c.SpaceNameOrID) { // hit on the ID; we prefer name so keep this as a fallback
-				foundSpaceByID = space
+		if c.DebugOut != nil {
+			considered := make([]string, len(allSpaces))
+			for i, space := range allSpaces {
+				considered[i] = fmt.Sprintf("%s (%s)", space.Name, space.ID)
 			}
+			c.debugf("space %q: considering %d spaces: %s", c.SpaceNameOrID, len(allSpaces), strings.Join(considered, ", "))
 		}
-		if foundSpace == nil && foundSpaceByID != nil {
-			foundSpace = foundSpaceByID
+
+		// the whole value is tried first, so a space with a comma in its name can still be given
+		foundSpace := c.findSpace(allSpaces, c.SpaceNameOrID)
+		if candidates := spaceCandidates(c.SpaceNameOrID); foundSpace == nil && len(candidates) > 1 {
+			for _, candidate := range candidates {
+				if foundSpace = c.findSpace(allSpaces, candidate); foundSpace != nil {
+					break
+				}
+			}
+			if foundSpace == nil {
+				var suggestions []string
+				for _, candidate := range candidates {
+					for _, name := range closestSpaceNames(allSpaces, candidate) {
+						if !util.SliceContains(suggestions, name) {
+							suggestions = append(suggestions, name)
+						}
+					}
+				}
+				if len(suggestions) > maxSpaceSuggestions {
+					suggestions = suggestions[:maxSpaceSuggestions]
+				}
+				return nil, &cliErrors.SpaceNotFoundError{SpaceNameOrID: c.SpaceNameOrID, Suggestions: suggestions}
+			}
 		}
 
 		if foundSpace == nil {
-			return nil, fmt.Errorf("cannot find space '%s'", c.SpaceNameOrID)
+			// spaces/all only lists the spaces the API key can see, so ask for the space directly to tell
+			// "no such space" apart from "you're not allowed in"
+			if isSpaceForbidden(systemClient, c.SpaceNameOrID) {
+				return nil, fmt.Errorf("space '%s' exists, but your API key does not have access to it; ask an Octopus administrator to add you to a team in that space, or use a different space", c.SpaceNameOrID)
+			}
+			return nil, &cliErrors.SpaceNotFoundError{SpaceNameOrID: c.SpaceNameOrID, Suggestions: closestSpaceNames(allSpaces, c.SpaceNameOrID)}
 		}
 		// ok we found a space
+		c.debugf("space %q: using %s", c.SpaceNameOrID, foundSpace.ID)
 		c.ActiveSpace = foundSpace
 		c.SpaceNameOrID = foundSpace.ID
 		foundSpaceID = foundSpace.ID
@@ -248,6 +583,104 @@ func (c *Client) GetSpacedClient(requester Requester) (*octopusApiClient.Client,
 	return scopedClient, nil
 }
 
+func (c *Client) GetServerVersion(requester Requester) (string, error) {
+	if c.serverVersion != "" {
+		return c.serverVersion, nil
+	}
+	systemClient, err := c.GetSystemClient(requester)
+	if err != nil {
+		return "", err
+	}
+	root, err := systemClient.Root.Get()
+	if err != nil {
+		return "", err
+	}
+	c.serverVersion = root.Version
+	return c.serverVersion, nil
+}
+
+// findSpace returns the space called spaceNameOrID, or failing that, the one with that ID
+func (c *Client) findSpace(allSpaces []*spaces.Space, spaceNameOrID string) *spaces.Space {
+	var foundSpaceByID *spaces.Space = nil // second-tier match, only use this if there's no match on the name
+	for _, space := range allSpaces {
+		if strings.EqualFold(space.Name, spaceNameOrID) { // direct hit on the name, this is the one we want
+			c.debugf("space %q: matched the name of %s", spaceNameOrID, space.ID)
+			return space
+		}
+		if strings.EqualFold(space.ID, spaceNameOrID) { // hit on the ID; we prefer name so keep this as a fallback
+			foundSpaceByID = space
+		}
+	}
+	if foundSpaceByID != nil {
+		c.debugf("space %q: no space has that name, so matched the ID of %s (%s)", spaceNameOrID, foundSpaceByID.Name, foundSpaceByID.ID)
+	} else {
+		c.debugf("space %q: matched neither a name nor an ID", spaceNameOrID)
+	}
+	return foundSpaceByID
+}
+
+// debugf writes a line for --debug, marked the same way as DebugRoundTripper's
+func (c *Client) debugf(format string, args ...any) {
+	if c.DebugOut != nil {
+		fmt.Fprintf(c.DebugOut, "[debug] "+format+"\n", args...)
+	}
+}
+
+// spaceCandidates splits a comma separated list of spaces to try in order. A single space comes back on its own
+func spaceCandidates(spaceNameOrID string) []string {
+	var candidates []string
+	for _, candidate := range strings.Split(spaceNameOrID, ",") {
+		if candidate = strings.TrimSpace(candidate); candidate != "" {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// maxSpaceSuggestions is how many near misses a SpaceNotFoundError offers
+const maxSpaceSuggestions = 3
+
+// closestSpaceNames returns the names of the spaces which are a small edit away from spaceNameOrID, nearest
+// first. Allowing one edit in every three characters catches typos without suggesting unrelated short names
+func closestSpaceNames(allSpaces []*spaces.Space, spaceNameOrID string) []string {
+	maxDistance := len([]rune(spaceNameOrID))/3 + 1
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for _, space := range allSpaces {
+		distance := util.LevenshteinDistance(strings.ToLower(space.Name), strings.ToLower(spaceNameOrID))
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{name: space.Name, distance: distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+	if len(candidates) > maxSpaceSuggestions {
+		candidates = candidates[:maxSpaceSuggestions]
+	}
+	return util.SliceTransform(candidates, func(c candidate) string { return c.name })
+}
+
+// spaceIdRE matches space IDs such as Spaces-1
+var spaceIdRE = regexp.MustCompile(`(?i)^Spaces-\d+$`)
+
+// isSpaceForbidden reports whether the server refused a direct lookup of the space with 403 Forbidden.
+// Only IDs can be checked: the server looks spaces up directly by ID alone, and searching by name only ever
+// returns the spaces the API key can already see, so a name which isn't in spaces/all is simply not found.
+// The SDK folds every status but 404 into the same error, so we go through sling to see the status code.
+func isSpaceForbidden(systemClient *octopusApiClient.Client, spaceNameOrID string) bool {
+	if !spaceIdRE.MatchString(spaceNameOrID) {
+		return false
+	}
+	path, err := services.GetByIDPath(systemClient.Spaces, spaceNameOrID)
+	if err != nil {
+		return false
+	}
+	resp, err := systemClient.Spaces.GetClient().New().Get(path).Receive(nil, nil)
+	return err == nil && resp.StatusCode == http.StatusForbidden
+}
+
 func (c *Client) GetSystemClient(requester Requester) (*octopusApiClient.Client, error) {
 	// Internal quirks of the go-octopusdeploy API SDK:
 	// A space-scoped client can do System level things perfectly well, but the inverse is not true.
@@ -291,6 +724,18 @@ func (s *stubClientFactory) GetSystemClient(requester Requester) (*octopusApiCli
 
 func (s *stubClientFactory) GetActiveSpace() *spaces.Space { return nil }
 
+func (s *stubClientFactory) HasSpace() bool { return false }
+
 func (s *stubClientFactory) SetSpaceNameOrId(_ string) {}
 
 func (s *stubClientFactory) GetHostUrl() string { return "" }
+
+func (s *stubClientFactory) DisableSpaceCache() {}
+
+func (s *stubClientFactory) SetCommandTimeout(_ time.Duration) {}
+
+func (s *stubClientFactory) CancelRequestsOn(_ <-chan struct{}) {}
+
+func (s *stubClientFactory) GetServerVersion(_ Requester) (string, error) {
+	return "", errors.New("app is not configured correctly")
+}
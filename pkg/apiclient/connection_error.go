@@ -0,0 +1,77 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// ConnectionError is raised when we can't reach the Octopus Server at all, e.g. its host name doesn't resolve or
+// nothing is listening there. The network error itself is kept in Err, and is logged with --debug.
+type ConnectionError struct {
+	Host   string
+	Reason string
+	Err    error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("cannot connect to the Octopus Server at %s; %s.\n", e.Host, e.Reason) +
+		"Check that OCTOPUS_URL or --server is the right address, and that this machine can reach it over the network."
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// ConnectionRoundTripper watches for requests which couldn't reach the server.
+// The SDK flattens transport errors into plain strings, so like ApiKeyExpiryRoundTripper it remembers the failure,
+// and ExplainError swaps in the ConnectionError once the command has failed.
+type ConnectionRoundTripper struct {
+	Next http.RoundTripper
+
+	// the last request which couldn't reach the server; nil until one fails, and again once one gets through
+	Failed *ConnectionError
+}
+
+func NewConnectionRoundTripper(next http.RoundTripper) *ConnectionRoundTripper {
+	return &ConnectionRoundTripper{Next: next}
+}
+
+func (c *ConnectionRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := c.Next.RoundTrip(r)
+	if err == nil {
+		c.Failed = nil
+		return resp, nil
+	}
+	if reason := connectionFailure(err); reason != "" {
+		c.Failed = &ConnectionError{Host: r.URL.Scheme + "://" + r.URL.Host, Reason: reason, Err: err}
+	}
+	return resp, err
+}
+
+// connectionFailure describes why err means the server couldn't be reached, or returns an empty string if it's
+// some other error
+func connectionFailure(err error) string {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "" // the command was cancelled or ran out of time, which it explains itself
+	}
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return "its host name could not be found"
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return "the connection was refused"
+	}
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return "the connection timed out"
+	}
+	var opError *net.OpError
+	if errors.As(err, &opError) && opError.Op == "dial" {
+		return "the connection failed"
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package apiclient_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingTransport struct{ err error }
+
+func (f *failingTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, f.err
+}
+
+func TestConnectionError(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+	}{
+		{"host name doesn't resolve", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "octopus.example", IsNotFound: true}}, "its host name could not be found"},
+		{"connection refused", refused, "the connection was refused"},
+		{"dial timed out", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ETIMEDOUT)}, "the connection timed out"},
+		{"command cancelled", context.Canceled, ""},
+		{"command ran out of time", &apiclient.TimeoutError{Timeout: time.Minute, Err: context.DeadlineExceeded}, ""},
+		{"something else", errors.New("boom"), ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			connection := apiclient.NewConnectionRoundTripper(&failingTransport{test.err})
+			factory, err := apiclient.NewClientFactory(&http.Client{Transport: connection}, serverUrl, placeholderApiKey, "", qa)
+			testutil.RequireSuccess(t, err)
+			client := factory.(*apiclient.Client)
+			client.Connection = connection
+			debugOut := &bytes.Buffer{}
+			client.DebugOut = debugOut
+
+			_, err = factory.GetSystemClient(&apiclient.FakeRequesterContext{})
+			assert.NotNil(t, err)
+
+			explained := apiclient.ExplainError(factory, err)
+			var connectionError *apiclient.ConnectionError
+			if test.wantReason == "" {
+				assert.False(t, errors.As(explained, &connectionError))
+				return
+			}
+			assert.True(t, errors.As(explained, &connectionError))
+			assert.Equal(t, "cannot connect to the Octopus Server at http://server; "+test.wantReason+".\n"+
+				"Check that OCTOPUS_URL or --server is the right address, and that this machine can reach it over the network.", explained.Error())
+			// the network error is kept, and shown with --debug
+			assert.ErrorIs(t, explained, test.err)
+			assert.Contains(t, debugOut.String(), "[debug] connecting to http://server failed: "+test.err.Error())
+		})
+	}
+
+	t.Run("forgets the failure once a request gets through", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		connection := apiclient.NewConnectionRoundTripper(api)
+		connection.Failed = &apiclient.ConnectionError{Host: "http://server", Reason: "the connection was refused", Err: refused}
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(connection), serverUrl, placeholderApiKey, "", qa)
+		testutil.RequireSuccess(t, err)
+		factory.(*apiclient.Client).Connection = connection
+
+		clientReceiver := testutil.GoBegin(func() error {
+			defer api.Close()
+			_, err := factory.GetSystemClient(&apiclient.FakeRequesterContext{})
+			return err
+		})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(root)
+		assert.Nil(t, <-clientReceiver)
+		assert.Nil(t, connection.Failed)
+
+		err = errors.New("boom")
+		assert.Same(t, err, apiclient.ExplainError(factory, err))
+	})
+}
@@ -0,0 +1,103 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+)
+
+// DeadlineRoundTripper gives every request the same deadline, so that the command as a whole gives up once its
+// timeout has passed rather than each request getting its own allowance. It also cancels whatever request is in
+// flight when the command is cancelled, e.g. by Ctrl-C. With neither set up it does nothing.
+type DeadlineRoundTripper struct {
+	Next http.RoundTripper
+
+	timeout  time.Duration
+	deadline time.Time
+	done     <-chan struct{}
+}
+
+func NewDeadlineRoundTripper(next http.RoundTripper) *DeadlineRoundTripper {
+	return &DeadlineRoundTripper{Next: next}
+}
+
+// SetTimeout starts the clock; requests made more than timeout from now will fail
+func (c *DeadlineRoundTripper) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.deadline = time.Now().Add(timeout)
+}
+
+// CancelOn makes requests fail as soon as done is closed. A nil channel is never closed
+func (c *DeadlineRoundTripper) CancelOn(done <-chan struct{}) {
+	c.done = done
+}
+
+func (c *DeadlineRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	if c.deadline.IsZero() && c.done == nil {
+		return c.Next.RoundTrip(r)
+	}
+	ctx, cancel := c.requestContext(r.Context())
+	resp, err := c.Next.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if !c.deadline.IsZero() && errors.Is(err, context.DeadlineExceeded) {
+			return nil, &TimeoutError{Timeout: c.timeout, Err: err}
+		}
+		return nil, err
+	}
+	// the body is read after we return, so the context has to live until the caller is done with it
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// requestContext is parent with the command's deadline, which is also cancelled if the command is
+func (c *DeadlineRoundTripper) requestContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	if c.done != nil {
+		go func() {
+			select {
+			case <-c.done:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	if c.deadline.IsZero() {
+		return ctx, cancel
+	}
+	ctx, cancelDeadline := context.WithDeadline(ctx, c.deadline)
+	return ctx, func() {
+		cancelDeadline()
+		cancel()
+	}
+}
+
+// TimeoutError is how a command reports that it ran out of time, whether an HTTP request or something
+// waiting on the command's context was cut short
+type TimeoutError struct {
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("operation timed out after %s; use --%s to allow longer", e.Timeout, constants.FlagTimeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
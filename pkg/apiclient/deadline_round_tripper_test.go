@@ -0,0 +1,54 @@
+package apiclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadlineRoundTripper(t *testing.T) {
+	// a server which never answers, so the only way out is the request's context
+	hang := testutil.RoundTripper(func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})
+
+	t.Run("fails requests once the timeout has passed", func(t *testing.T) {
+		rt := apiclient.NewDeadlineRoundTripper(hang)
+		rt.SetTimeout(10 * time.Millisecond)
+		req, _ := http.NewRequest("GET", "http://server/api", nil)
+
+		_, err := rt.RoundTrip(req)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.EqualError(t, err, "operation timed out after 10ms; use --timeout to allow longer")
+	})
+
+	t.Run("does nothing without a timeout", func(t *testing.T) {
+		rt := apiclient.NewDeadlineRoundTripper(testutil.RoundTripper(func(r *http.Request) (*http.Response, error) {
+			_, hasDeadline := r.Context().Deadline()
+			assert.False(t, hasDeadline)
+			return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+		}))
+		req, _ := http.NewRequest("GET", "http://server/api", nil)
+
+		resp, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+	t.Run("fails requests once the command is cancelled", func(t *testing.T) {
+		rt := apiclient.NewDeadlineRoundTripper(hang)
+		done := make(chan struct{})
+		rt.CancelOn(done)
+		req, _ := http.NewRequest("GET", "http://server/api", nil)
+
+		close(done)
+		_, err := rt.RoundTrip(req)
+		assert.Equal(t, context.Canceled, err)
+	})
+}
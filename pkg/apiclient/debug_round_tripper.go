@@ -0,0 +1,100 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/clock"
+	"github.com/OctopusDeploy/cli/pkg/output"
+)
+
+// redactedHeaders carry credentials, so --debug never shows their values
+var redactedHeaders = []string{"X-Octopus-Apikey", "Authorization", "Cookie", "Set-Cookie"}
+
+// DebugRoundTripper writes each request and its outcome to Out for --debug: the method and URL, the request
+// headers and JSON body, then the status and how long it took. Credentials in headers and sensitive values
+// in the body are masked. Response bodies aren't shown, as they can hold secrets we can't recognise.
+type DebugRoundTripper struct {
+	Out   io.Writer
+	Next  http.RoundTripper
+	Clock clock.Clock
+}
+
+func NewDebugRoundTripper(out io.Writer, next http.RoundTripper) *DebugRoundTripper {
+	return &DebugRoundTripper{Out: out, Next: next, Clock: clock.Real}
+}
+
+func (d *DebugRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	lines := []string{fmt.Sprintf("%s %s", r.Method, r.URL.Redacted())}
+	lines = append(lines, debugHeaders(r.Header)...)
+
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// a RoundTripper mustn't modify the request it's given, so the body goes on a copy
+		r = r.Clone(r.Context())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+		lines = append(lines, "> "+debugBody(body))
+	}
+
+	start := d.Clock.Now()
+	resp, err := d.Next.RoundTrip(r)
+	elapsed := d.Clock.Now().Sub(start).Round(time.Millisecond)
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("< failed after %s: %v", elapsed, err))
+	} else {
+		lines = append(lines, fmt.Sprintf("< %s (%s)", resp.Status, elapsed))
+	}
+
+	var buf strings.Builder
+	for _, line := range lines {
+		buf.WriteString("[debug] " + line + "\n")
+	}
+	_, _ = io.WriteString(d.Out, buf.String())
+	return resp, err
+}
+
+func debugHeaders(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		for _, redacted := range redactedHeaders {
+			if strings.EqualFold(name, redacted) {
+				value = output.MaskedValue
+				break
+			}
+		}
+		lines = append(lines, fmt.Sprintf("> %s: %s", name, value))
+	}
+	return lines
+}
+
+// debugBody shows a JSON body with its sensitive values masked. Anything else could hold a secret we can't
+// find, so only its size is shown.
+func debugBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("(%d bytes)", len(body))
+	}
+	masked, err := json.Marshal(output.MaskSensitiveValues(v))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes)", len(body))
+	}
+	return string(masked)
+}
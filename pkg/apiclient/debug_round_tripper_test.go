@@ -0,0 +1,82 @@
+package apiclient_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugRoundTripper(t *testing.T) {
+	newRoundTripper := func(next testutil.RoundTripper) (*apiclient.DebugRoundTripper, *bytes.Buffer) {
+		out := &bytes.Buffer{}
+		fakeClock := testutil.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		rt := apiclient.NewDebugRoundTripper(out, testutil.RoundTripper(func(r *http.Request) (*http.Response, error) {
+			fakeClock.Advance(150 * time.Millisecond)
+			return next(r)
+		}))
+		rt.Clock = fakeClock
+		return rt, out
+	}
+
+	t.Run("logs the request and response with credentials masked", func(t *testing.T) {
+		var sentBody string
+		rt, out := newRoundTripper(func(r *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(r.Body)
+			sentBody = string(data)
+			return &http.Response{Status: "201 Created", StatusCode: 201, Body: http.NoBody}, nil
+		})
+		body := `{"Name":"Web deploy","PrivateKeyPassphrase":{"HasValue":true,"NewValue":"hunter2"}}`
+		req, _ := http.NewRequest("POST", "http://server/api/Spaces-1/accounts", strings.NewReader(body))
+		req.Header.Set("X-Octopus-ApiKey", "API-SECRETXXXXXXXXXXXXXXXXXXXXXXX")
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		// the body still reaches the server intact
+		assert.Equal(t, body, sentBody)
+		assert.Equal(t, heredoc.Doc(`
+			[debug] POST http://server/api/Spaces-1/accounts
+			[debug] > Content-Type: application/json
+			[debug] > X-Octopus-Apikey: ***
+			[debug] > {"Name":"Web deploy","PrivateKeyPassphrase":{"HasValue":true,"NewValue":"***"}}
+			[debug] < 201 Created (150ms)
+		`), out.String())
+	})
+
+	t.Run("logs failures", func(t *testing.T) {
+		rt, out := newRoundTripper(func(r *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})
+		req, _ := http.NewRequest("GET", "http://server/api", nil)
+		req.Header.Set("Authorization", "Bearer token")
+
+		_, err := rt.RoundTrip(req)
+		assert.EqualError(t, err, "connection refused")
+		assert.Equal(t, heredoc.Doc(`
+			[debug] GET http://server/api
+			[debug] > Authorization: ***
+			[debug] < failed after 150ms: connection refused
+		`), out.String())
+	})
+
+	t.Run("shows only the size of a body which isn't JSON", func(t *testing.T) {
+		rt, out := newRoundTripper(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{Status: "200 OK", StatusCode: 200, Body: http.NoBody}, nil
+		})
+		req, _ := http.NewRequest("POST", "http://server/api/packages/raw", strings.NewReader("password=hunter2"))
+
+		_, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Contains(t, out.String(), "[debug] > (16 bytes)\n")
+		assert.NotContains(t, out.String(), "hunter2")
+	})
+}
@@ -0,0 +1,56 @@
+package apiclient_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientFactoryFromConfig_Overrides(t *testing.T) {
+	t.Setenv(constants.EnvOctopusUrl, "http://from-environment")
+	t.Setenv(constants.EnvOctopusAccessToken, "eyJhbGciOiJSUzI1NiJ9.token")
+	_ = viper.BindEnv(constants.ConfigUrl, constants.EnvOctopusUrl)
+	_ = viper.BindEnv(constants.ConfigAccessToken, constants.EnvOctopusAccessToken)
+
+	apiclient.ServerOverride = "http://from-flag"
+	apiclient.ApiKeyOverride = "API-FROMFLAGXXXXXXXXXXXXXXXXXXXXXX"
+	defer func() {
+		apiclient.ServerOverride = ""
+		apiclient.ApiKeyOverride = ""
+	}()
+
+	askProvider := question.NewAskProvider(nil)
+	askProvider.DisableInteractive()
+	factory, err := apiclient.NewClientFactoryFromConfig(askProvider)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "http://from-flag", factory.GetHostUrl())
+	// the key given on the command line is used, rather than the access token from the environment
+	assert.Equal(t, "API-FROMFLAGXXXXXXXXXXXXXXXXXXXXXX", factory.(*apiclient.Client).ApiKey)
+}
+
+func TestNewClientFactoryFromConfig_ApiKeyOverrideFromFile(t *testing.T) {
+	t.Setenv(constants.EnvOctopusUrl, "http://from-environment")
+	t.Setenv(constants.EnvOctopusApiKeyCommand, "false")
+	_ = viper.BindEnv(constants.ConfigUrl, constants.EnvOctopusUrl)
+	_ = viper.BindEnv(constants.ConfigApiKeyCommand, constants.EnvOctopusApiKeyCommand)
+
+	keyFile := filepath.Join(t.TempDir(), "octopus")
+	assert.Nil(t, os.WriteFile(keyFile, []byte("API-FROMFILEXXXXXXXXXXXXXXXXXXXXXX\n"), 0600))
+	apiclient.ApiKeyOverride = apiclient.ApiKeyFilePrefix + keyFile
+	defer func() { apiclient.ApiKeyOverride = "" }()
+
+	askProvider := question.NewAskProvider(nil)
+	askProvider.DisableInteractive()
+	factory, err := apiclient.NewClientFactoryFromConfig(askProvider)
+	assert.Nil(t, err)
+
+	// the file named by --api-key is read, and the API key command isn't run
+	assert.Equal(t, "API-FROMFILEXXXXXXXXXXXXXXXXXXXXXX", factory.(*apiclient.Client).ApiKey)
+}
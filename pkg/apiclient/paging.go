@@ -0,0 +1,78 @@
+package apiclient
+
+import (
+	"fmt"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+)
+
+// DefaultPageSize is how many items ForEachPage asks for at a time, unless the caller says otherwise
+const DefaultPageSize = 100
+
+// PageFunc fetches take items from a collection, starting after the first skip
+type PageFunc[T any] func(skip int, take int) (*resources.Resources[T], error)
+
+// ProgressReporter is told how far a paged fetch has got, so the user can see it while waiting for the rest.
+// total is how many items the server said there were, which can change while we page through them; it is
+// 0 or less if the server didn't say.
+type ProgressReporter interface {
+	ShowProgress(fetched int, total int)
+	ClearProgress()
+}
+
+type noProgress struct{}
+
+func (noProgress) ShowProgress(int, int) {}
+func (noProgress) ClearProgress()        {}
+
+// ProgressFor returns the ProgressReporter for requests made with octopus, which shows progress beside the spinner.
+// If octopus doesn't have a spinner, e.g. because we aren't interactive, progress isn't shown anywhere.
+func ProgressFor(octopus *client.Client) ProgressReporter {
+	if octopus != nil && octopus.HttpSession() != nil && octopus.HttpSession().HttpClient != nil {
+		if reporter, ok := octopus.HttpSession().HttpClient.Transport.(ProgressReporter); ok {
+			return reporter
+		}
+	}
+	return noProgress{}
+}
+
+// FormatProgress describes how far a paged fetch has got, or returns "" if the total isn't known, as
+// a count on its own says little about how long is left.
+func FormatProgress(fetched int, total int) string {
+	if total <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" fetched %d of ~%d", fetched, total)
+}
+
+// ForEachPage fetches a collection from the server a page at a time, calling fn with the items in each page as
+// it arrives, rather than loading the whole collection into memory the way GetAll and GetAllPages do. fn
+// returns false to stop early, e.g. once a --limit has been reached, so no more pages are fetched.
+// A pageSize of 0 or less means DefaultPageSize
+func ForEachPage[T any](pageSize int, getPage PageFunc[T], fn func(items []T) (bool, error)) error {
+	return ForEachPageWithProgress(pageSize, noProgress{}, getPage, fn)
+}
+
+// ForEachPageWithProgress is ForEachPage, telling progress how many items have been fetched after each page
+func ForEachPageWithProgress[T any](pageSize int, progress ProgressReporter, getPage PageFunc[T], fn func(items []T) (bool, error)) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	defer progress.ClearProgress()
+	for skip := 0; ; {
+		page, err := getPage(skip, pageSize)
+		if err != nil {
+			return err
+		}
+		more, err := fn(page.Items)
+		if err != nil || !more {
+			return err
+		}
+		skip += len(page.Items)
+		if len(page.Items) == 0 || skip >= page.TotalResults {
+			return nil
+		}
+		progress.ShowProgress(skip, page.TotalResults)
+	}
+}
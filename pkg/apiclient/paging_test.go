@@ -0,0 +1,129 @@
+package apiclient_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachPage(t *testing.T) {
+	// a collection of 5 numbers, served up however the caller asks
+	getPage := func(requests *[][2]int) apiclient.PageFunc[int] {
+		all := []int{1, 2, 3, 4, 5}
+		return func(skip int, take int) (*resources.Resources[int], error) {
+			*requests = append(*requests, [2]int{skip, take})
+			end := skip + take
+			if end > len(all) {
+				end = len(all)
+			}
+			return &resources.Resources[int]{Items: all[skip:end], PagedResults: resources.PagedResults{TotalResults: len(all)}}, nil
+		}
+	}
+
+	t.Run("calls back with each page until the collection runs out", func(t *testing.T) {
+		var requests [][2]int
+		var pages [][]int
+		err := apiclient.ForEachPage(2, getPage(&requests), func(items []int) (bool, error) {
+			pages = append(pages, items)
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, pages)
+		assert.Equal(t, [][2]int{{0, 2}, {2, 2}, {4, 2}}, requests)
+	})
+
+	t.Run("stops fetching when the callback says so", func(t *testing.T) {
+		var requests [][2]int
+		err := apiclient.ForEachPage(2, getPage(&requests), func(items []int) (bool, error) {
+			return false, nil
+		})
+		assert.Nil(t, err)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("uses the default page size", func(t *testing.T) {
+		var requests [][2]int
+		err := apiclient.ForEachPage(0, getPage(&requests), func(items []int) (bool, error) {
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, [][2]int{{0, apiclient.DefaultPageSize}}, requests)
+	})
+
+	t.Run("returns the callback's error", func(t *testing.T) {
+		var requests [][2]int
+		err := apiclient.ForEachPage(2, getPage(&requests), func(items []int) (bool, error) {
+			return true, errors.New("broken pipe")
+		})
+		assert.EqualError(t, err, "broken pipe")
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("reports progress after each page but the last, then clears it", func(t *testing.T) {
+		var requests [][2]int
+		progress := &recordingProgress{}
+		err := apiclient.ForEachPageWithProgress(2, progress, getPage(&requests), func(items []int) (bool, error) {
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{" fetched 2 of ~5", " fetched 4 of ~5", "cleared"}, progress.updates)
+	})
+}
+
+type recordingProgress struct {
+	updates []string
+}
+
+func (p *recordingProgress) ShowProgress(fetched int, total int) {
+	p.updates = append(p.updates, apiclient.FormatProgress(fetched, total))
+}
+
+func (p *recordingProgress) ClearProgress() {
+	p.updates = append(p.updates, "cleared")
+}
+
+func TestFormatProgress(t *testing.T) {
+	assert.Equal(t, " fetched 100 of ~2500", apiclient.FormatProgress(100, 2500))
+	// with no total, the spinner is shown on its own as before
+	assert.Equal(t, "", apiclient.FormatProgress(100, 0))
+}
+
+func TestProgressFor(t *testing.T) {
+	serverUrl, _ := url.Parse("http://server")
+	newClient := func(t *testing.T, transport http.RoundTripper, api *testutil.MockHttpServer) *client.Client {
+		receiver := testutil.GoBegin2(func() (*client.Client, error) {
+			defer api.Close()
+			return client.NewClient(&http.Client{Transport: transport}, serverUrl, "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX", "")
+		})
+		api.ExpectRequest(t, "GET", "/api").RespondWith(testutil.NewRootResource())
+		octopus, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, err)
+		return octopus
+	}
+
+	t.Run("reports on the spinner when the client has one", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		spinnerRoundTripper := apiclient.NewSpinnerRoundTripper()
+		spinnerRoundTripper.Next = api
+
+		progress := apiclient.ProgressFor(newClient(t, spinnerRoundTripper, api))
+		assert.Same(t, spinnerRoundTripper, progress)
+		progress.ShowProgress(100, 2500)
+		assert.Equal(t, " fetched 100 of ~2500", spinnerRoundTripper.Spinner.Suffix)
+		progress.ClearProgress()
+		assert.Equal(t, "", spinnerRoundTripper.Spinner.Suffix)
+	})
+
+	t.Run("does nothing without a spinner", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		progress := apiclient.ProgressFor(newClient(t, api, api))
+		assert.NotPanics(t, func() { progress.ShowProgress(1, 2) })
+	})
+}
@@ -0,0 +1,162 @@
+package apiclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/clock"
+)
+
+const (
+	DefaultRetryCount = 2 // retries after the first attempt
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+// PermanentNetworkError is raised when we couldn't talk to the Octopus Server for a reason that won't go away
+// by trying again, such as the host name not resolving or the server's certificate not being trusted.
+type PermanentNetworkError struct {
+	Reason string
+	Err    error
+}
+
+func (e *PermanentNetworkError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *PermanentNetworkError) Unwrap() error { return e.Err }
+
+// IsTransientNetworkError tells you whether err is the kind of network failure which is likely to succeed
+// if we try again shortly, e.g. a connection refused or reset while the server is restarting, or a timeout.
+func IsTransientNetworkError(err error) bool {
+	if err == nil || permanentNetworkErrorReason(err) != "" {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) {
+		return true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true // the server hung up on us mid-request
+	}
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return dnsError.IsTimeout || dnsError.IsTemporary
+	}
+	var netError net.Error
+	return errors.As(err, &netError) && netError.Timeout()
+}
+
+// permanentNetworkErrorReason returns a human-readable explanation if err is a network failure that isn't worth
+// retrying, or an empty string otherwise
+func permanentNetworkErrorReason(err error) string {
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) && dnsError.IsNotFound {
+		return fmt.Sprintf("cannot resolve the Octopus Server host name '%s'; please check the server URL", dnsError.Name)
+	}
+
+	var unknownAuthorityError x509.UnknownAuthorityError
+	var certificateInvalidError x509.CertificateInvalidError
+	var hostnameError x509.HostnameError
+	if errors.As(err, &unknownAuthorityError) || errors.As(err, &certificateInvalidError) || errors.As(err, &hostnameError) {
+		return "the Octopus Server's TLS certificate could not be verified"
+	}
+	return ""
+}
+
+// RetryRoundTripper retries requests which fail with a transient network error or a 5xx response
+// (e.g. while the Octopus Server is restarting), waiting twice as long between each attempt.
+// 4xx responses are never retried, and permanent network errors are reported straight away as a PermanentNetworkError.
+// Only idempotent requests are retried once they may have reached the server; anything else, such as a POST which
+// creates something, is only retried if it failed before it was sent, so that it can't be done twice.
+type RetryRoundTripper struct {
+	Next        http.RoundTripper
+	MaxAttempts int
+	Delay       time.Duration // the wait before the first retry; this doubles for each subsequent retry
+	Clock       clock.Clock
+}
+
+func NewRetryRoundTripper(next http.RoundTripper) *RetryRoundTripper {
+	return &RetryRoundTripper{
+		Next:        next,
+		MaxAttempts: DefaultRetryCount + 1,
+		Delay:       defaultRetryDelay,
+		Clock:       clock.Real,
+	}
+}
+
+func (c *RetryRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	delay := c.Delay
+	req := r
+	for attempt := 1; ; attempt++ {
+		resp, err := c.Next.RoundTrip(req)
+		if err != nil {
+			if reason := permanentNetworkErrorReason(err); reason != "" {
+				return nil, &PermanentNetworkError{Reason: reason, Err: err}
+			}
+			// a cancelled or expired request context fails the same way every time, so don't bother retrying it
+			if attempt >= c.MaxAttempts || r.Context().Err() != nil || !IsTransientNetworkError(err) || !(isIdempotent(r.Method) || isUnsent(err)) {
+				return nil, err
+			}
+		} else {
+			if resp.StatusCode < http.StatusInternalServerError || attempt >= c.MaxAttempts || !isIdempotent(r.Method) {
+				return resp, nil
+			}
+		}
+		next, ok := rewound(r)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil && resp.Body != nil {
+			// we're going to throw this response away, so release the connection
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		// stop waiting if the command is cancelled or runs out of time in the meantime
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-c.Clock.After(delay):
+		}
+		req = next
+		delay *= 2
+	}
+}
+
+// isIdempotent reports whether sending a request with method twice has the same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnsent reports whether err happened before the request could be sent, i.e. we never connected to the server
+func isUnsent(err error) bool {
+	var opError *net.OpError
+	return errors.Is(err, syscall.ECONNREFUSED) || (errors.As(err, &opError) && opError.Op == "dial")
+}
+
+// rewound returns a copy of r with its body reset so it can be sent again, or false if that isn't possible.
+// r itself is left alone, as it belongs to the caller.
+func rewound(r *http.Request) (*http.Request, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return r, true
+	}
+	if r.GetBody == nil {
+		return nil, false
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	clone := r.Clone(r.Context())
+	clone.Body = body
+	return clone, true
+}
@@ -0,0 +1,235 @@
+package apiclient_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/clock"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// wraps err the same way the standard library does when a dial fails
+func dialError(err error) error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: &os.SyscallError{Syscall: "connect", Err: err}}
+}
+
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func TestIsTransientNetworkError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"connection refused", dialError(syscall.ECONNREFUSED), true},
+		{"connection reset", dialError(syscall.ECONNRESET), true},
+		{"timeout", &url.Error{Op: "Get", URL: "http://server/api", Err: &timeoutError{}}, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"dns lookup timed out", &net.DNSError{Err: "timeout", Name: "server", IsTimeout: true}, true},
+		{"dns name not found", &net.DNSError{Err: "no such host", Name: "server", IsNotFound: true}, false},
+		{"untrusted certificate", &url.Error{Op: "Get", URL: "https://server/api", Err: x509.UnknownAuthorityError{}}, false},
+		{"wrong host on certificate", x509.HostnameError{Host: "server"}, false},
+		{"something else", errors.New("boom"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.transient, apiclient.IsTransientNetworkError(test.err))
+		})
+	}
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	newRoundTripper := func(errs ...error) (*apiclient.RetryRoundTripper, *int) {
+		calls := 0
+		rt := apiclient.NewRetryRoundTripper(testutil.RoundTripper(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls <= len(errs) {
+				return nil, errs[calls-1]
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}))
+		rt.Clock = testutil.NewFakeClock(time.Now())
+		return rt, &calls
+	}
+	req, _ := http.NewRequest("GET", "http://server/api", nil)
+
+	t.Run("retries transient errors until the request succeeds", func(t *testing.T) {
+		rt, calls := newRoundTripper(dialError(syscall.ECONNREFUSED), dialError(syscall.ECONNRESET))
+		resp, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, *calls)
+		// each wait is twice as long as the one before
+		assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, rt.Clock.(*testutil.FakeClock).Waits)
+	})
+
+	t.Run("stops waiting to retry once the request is cancelled", func(t *testing.T) {
+		rt, calls := newRoundTripper(dialError(syscall.ECONNREFUSED))
+		rt.Clock = clock.Real
+		rt.Delay = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		_, err := rt.RoundTrip(req.WithContext(ctx))
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("retries a POST which never reached the server", func(t *testing.T) {
+		var bodies []string
+		rt := apiclient.NewRetryRoundTripper(testutil.RoundTripper(func(r *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				return nil, dialError(syscall.ECONNREFUSED)
+			}
+			return &http.Response{StatusCode: http.StatusCreated}, nil
+		}))
+		rt.Clock = testutil.NewFakeClock(time.Now())
+		post, _ := http.NewRequest("POST", "http://server/api/Spaces-1/accounts", strings.NewReader(`{"Name":"Web deploy"}`))
+		originalBody := post.Body
+		resp, err := rt.RoundTrip(post)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		// the whole body is sent each time, without touching the caller's request
+		assert.Equal(t, []string{`{"Name":"Web deploy"}`, `{"Name":"Web deploy"}`}, bodies)
+		assert.True(t, post.Body == originalBody)
+	})
+
+	t.Run("does not retry a POST which failed once it was sent", func(t *testing.T) {
+		rt, calls := newRoundTripper(io.ErrUnexpectedEOF)
+		post, _ := http.NewRequest("POST", "http://server/api/Spaces-1/accounts", strings.NewReader(`{"Name":"Web deploy"}`))
+		_, err := rt.RoundTrip(post)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("gives up after the maximum number of attempts", func(t *testing.T) {
+		rt, calls := newRoundTripper(dialError(syscall.ECONNREFUSED), dialError(syscall.ECONNREFUSED), dialError(syscall.ECONNREFUSED))
+		_, err := rt.RoundTrip(req)
+		assert.True(t, errors.Is(err, syscall.ECONNREFUSED))
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("does not retry dns failures", func(t *testing.T) {
+		rt, calls := newRoundTripper(&net.DNSError{Err: "no such host", Name: "server", IsNotFound: true})
+		_, err := rt.RoundTrip(req)
+		assert.EqualError(t, err, "cannot resolve the Octopus Server host name 'server'; please check the server URL: lookup server: no such host")
+		var permanentError *apiclient.PermanentNetworkError
+		assert.True(t, errors.As(err, &permanentError))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("does not retry certificate errors", func(t *testing.T) {
+		rt, calls := newRoundTripper(x509.UnknownAuthorityError{})
+		_, err := rt.RoundTrip(req)
+		var permanentError *apiclient.PermanentNetworkError
+		assert.True(t, errors.As(err, &permanentError))
+		assert.Equal(t, "the Octopus Server's TLS certificate could not be verified", permanentError.Reason)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestRetryRoundTripper_StatusCodes(t *testing.T) {
+	newRoundTripper := func(statusCodes ...int) (*apiclient.RetryRoundTripper, *int) {
+		calls := 0
+		rt := apiclient.NewRetryRoundTripper(testutil.RoundTripper(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls <= len(statusCodes) {
+				return &http.Response{StatusCode: statusCodes[calls-1], Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}))
+		rt.Clock = testutil.NewFakeClock(time.Now())
+		return rt, &calls
+	}
+	req, _ := http.NewRequest("GET", "http://server/api", nil)
+
+	t.Run("retries server errors until the request succeeds", func(t *testing.T) {
+		rt, calls := newRoundTripper(http.StatusServiceUnavailable, http.StatusBadGateway)
+		resp, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("returns the last server error after the maximum number of attempts", func(t *testing.T) {
+		rt, calls := newRoundTripper(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusGatewayTimeout)
+		resp, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		rt, calls := newRoundTripper(http.StatusNotFound)
+		resp, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("does not retry a POST which may have been carried out", func(t *testing.T) {
+		rt, calls := newRoundTripper(http.StatusServiceUnavailable)
+		post, _ := http.NewRequest("POST", "http://server/api/Spaces-1/accounts", strings.NewReader(`{"Name":"Web deploy"}`))
+		resp, err := rt.RoundTrip(post)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("retries PUT and DELETE, which are safe to repeat", func(t *testing.T) {
+		for _, method := range []string{"PUT", "DELETE"} {
+			rt, calls := newRoundTripper(http.StatusServiceUnavailable)
+			r, _ := http.NewRequest(method, "http://server/api/Spaces-1/accounts/Accounts-1", nil)
+			resp, err := rt.RoundTrip(r)
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, 2, *calls)
+		}
+	})
+
+	t.Run("does not retry when retries are turned off", func(t *testing.T) {
+		rt, calls := newRoundTripper(http.StatusServiceUnavailable)
+		rt.MaxAttempts = 1
+		resp, err := rt.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestParseRetryCount(t *testing.T) {
+	retryCount, err := apiclient.ParseRetryCount("")
+	assert.Nil(t, err)
+	assert.Equal(t, apiclient.DefaultRetryCount, retryCount)
+
+	retryCount, err = apiclient.ParseRetryCount("0")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, retryCount)
+
+	retryCount, err = apiclient.ParseRetryCount("5")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, retryCount)
+
+	_, err = apiclient.ParseRetryCount("-1")
+	assert.EqualError(t, err, "OCTOPUS_RETRY_COUNT environment variable has an invalid value '-1'; it must be a whole number of zero or more")
+
+	_, err = apiclient.ParseRetryCount("lots")
+	assert.NotNil(t, err)
+}
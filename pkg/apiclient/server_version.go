@@ -0,0 +1,55 @@
+package apiclient
+
+import (
+	"strconv"
+	"strings"
+
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+)
+
+// CompareServerVersions compares two Octopus Server versions such as "2022.4.8471", returning -1 if a is older
+// than b, 1 if it is newer, and 0 if they are the same. Parts are compared as numbers, so 2022.10 is newer than
+// 2022.9, and missing parts count as zero. Anything after a '-' or '+' (e.g. a pre-release tag) is ignored.
+func CompareServerVersions(a string, b string) int {
+	aParts, bParts := versionParts(a), versionParts(b)
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if aPart < bPart {
+			return -1
+		}
+		if aPart > bPart {
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	var parts []int
+	for _, part := range strings.Split(strings.TrimSpace(version), ".") {
+		n, _ := strconv.Atoi(part) // anything that isn't a number counts as zero
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// RequireServerVersion returns a ServerVersionError if the Octopus Server is older than minimumVersion
+func RequireServerVersion(clientFactory ClientFactory, requester Requester, minimumVersion string) error {
+	serverVersion, err := clientFactory.GetServerVersion(requester)
+	if err != nil {
+		return err
+	}
+	if CompareServerVersions(serverVersion, minimumVersion) < 0 {
+		return &cliErrors.ServerVersionError{MinimumVersion: minimumVersion, ServerVersion: serverVersion}
+	}
+	return nil
+}
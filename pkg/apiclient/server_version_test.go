@@ -0,0 +1,59 @@
+package apiclient_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareServerVersions(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"2022.4.8471", "2022.4.8471", 0},
+		{"2022.4", "2022.4.0", 0},
+		{"2022.3.100", "2022.4", -1},
+		{"2022.10.1", "2022.9.5000", 1},
+		{"2023.1.1234-pre", "2023.1.1234", 0},
+		{"2019.1", "2022.1", -1},
+	}
+	for _, test := range tests {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			assert.Equal(t, test.want, apiclient.CompareServerVersions(test.a, test.b))
+		})
+	}
+}
+
+func TestRequireServerVersion(t *testing.T) {
+	versionedRoot := testutil.NewRootResource()
+	versionedRoot.Version = "2022.1.2584"
+	versionedRoot.Links["Self"] = "/api"
+
+	t.Run("remembers the version and rejects a server which is too old", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		factory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "", qa)
+		testutil.RequireSuccess(t, err)
+
+		receiver := testutil.GoBegin(func() error {
+			defer api.Close()
+			if err := apiclient.RequireServerVersion(factory, &apiclient.FakeRequesterContext{}, "2021.3"); err != nil {
+				return err
+			}
+			// asked again, the version comes from memory
+			return apiclient.RequireServerVersion(factory, &apiclient.FakeRequesterContext{}, "2022.2")
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(versionedRoot)
+		api.ExpectRequest(t, "GET", "/api").RespondWith(versionedRoot)
+
+		err = <-receiver
+		assert.EqualError(t, err, "this command requires Octopus 2022.2 or later, but the Octopus Server is version 2022.1.2584")
+		var versionErr *cliErrors.ServerVersionError
+		assert.ErrorAs(t, err, &versionErr)
+	})
+}
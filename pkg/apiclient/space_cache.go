@@ -0,0 +1,102 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+)
+
+const DefaultSpaceCacheTTL = 10 * time.Minute
+
+// SpaceCache remembers which space a space name or ID resolved to, so that GetSpacedClient doesn't have
+// to load every space from the server on each invocation. Entries are grouped by server URL and expire after TTL.
+// The cache is a convenience only: if the file can't be read or written we quietly fall back to the API.
+// A nil SpaceCache caches nothing.
+type SpaceCache struct {
+	Path string
+	TTL  time.Duration
+}
+
+type spaceCacheEntry struct {
+	Space    *spaces.Space
+	CachedAt time.Time
+}
+
+// map of server URL -> lowercased space name or ID -> entry
+type spaceCacheFile map[string]map[string]*spaceCacheEntry
+
+func NewSpaceCache(path string) *SpaceCache {
+	return &SpaceCache{Path: path, TTL: DefaultSpaceCacheTTL}
+}
+
+// Get returns the cached space for spaceNameOrID on the given server, or nil if there isn't one or it has expired.
+func (s *SpaceCache) Get(host string, spaceNameOrID string) *spaces.Space {
+	if s == nil {
+		return nil
+	}
+	entry := s.load()[host][strings.ToLower(spaceNameOrID)]
+	if entry == nil || entry.Space == nil || time.Since(entry.CachedAt) >= s.TTL {
+		return nil
+	}
+	return entry.Space
+}
+
+// Refresh replaces everything cached for the given server with allSpaces, keyed by both name and ID.
+// Names are written last so that they win where a name collides with another space's ID,
+// which matches how GetSpacedClient resolves spaces.
+func (s *SpaceCache) Refresh(host string, allSpaces []*spaces.Space) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+	entries := make(map[string]*spaceCacheEntry, len(allSpaces)*2)
+	for _, space := range allSpaces {
+		entries[strings.ToLower(space.ID)] = &spaceCacheEntry{Space: space, CachedAt: now}
+	}
+	for _, space := range allSpaces {
+		entries[strings.ToLower(space.Name)] = &spaceCacheEntry{Space: space, CachedAt: now}
+	}
+	cache := s.load()
+	cache[host] = entries
+	s.save(cache)
+}
+
+// Invalidate forgets everything cached for the given server.
+func (s *SpaceCache) Invalidate(host string) {
+	if s == nil {
+		return
+	}
+	cache := s.load()
+	if _, ok := cache[host]; !ok {
+		return
+	}
+	delete(cache, host)
+	s.save(cache)
+}
+
+func (s *SpaceCache) load() spaceCacheFile {
+	cache := spaceCacheFile{}
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return spaceCacheFile{} // corrupt; start again
+	}
+	return cache
+}
+
+func (s *SpaceCache) save(cache spaceCacheFile) {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), os.ModePerm); err != nil {
+		return
+	}
+	_ = os.WriteFile(s.Path, data, 0600)
+}
@@ -0,0 +1,75 @@
+package apiclient_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaceCache(t *testing.T) {
+	integrationsSpace := spaces.NewSpace("Integrations")
+	integrationsSpace.ID = "Spaces-7"
+
+	renamedSpace := spaces.NewSpace("Spaces-7") // collides with the other space's ID
+	renamedSpace.ID = "Spaces-209"
+
+	newCache := func(t *testing.T) *apiclient.SpaceCache {
+		return apiclient.NewSpaceCache(filepath.Join(t.TempDir(), "octopus", "space_cache.json"))
+	}
+
+	t.Run("looks up spaces by name or ID, ignoring case", func(t *testing.T) {
+		cache := newCache(t)
+		assert.Nil(t, cache.Get(serverUrl, "Integrations"))
+
+		cache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+
+		assert.Equal(t, "Spaces-7", cache.Get(serverUrl, "iNtegrationS").ID)
+		assert.Equal(t, "Spaces-7", cache.Get(serverUrl, "spaces-7").ID)
+		assert.Nil(t, cache.Get("http://other-server", "Integrations"))
+	})
+
+	t.Run("prefers names over IDs", func(t *testing.T) {
+		cache := newCache(t)
+		cache.Refresh(serverUrl, []*spaces.Space{integrationsSpace, renamedSpace})
+		assert.Equal(t, "Spaces-209", cache.Get(serverUrl, "Spaces-7").ID)
+	})
+
+	t.Run("ignores expired entries", func(t *testing.T) {
+		cache := newCache(t)
+		cache.TTL = 0
+		cache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+		assert.Nil(t, cache.Get(serverUrl, "Integrations"))
+	})
+
+	t.Run("invalidate only forgets the given server", func(t *testing.T) {
+		cache := newCache(t)
+		cache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+		cache.Refresh("http://other-server", []*spaces.Space{integrationsSpace})
+
+		cache.Invalidate(serverUrl)
+
+		assert.Nil(t, cache.Get(serverUrl, "Integrations"))
+		assert.NotNil(t, cache.Get("http://other-server", "Integrations"))
+	})
+
+	t.Run("treats a corrupt file as empty", func(t *testing.T) {
+		cache := newCache(t)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(cache.Path), os.ModePerm))
+		assert.Nil(t, os.WriteFile(cache.Path, []byte("not json"), 0600))
+		assert.Nil(t, cache.Get(serverUrl, "Integrations"))
+
+		cache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+		assert.NotNil(t, cache.Get(serverUrl, "Integrations"))
+	})
+
+	t.Run("a nil cache caches nothing", func(t *testing.T) {
+		var cache *apiclient.SpaceCache
+		cache.Refresh(serverUrl, []*spaces.Space{integrationsSpace})
+		assert.Nil(t, cache.Get(serverUrl, "Integrations"))
+		cache.Invalidate(serverUrl)
+	})
+}
@@ -0,0 +1,92 @@
+package apiclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+)
+
+// SpacePromptPageSize is how many spaces the space prompt loads up front. Servers with more spaces than
+// this offer a search instead of loading them all, which can take a long time on very large instances.
+const SpacePromptPageSize = 50
+
+const searchForAnotherSpace = "Search for another space..."
+
+// promptForSpace asks the user to pick a space. It returns the selected space, along with every space on
+// the server if they all fit in the first page, so the caller can cache them; otherwise allSpaces is nil.
+func promptForSpace(ask question.Asker, systemClient *octopusApiClient.Client) (selected *spaces.Space, allSpaces []*spaces.Space, err error) {
+	firstPage, err := systemClient.Spaces.Get(spaces.SpacesQuery{Take: SpacePromptPageSize})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if firstPage.TotalResults <= len(firstPage.Items) {
+		switch len(firstPage.Items) {
+		case 0:
+			return nil, nil, &cliErrors.NoSpacesError{}
+		case 1:
+			return firstPage.Items[0], firstPage.Items, nil
+		default:
+			selected, err = question.SelectMap(ask, "You have not specified a Space. Please select one:", firstPage.Items, func(item *spaces.Space) string { return item.GetName() }, survey.WithFilter(question.FuzzyFilter))
+			return selected, firstPage.Items, err
+		}
+	}
+
+	// too many spaces to offer them all; show the first page, and search the server for anything else
+	message := fmt.Sprintf("You have not specified a Space. Please select one (showing %d of %d):", len(firstPage.Items), firstPage.TotalResults)
+	options := firstPage.Items
+	for {
+		selected, err = selectSpaceOrSearch(ask, message, options)
+		if err != nil || selected != nil {
+			return selected, nil, err
+		}
+
+		var searchTerm string
+		if err := ask(&survey.Input{
+			Message: "Space name, or part of it",
+		}, &searchTerm, survey.WithValidator(survey.Required)); err != nil {
+			return nil, nil, err
+		}
+
+		found, err := systemClient.Spaces.Get(spaces.SpacesQuery{PartialName: searchTerm, Take: SpacePromptPageSize})
+		if err != nil {
+			return nil, nil, err
+		}
+		// as when a space is given by name, an exact match on the name wins, so there's nothing more to ask
+		for _, space := range found.Items {
+			if strings.EqualFold(space.Name, searchTerm) {
+				return space, nil, nil
+			}
+		}
+		switch {
+		case len(found.Items) == 0:
+			message = fmt.Sprintf("No spaces match '%s'. Please select one:", searchTerm)
+			options = firstPage.Items
+		case len(found.Items) == 1 && found.TotalResults == 1:
+			return found.Items[0], nil, nil
+		default:
+			message = fmt.Sprintf("Spaces matching '%s' (showing %d of %d). Please select one:", searchTerm, len(found.Items), found.TotalResults)
+			options = found.Items
+		}
+	}
+}
+
+// selectSpaceOrSearch offers the spaces plus an option to search for another, returning nil if the user
+// chose to search
+func selectSpaceOrSearch(ask question.Asker, message string, options []*spaces.Space) (*spaces.Space, error) {
+	search := &spaces.Space{Name: searchForAnotherSpace}
+	// the search option stays visible while filtering, as not finding the space in the list is when it's wanted
+	filter := func(filter string, value string, index int) bool {
+		return value == searchForAnotherSpace || question.FuzzyFilter(filter, value, index)
+	}
+	selected, err := question.SelectMap(ask, message, append(options[:len(options):len(options)], search), func(item *spaces.Space) string { return item.GetName() }, survey.WithFilter(filter))
+	if err != nil || selected == search {
+		return nil, err
+	}
+	return selected, nil
+}
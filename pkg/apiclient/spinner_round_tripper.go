@@ -2,9 +2,11 @@ package apiclient
 
 import (
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"golang.org/x/term"
 )
 
 type SpinnerRoundTripper struct {
@@ -15,12 +17,36 @@ type SpinnerRoundTripper struct {
 func NewSpinnerRoundTripper() *SpinnerRoundTripper {
 	return &SpinnerRoundTripper{
 		Next:    http.DefaultTransport,
-		Spinner: spinner.New(spinner.CharSets[11], 100*time.Millisecond, spinner.WithColor("cyan")),
+		Spinner: NewSpinner(),
 	}
 }
 
+// NewSpinner returns the spinner we show while waiting on the Octopus Server. It draws on stderr, so that
+// stdout only ever carries the command's output, and stays off if stderr has been redirected somewhere that
+// isn't a terminal.
+func NewSpinner() *spinner.Spinner {
+	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond, spinner.WithColor("cyan"), spinner.WithWriter(os.Stderr))
+	if !term.IsTerminal(int(os.Stderr.Fd())) {
+		s.Disable()
+	}
+	return s
+}
+
 func (c *SpinnerRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
 	c.Spinner.Start()
 	defer c.Spinner.Stop()
 	return c.Next.RoundTrip(r)
 }
+
+// ShowProgress puts how far a paged fetch has got beside the spinner, which shows it while the next page loads
+func (c *SpinnerRoundTripper) ShowProgress(fetched int, total int) {
+	c.Spinner.Lock()
+	defer c.Spinner.Unlock()
+	c.Spinner.Suffix = FormatProgress(fetched, total)
+}
+
+func (c *SpinnerRoundTripper) ClearProgress() {
+	c.Spinner.Lock()
+	defer c.Spinner.Unlock()
+	c.Spinner.Suffix = ""
+}
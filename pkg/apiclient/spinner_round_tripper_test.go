@@ -0,0 +1,14 @@
+package apiclient_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpinner_DrawsOnStderr(t *testing.T) {
+	s := apiclient.NewSpinner()
+	assert.Equal(t, os.Stderr, s.Writer)
+}
@@ -0,0 +1,20 @@
+package clock
+
+import "time"
+
+// Clock is how code which waits or measures time tells the time, so that tests can make time pass without
+// actually waiting for it. Use Real outside tests; testutil.FakeClock stands in for it in tests.
+type Clock interface {
+	Now() time.Time
+	// After is time.After, so waits can also be abandoned by selecting on a context
+	After(d time.Duration) <-chan time.Time
+}
+
+// Real is the time on the wall
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -6,11 +6,15 @@ import (
 	cmdAzure "github.com/OctopusDeploy/cli/pkg/cmd/account/azure"
 	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/account/create"
 	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/account/delete"
+	cmdExport "github.com/OctopusDeploy/cli/pkg/cmd/account/export"
+	cmdFind "github.com/OctopusDeploy/cli/pkg/cmd/account/find"
 	cmdGCP "github.com/OctopusDeploy/cli/pkg/cmd/account/gcp"
+	cmdImport "github.com/OctopusDeploy/cli/pkg/cmd/account/import"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/account/list"
 	cmdSSH "github.com/OctopusDeploy/cli/pkg/cmd/account/ssh"
 	cmdToken "github.com/OctopusDeploy/cli/pkg/cmd/account/token"
 	cmdUsr "github.com/OctopusDeploy/cli/pkg/cmd/account/username"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/account/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -30,7 +34,11 @@ func NewCmdAccount(f factory.Factory) *cobra.Command {
 
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
+	cmd.AddCommand(cmdImport.NewCmdImport(f))
+	cmd.AddCommand(cmdExport.NewCmdExport(f))
 	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdFind.NewCmdFind(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
 	cmd.AddCommand(cmdAWS.NewCmdAws(f))
 	cmd.AddCommand(cmdAzure.NewCmdAzure(f))
 	cmd.AddCommand(cmdGCP.NewCmdGcp(f))
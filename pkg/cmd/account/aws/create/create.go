@@ -3,7 +3,6 @@ package create
 import (
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
-	"os"
 
 	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
@@ -17,7 +16,6 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/cli/pkg/surveyext"
 	"github.com/OctopusDeploy/cli/pkg/util/flag"
-	"github.com/OctopusDeploy/cli/pkg/validation"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/spf13/cobra"
@@ -49,17 +47,18 @@ func NewCreateFlags() *CreateFlags {
 
 func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
 	return &CreateOptions{
-		CreateFlags:  flags,
-		Dependencies: dependencies,
-		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
-			return selectors.GetAllEnvironments(dependencies.Client)
-		},
+		CreateFlags:                flags,
+		Dependencies:               dependencies,
+		GetAllEnvironmentsCallback: dependencies.GetAllEnvironments,
 	}
 }
 
 func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 	descriptionFilePath := ""
+	strict := false
+	anyEnvironment := false
+	createMissingEnvironments := false
 
 	cmd := &cobra.Command{
 		Use:     "create",
@@ -70,21 +69,14 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
 			if descriptionFilePath != "" {
-				if err := validation.IsExistingFile(descriptionFilePath); err != nil {
-					return err
-				}
-				data, err := os.ReadFile(descriptionFilePath)
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
 				if err != nil {
 					return err
 				}
 				opts.Description.Value = string(data)
 			}
-			if opts.Environments.Value != nil {
-				env, err := helper.ResolveEnvironmentNames(opts.Environments.Value, opts.Client)
-				if err != nil {
-					return err
-				}
-				opts.Environments.Value = env
+			if err := helper.ResolveEnvironmentFlags(c, opts.Environments, opts.Dependencies, strict, anyEnvironment, createMissingEnvironments); err != nil {
+				return err
 			}
 			return CreateRun(opts)
 		},
@@ -96,7 +88,11 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	flags.StringVar(&createFlags.AccessKey.Value, createFlags.AccessKey.Name, "", "The AWS access key to use when authenticating against Amazon Web Services.")
 	flags.StringVar(&createFlags.SecretKey.Value, createFlags.SecretKey.Name, "", "The AWS secret key to use when authenticating against Amazon Web Services.")
 	flags.StringArrayVarP(&createFlags.Environments.Value, createFlags.Environments.Name, "e", nil, "The environments that are allowed to use this account")
-	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`")
+	helper.RegisterEnvironmentCompletion(cmd, f, createFlags.Environments.Name)
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+	helper.RegisterStrictFlag(cmd, &strict)
+	helper.RegisterAnyEnvironmentFlag(cmd, &anyEnvironment)
+	helper.RegisterCreateMissingEnvironmentsFlag(cmd, &createMissingEnvironments)
 
 	return cmd
 }
@@ -116,13 +112,16 @@ func CreateRun(opts *CreateOptions) error {
 
 	createdAccount, err := opts.Client.Accounts.Add(awsAccount)
 	if err != nil {
-		return err
+		return helper.ExplainEnvironmentLimitError(err, opts.Environments.Value)
 	}
 
-	_, err = fmt.Fprintf(opts.Out, "Successfully created AWS account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
+	err = output.Successf(opts.Out, createdAccount.GetID(), "Successfully created AWS account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), createdAccount.GetID())
 	fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", link)
 	if !opts.NoPrompt {
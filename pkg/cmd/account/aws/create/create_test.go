@@ -144,3 +144,70 @@ func TestAWSAccountCreateNoPrompt(t *testing.T) {
 		output.Bluef("%s/app#/%s/infrastructure/accounts/%s", "", opts.Space.GetID(), testAccount.ID),
 	), res)
 }
+
+func TestAWSAccountCreateDoesNotEchoSecretKey(t *testing.T) {
+	space := fixtures.NewSpace("Spaces-1", "testspace")
+	api := testutil.NewMockHttpServer()
+	out := &bytes.Buffer{}
+
+	opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Space: space, CmdPath: "octopus account aws create"})
+	opts.Name.Value = "testaccount"
+	opts.Description.Value = "test"
+	opts.AccessKey.Value = "testaccesskey123"
+	opts.SecretKey.Value = "testsecretkey123"
+	opts.Environments.Value = []string{}
+
+	errReceiver := testutil.GoBegin(func() error {
+		defer api.Close()
+		octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+		opts.Client = octopus
+		opts.Out = out
+		return create.CreateRun(opts) // prompting is on, so the automation command is printed too
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+	body, err := testutil.ReadJson[map[string]any](req.Request.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"HasValue": true, "Hint": nil, "NewValue": "testsecretkey123"}, body["SecretKey"])
+
+	createdAccount, _ := accounts.NewAmazonWebServicesAccount(opts.Name.Value, opts.AccessKey.Value, &core.SensitiveValue{HasValue: true})
+	createdAccount.ID = "Accounts-1"
+	req.RespondWithStatus(201, "", createdAccount)
+
+	assert.Nil(t, <-errReceiver)
+	assert.NotContains(t, out.String(), "testsecretkey123")
+	assert.Contains(t, out.String(), "--secret-key '***'")
+}
+
+func TestAWSAccountCreateQuiet(t *testing.T) {
+	space := fixtures.NewSpace("Spaces-1", "testspace")
+	api := testutil.NewMockHttpServer()
+	out := &bytes.Buffer{}
+
+	opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Space: space, CmdPath: "octopus account aws create"})
+	opts.Name.Value = "testaccount"
+	opts.Description.Value = "test"
+	opts.AccessKey.Value = "testaccesskey123"
+	opts.SecretKey.Value = "testsecretkey123"
+	opts.Environments.Value = []string{}
+
+	output.IsQuiet = true
+	defer func() { output.IsQuiet = false }()
+
+	errReceiver := testutil.GoBegin(func() error {
+		defer api.Close()
+		octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+		opts.Client = octopus
+		opts.Out = out
+		return create.CreateRun(opts) // prompting is on, but --quiet still leaves out the automation command
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	createdAccount, _ := accounts.NewAmazonWebServicesAccount(opts.Name.Value, opts.AccessKey.Value, &core.SensitiveValue{HasValue: true})
+	createdAccount.ID = "Accounts-1"
+	api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+
+	assert.Nil(t, <-errReceiver)
+	assert.Equal(t, "Accounts-1\n", out.String())
+}
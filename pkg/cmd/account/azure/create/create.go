@@ -3,7 +3,6 @@ package create
 import (
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
-	"os"
 	"strings"
 
 	"github.com/OctopusDeploy/cli/pkg/util"
@@ -61,11 +60,9 @@ func NewCreateFlags() *CreateFlags {
 
 func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
 	return &CreateOptions{
-		CreateFlags:  flags,
-		Dependencies: dependencies,
-		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
-			return selectors.GetAllEnvironments(dependencies.Client)
-		},
+		CreateFlags:                flags,
+		Dependencies:               dependencies,
+		GetAllEnvironmentsCallback: dependencies.GetAllEnvironments,
 	}
 }
 
@@ -91,6 +88,9 @@ var azureResourceManagementBaseUri = map[string]string{
 func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 	descriptionFilePath := ""
+	strict := false
+	anyEnvironment := false
+	createMissingEnvironments := false
 
 	cmd := &cobra.Command{
 		Use:     "create",
@@ -101,10 +101,7 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
 			if descriptionFilePath != "" {
-				if err := validation.IsExistingFile(descriptionFilePath); err != nil {
-					return err
-				}
-				data, err := os.ReadFile(descriptionFilePath)
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
 				if err != nil {
 					return err
 				}
@@ -146,12 +143,8 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 					opts.ADEndpointBaseUrl.Value = azureADEndpointBaseUri[opts.AzureEnvironment.Value]
 				}
 			}
-			if opts.Environments.Value != nil {
-				env, err := helper.ResolveEnvironmentNames(opts.Environments.Value, opts.Client)
-				if err != nil {
-					return err
-				}
-				opts.Environments.Value = env
+			if err := helper.ResolveEnvironmentFlags(c, opts.Environments, opts.Dependencies, strict, anyEnvironment, createMissingEnvironments); err != nil {
+				return err
 			}
 			return CreateRun(opts)
 		},
@@ -165,10 +158,14 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	flags.StringVar(&createFlags.ApplicationID.Value, createFlags.ApplicationID.Name, "", "Your Azure Active Directory Application ID.")
 	flags.StringVar(&createFlags.ApplicationPasswordKey.Value, createFlags.ApplicationPasswordKey.Name, "", "The password for the Azure Active Directory application.")
 	flags.StringArrayVarP(&createFlags.Environments.Value, createFlags.Environments.Name, "e", nil, "The environments that are allowed to use this account")
+	helper.RegisterEnvironmentCompletion(cmd, f, createFlags.Environments.Name)
 	flags.StringVar(&createFlags.AzureEnvironment.Value, createFlags.AzureEnvironment.Name, "", "Set only if you are using an isolated Azure Environment. Configure isolated Azure Environment. Valid option are AzureChinaCloud, AzureChinaCloud, AzureGermanCloud or AzureUSGovernment")
 	flags.StringVar(&createFlags.ADEndpointBaseUrl.Value, createFlags.ADEndpointBaseUrl.Name, "", "Set this only if you need to override the default Active Directory Endpoint.")
 	flags.StringVar(&createFlags.RMBaseUri.Value, createFlags.RMBaseUri.Name, "", "Set this only if you need to override the default Resource Management Endpoint.")
-	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`")
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+	helper.RegisterStrictFlag(cmd, &strict)
+	helper.RegisterAnyEnvironmentFlag(cmd, &anyEnvironment)
+	helper.RegisterCreateMissingEnvironmentsFlag(cmd, &createMissingEnvironments)
 
 	return cmd
 }
@@ -209,13 +206,16 @@ func CreateRun(opts *CreateOptions) error {
 
 	createdAccount, err = opts.Client.Accounts.Add(servicePrincipalAccount)
 	if err != nil {
-		return err
+		return helper.ExplainEnvironmentLimitError(err, opts.Environments.Value)
 	}
 
-	_, err = fmt.Fprintf(opts.Out, "Successfully created Azure account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
+	err = output.Successf(opts.Out, createdAccount.GetID(), "Successfully created Azure account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), createdAccount.GetID())
 	fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", link)
 	if !opts.NoPrompt {
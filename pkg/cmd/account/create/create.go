@@ -2,7 +2,9 @@ package create
 
 import (
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
+	"sort"
+	"strings"
+
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	awsCreate "github.com/OctopusDeploy/cli/pkg/cmd/account/aws/create"
@@ -13,87 +15,206 @@ import (
 	usernameCreate "github.com/OctopusDeploy/cli/pkg/cmd/account/username/create"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question/selectors"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/spf13/cobra"
 )
 
-const (
-	AwsAccount              = "AWS Account"
-	AzureAccount            = "Azure Account"
-	GcpAccount              = "Google Cloud Account"
-	SshAccount              = "SSH Key Pair"
-	UsernamePasswordAccount = "Username/Password"
-	TokenAccount            = "Token"
-)
+const FlagType = "type"
+
+// the subcommand which creates each type of account, e.g. 'ssh' for 'account ssh create'
+var subcommandNames = map[accounts.AccountType]string{
+	accounts.AccountTypeAmazonWebServicesAccount:   "aws",
+	accounts.AccountTypeAzureServicePrincipal:      "azure",
+	accounts.AccountTypeGoogleCloudPlatformAccount: "gcp",
+	accounts.AccountTypeSSHKeyPair:                 "ssh",
+	accounts.AccountTypeUsernamePassword:           "username",
+	accounts.AccountTypeToken:                      "token",
+}
+
+type CreateFlags struct {
+	Type *flag.Flag[string]
+}
+
+type CreateOptions struct {
+	*CreateFlags
+	*cmd.Dependencies
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Type: flag.New[string](FlagType, false),
+	}
+}
+
+func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:  flags,
+		Dependencies: dependencies,
+	}
+}
 
 func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create an account",
-		Long:    "Create an account in Octopus Deploy",
-		Example: heredoc.Docf("$ %s account create", constants.ExecutableName),
+		Use:   "create",
+		Short: "Create an account",
+		Long:  "Create an account in Octopus Deploy, asking which type of account it is before handing over to the create command for that type",
+		Example: heredoc.Docf(`
+			$ %[1]s account create
+			$ %[1]s account create --type SshKeyPair
+			$ %[1]s account create --type Token --name Registry --token secret --no-prompt
+		`, constants.ExecutableName),
 		Aliases: []string{"new"},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return createRun(f, cmd)
+		// the flags after --type belong to that type's create command, which cobra can't know in advance, so
+		// the arguments arrive unparsed and are handed on from here
+		DisableFlagParsing: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if typeValue, rest, ok := takeTypeFlag(args); ok {
+				accountType, err := ParseAccountType(typeValue)
+				if err != nil {
+					return err
+				}
+				return executeAs(c, []string{SubcommandName(accountType), "create"}, rest)
+			}
+			if c.DisableFlagParsing {
+				// no type, so no flags to hand on: run again with the flags parsed as usual, and ask for the type
+				c.DisableFlagParsing = false
+				defer func() { c.DisableFlagParsing = true }()
+				return executeAs(c, []string{c.Name()}, args)
+			}
+			return CreateRun(NewCreateOptions(createFlags, cmd.NewDependencies(f, c)))
 		},
 	}
 
+	cmd.Flags().StringVarP(&createFlags.Type.Value, createFlags.Type.Name, "t", "", fmt.Sprintf("The type of account to create: %s. Required when prompting is disabled. Any further flags are passed to the create command for that type", output.FormatAsList(validTypes())))
+
 	return cmd
 }
 
-func createRun(f factory.Factory, c *cobra.Command) error {
-	dependencies := cmd.NewDependencies(f, c)
-
-	accountTypes := []string{
-		AwsAccount,
-		AzureAccount,
-		GcpAccount,
-		SshAccount,
-		UsernamePasswordAccount,
-		TokenAccount,
+// takeTypeFlag finds the value of --type in args, which haven't been parsed, and returns the other arguments
+func takeTypeFlag(args []string) (string, []string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		end := i + 1
+		var value string
+		switch {
+		case arg == "--"+FlagType || arg == "-t":
+			if end == len(args) {
+				return "", nil, false
+			}
+			value = args[end]
+			end++
+		case strings.HasPrefix(arg, "--"+FlagType+"="):
+			value = strings.TrimPrefix(arg, "--"+FlagType+"=")
+		case strings.HasPrefix(arg, "-t"):
+			value = strings.TrimPrefix(strings.TrimPrefix(arg, "-t"), "=")
+		default:
+			continue
+		}
+		return value, append(append([]string{}, args[:i]...), args[end:]...), true
 	}
+	return "", nil, false
+}
 
-	var accountType string
-	err := f.Ask(&survey.Select{
-		Help:    "The type of account being created.",
-		Message: "Account Type",
-		Options: accountTypes,
-	}, &accountType)
-	if err != nil {
-		return err
+// executeAs runs the command called names under c's parent, e.g. 'ssh create' under 'account', with args, as
+// though it had been given on the command line, so that its flags, checks and hooks all apply
+func executeAs(c *cobra.Command, names []string, args []string) error {
+	var path []string
+	for p := c.Parent(); p != nil && p.HasParent(); p = p.Parent() {
+		path = append([]string{p.Name()}, path...)
 	}
+	root := c.Root()
+	root.SetArgs(append(append(path, names...), args...))
+	_, err := root.ExecuteC()
+	return err
+}
 
-	switch accountType {
-	case AwsAccount:
-		opts := awsCreate.NewCreateOptions(awsCreate.NewCreateFlags(), cmd.NewDependenciesFromExisting(dependencies, fmt.Sprintf("%s account aws create", constants.ExecutableName)))
-		if err := awsCreate.CreateRun(opts); err != nil {
-			return err
-		}
-	case AzureAccount:
-		opts := azureCreate.NewCreateOptions(azureCreate.NewCreateFlags(), cmd.NewDependenciesFromExisting(dependencies, fmt.Sprintf("%s account azure create", constants.ExecutableName)))
-		if err := azureCreate.CreateRun(opts); err != nil {
+// CreateRun works out the account type, from --type or by asking, then runs the create command for that type
+func CreateRun(opts *CreateOptions) error {
+	var accountType accounts.AccountType
+	if opts.Type.Value != "" {
+		var err error
+		if accountType, err = ParseAccountType(opts.Type.Value); err != nil {
 			return err
 		}
-	case GcpAccount:
-		opts := gcpCreate.NewCreateOptions(gcpCreate.NewCreateFlags(), cmd.NewDependenciesFromExisting(dependencies, fmt.Sprintf("%s account gcp create", constants.ExecutableName)))
-		if err := gcpCreate.CreateRun(opts); err != nil {
-			return err
-		}
-	case SshAccount:
-		opts := sshCreate.NewCreateOptions(sshCreate.NewCreateFlags(), cmd.NewDependenciesFromExisting(dependencies, fmt.Sprintf("%s account ssh create", constants.ExecutableName)))
-		if err := sshCreate.CreateRun(opts); err != nil {
-			return err
+	} else {
+		// automation shouldn't depend on us guessing which kind of account it meant
+		if opts.NoPrompt {
+			return fmt.Errorf("--%s is required when prompting is disabled. Valid values are %s", FlagType, output.FormatAsList(validTypes()))
 		}
-	case TokenAccount:
-		opts := tokenCreate.NewCreateOptions(tokenCreate.NewCreateFlags(), cmd.NewDependenciesFromExisting(dependencies, fmt.Sprintf("%s account token create", constants.ExecutableName)))
-		if err := tokenCreate.CreateRun(opts); err != nil {
+		selectedType, err := selectors.AccountTypeSelect(opts.Ask)
+		if err != nil {
 			return err
 		}
-	case UsernamePasswordAccount:
-		opts := usernameCreate.NewCreateOptions(usernameCreate.NewCreateFlags(), cmd.NewDependenciesFromExisting(dependencies, fmt.Sprintf("%s account username create", constants.ExecutableName)))
-		if err := usernameCreate.CreateRun(opts); err != nil {
-			return err
+		accountType = selectedType
+	}
+
+	dependencies := cmd.NewDependenciesFromExisting(opts.Dependencies, fmt.Sprintf("%s account %s create", constants.ExecutableName, subcommandNames[accountType]))
+	switch accountType {
+	case accounts.AccountTypeAmazonWebServicesAccount:
+		return awsCreate.CreateRun(awsCreate.NewCreateOptions(awsCreate.NewCreateFlags(), dependencies))
+	case accounts.AccountTypeAzureServicePrincipal:
+		return azureCreate.CreateRun(azureCreate.NewCreateOptions(azureCreate.NewCreateFlags(), dependencies))
+	case accounts.AccountTypeGoogleCloudPlatformAccount:
+		return gcpCreate.CreateRun(gcpCreate.NewCreateOptions(gcpCreate.NewCreateFlags(), dependencies))
+	case accounts.AccountTypeSSHKeyPair:
+		return sshCreate.CreateRun(sshCreate.NewCreateOptions(sshCreate.NewCreateFlags(), dependencies))
+	case accounts.AccountTypeToken:
+		return tokenCreate.CreateRun(tokenCreate.NewCreateOptions(tokenCreate.NewCreateFlags(), dependencies))
+	case accounts.AccountTypeUsernamePassword:
+		return usernameCreate.CreateRun(usernameCreate.NewCreateOptions(usernameCreate.NewCreateFlags(), dependencies))
+	default:
+		return fmt.Errorf("creating %s accounts is not supported", accountType)
+	}
+}
+
+// ParseAccountType finds the account type called value, ignoring case, e.g. SshKeyPair
+func ParseAccountType(value string) (accounts.AccountType, error) {
+	for t := range subcommandNames {
+		if strings.EqualFold(string(t), value) {
+			return t, nil
 		}
 	}
+	return "", fmt.Errorf("unknown account type '%s'. Valid values are %s", value, output.FormatAsList(validTypes()))
+}
+
+// SubcommandName returns the name of the command for accountType under 'account', e.g. ssh for SshKeyPair
+func SubcommandName(accountType accounts.AccountType) string {
+	return subcommandNames[accountType]
+}
+
+// NewCmdCreateForType returns a new create command for accountType, e.g. the one behind 'account ssh create'
+// for SshKeyPair. Its flags start out empty each time.
+func NewCmdCreateForType(f factory.Factory, accountType accounts.AccountType) (*cobra.Command, error) {
+	switch accountType {
+	case accounts.AccountTypeAmazonWebServicesAccount:
+		return awsCreate.NewCmdCreate(f), nil
+	case accounts.AccountTypeAzureServicePrincipal:
+		return azureCreate.NewCmdCreate(f), nil
+	case accounts.AccountTypeGoogleCloudPlatformAccount:
+		return gcpCreate.NewCmdCreate(f), nil
+	case accounts.AccountTypeSSHKeyPair:
+		return sshCreate.NewCmdCreate(f), nil
+	case accounts.AccountTypeToken:
+		return tokenCreate.NewCmdCreate(f), nil
+	case accounts.AccountTypeUsernamePassword:
+		return usernameCreate.NewCmdCreate(f), nil
+	default:
+		return nil, fmt.Errorf("creating %s accounts is not supported", accountType)
+	}
+}
 
-	return nil
+func validTypes() []string {
+	types := make([]string, 0, len(selectors.CreatableAccountTypes))
+	for _, option := range selectors.CreatableAccountTypes {
+		types = append(types, string(option.Type))
+	}
+	sort.Strings(types)
+	return types
 }
@@ -0,0 +1,128 @@
+package create_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/create"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestAccountCreate(t *testing.T) {
+	stop := errors.New("stop here")
+
+	tests := []struct {
+		name        string
+		accountType string
+		noPrompt    bool
+		run         func(t *testing.T, qa *testutil.AskMocker, errReceiver chan error)
+	}{
+		{"asks for the type and hands over to that type's create", "", false, func(t *testing.T, qa *testutil.AskMocker, errReceiver chan error) {
+			_ = qa.ExpectQuestion(t, &survey.Select{
+				Message: "Account Type",
+				Options: []string{"AWS Account", "Azure Account", "Google Cloud Account", "SSH Key Pair", "Username/Password", "Token"},
+			}).AnswerWith("SSH Key Pair")
+			// the first question of account ssh create; its own tests cover the rest
+			qa.ExpectQuestion(t, &survey.Input{
+				Message: "Name",
+				Help:    "A short, memorable, unique name for this account.",
+			}).AnswerWithError(stop)
+
+			assert.Equal(t, stop, <-errReceiver)
+		}},
+		{"doesn't ask for the type when --type is given", "token", false, func(t *testing.T, qa *testutil.AskMocker, errReceiver chan error) {
+			qa.ExpectQuestion(t, &survey.Input{
+				Message: "Name",
+				Help:    "A short, memorable, unique name for this account.",
+			}).AnswerWithError(stop)
+
+			assert.Equal(t, stop, <-errReceiver)
+		}},
+		{"requires --type when prompting is disabled", "", true, func(t *testing.T, qa *testutil.AskMocker, errReceiver chan error) {
+			assert.EqualError(t, <-errReceiver, "--type is required when prompting is disabled. Valid values are AmazonWebServicesAccount, AzureServicePrincipal, GoogleCloudAccount, SshKeyPair, Token, UsernamePassword")
+		}},
+		{"rejects an unknown type", "carrier-pigeon", false, func(t *testing.T, qa *testutil.AskMocker, errReceiver chan error) {
+			assert.EqualError(t, <-errReceiver, "unknown account type 'carrier-pigeon'. Valid values are AmazonWebServicesAccount, AzureServicePrincipal, GoogleCloudAccount, SshKeyPair, Token, UsernamePassword")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qa := testutil.NewAskMocker()
+			opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: &bytes.Buffer{}, NoPrompt: test.noPrompt, Ask: qa.AsAsker()})
+			opts.Type.Value = test.accountType
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer qa.Close()
+				return create.CreateRun(opts)
+			})
+
+			test.run(t, qa, errReceiver)
+		})
+	}
+}
+
+func TestAccountCreateWithTypeNoPrompt(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewTokenAccount("Registry", core.NewSensitiveValue("secret"))
+	createdAccount.ID = "Accounts-1"
+
+	api, qa := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(qa.AsAsker())
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		// the flags after --type are those of account token create
+		rootCmd.SetArgs([]string{"account", "create", "--no-prompt", "--type", "token", "--name", "Registry", "--token", "secret"})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+	req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+	body, err := testutil.ReadJson[map[string]any](req.Request.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "Registry", body["Name"])
+	assert.Equal(t, "Token", body["AccountType"])
+	req.RespondWithStatus(201, "", createdAccount)
+
+	_, err = testutil.ReceivePair(cmdReceiver)
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "Successfully created Token account Registry")
+}
+
+func TestAccountCreateWithoutTypeNoPrompt(t *testing.T) {
+	api, qa := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(qa.AsAsker())
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, fixtures.NewSpace("Spaces-1", "Default Space"), askProvider), nil, askProvider)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		rootCmd.SetArgs([]string{"account", "create", "--no-prompt"})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+	_, err := testutil.ReceivePair(cmdReceiver)
+	assert.EqualError(t, err, "--type is required when prompting is disabled. Valid values are AmazonWebServicesAccount, AzureServicePrincipal, GoogleCloudAccount, SshKeyPair, Token, UsernamePassword")
+}
@@ -2,97 +2,101 @@ package delete
 
 import (
 	"fmt"
+
 	"github.com/MakeNowJust/heredoc/v2"
-	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/list"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/spf13/cobra"
 )
 
+type DeleteOptions struct {
+	*cmd.Dependencies
+	*question.ConfirmFlags
+	IdOrName string
+}
+
+func NewDeleteOptions(confirmFlags *question.ConfirmFlags, dependencies *cmd.Dependencies, idOrName string) *DeleteOptions {
+	return &DeleteOptions{
+		Dependencies: dependencies,
+		ConfirmFlags: confirmFlags,
+		IdOrName:     idOrName,
+	}
+}
+
 func NewCmdDelete(f factory.Factory) *cobra.Command {
-	var skipConfirmation bool
+	confirmFlags := question.NewConfirmFlags()
 	cmd := &cobra.Command{
 		Use:     "delete {<name> | <id>}",
 		Short:   "Delete an account",
-		Long:    "Delete an account in Octopus Deploy",
+		Long:    "Delete an account of any type in Octopus Deploy",
 		Aliases: []string{"del", "rm", "remove"},
 		Example: heredoc.Docf(`
 			$ %[1]s account delete
-			$ %[1]s account rm
+			$ %[1]s account rm "Deploy Key"
+			$ %[1]s account delete Accounts-12 --confirm
 		`, constants.ExecutableName),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
-				return deleteRun(f, cmd)
-			}
-
-			itemIDOrName := args[0]
-
-			client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
-
-			if err != nil {
-				return err
-			}
-
-			// SDK doesn't have accounts.GetByIDOrName so we emulate it here
-			foundAccounts, err := client.Accounts.Get(accounts.AccountsQuery{
-				// TODO we can't lookup by ID yet because the server doesn't support it
-				PartialName: itemIDOrName,
-			})
-			if err != nil {
-				return err
-			}
-			// need exact match
-			var itemToDelete accounts.IAccount
-			for _, act := range foundAccounts.Items {
-				if act.GetName() == itemIDOrName {
-					itemToDelete = act
-					break
-				}
-			}
-			if itemToDelete == nil {
-				return fmt.Errorf("cannot find an account with name or ID of '%s'", itemIDOrName)
+		RunE: func(c *cobra.Command, args []string) error {
+			idOrName := ""
+			if len(args) > 0 {
+				idOrName = args[0]
 			}
-
-			if !skipConfirmation { // TODO NO_PROMPT env var or whatever we do there
-				return question.DeleteWithConfirmation(f.Ask, "space", itemToDelete.GetName(), itemToDelete.GetID(), func() error {
-					return delete(client, itemToDelete)
-				})
-			}
-
-			return delete(client, itemToDelete)
+			return DeleteRun(NewDeleteOptions(confirmFlags, cmd.NewDependencies(f, c), idOrName))
 		},
 	}
 
-	question.RegisterConfirmDeletionFlag(cmd, &skipConfirmation, "account")
+	question.RegisterConfirmDeletionFlag(cmd, &confirmFlags.Confirm.Value, "account")
 
 	return cmd
 }
 
-func deleteRun(f factory.Factory, cmd *cobra.Command) error {
-	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
-	if err != nil {
-		return err
+// DeleteRun asks the user to type the account's name before deleting it, unless --confirm was given.
+// With prompting disabled there is nobody to ask, so --confirm is required.
+func DeleteRun(opts *DeleteOptions) error {
+	if opts.NoPrompt && !opts.Confirm.Value {
+		if opts.IdOrName == "" {
+			return fmt.Errorf("an account name or ID is required when prompting is disabled")
+		}
+		return fmt.Errorf("deleting the account '%s' cannot be undone; pass --%s to delete it when prompting is disabled", opts.IdOrName, question.FlagConfirm)
 	}
 
-	existingAccounts, err := client.Accounts.GetAll()
-	if err != nil {
-		return err
+	var itemToDelete accounts.IAccount
+	if opts.IdOrName == "" {
+		existingItems, err := opts.Client.Accounts.GetAll()
+		if err != nil {
+			return err
+		}
+		if itemToDelete, err = selectors.ByName(opts.Ask, existingItems, "Select the account you wish to delete:"); err != nil {
+			return err
+		}
+	} else {
+		account, err := helper.FindAccount(opts.Client, opts.IdOrName)
+		if err != nil {
+			return err
+		}
+		itemToDelete = account
 	}
 
-	accountToDelete, err := selectors.ByName(f.Ask, existingAccounts, "Select the account you wish to delete:")
-	if err != nil {
-		return err
+	// e.g. 'SSH Key Pair account', so it's clear which account is about to go when names are similar
+	itemType := list.AccountTypeMap[itemToDelete.GetAccountType()] + " account"
+	if !opts.Confirm.Value {
+		return question.DeleteWithConfirmation(opts.Ask, itemType, itemToDelete.GetName(), itemToDelete.GetID(), func() error {
+			return delete(opts, itemToDelete)
+		})
 	}
 
-	return question.DeleteWithConfirmation(f.Ask, "account", accountToDelete.GetName(), accountToDelete.GetID(), func() error {
-		return delete(client, accountToDelete)
-	})
+	if err := delete(opts, itemToDelete); err != nil {
+		return err
+	}
+	return output.Successf(opts.Out, itemToDelete.GetID(), "%s The %s, \"%s\" %s was deleted successfully.\n", output.Red("✔"), itemType, itemToDelete.GetName(), output.Dimf("(%s)", itemToDelete.GetID()))
 }
 
-func delete(client *client.Client, accountToDelete accounts.IAccount) error {
-	return client.Accounts.DeleteByID(accountToDelete.GetID())
+func delete(opts *DeleteOptions, itemToDelete accounts.IAccount) error {
+	return opts.Client.Accounts.DeleteByID(itemToDelete.GetID())
 }
@@ -0,0 +1,108 @@
+package delete_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/delete"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func TestAccountDelete(t *testing.T) {
+	sshAccount, _ := accounts.NewSSHKeyAccount("Deploy Key", "deploy", core.NewSensitiveValue(""))
+	sshAccount.ID = "Accounts-1"
+	tokenAccount, _ := accounts.NewTokenAccount("Deploy", core.NewSensitiveValue(""))
+	tokenAccount.ID = "Accounts-2"
+	usernameAccount, _ := accounts.NewUsernamePasswordAccount("deploy")
+	usernameAccount.ID = "Accounts-3"
+	allAccounts := []accounts.IAccount{sshAccount, tokenAccount, usernameAccount}
+
+	tests := []struct {
+		name     string
+		idOrName string
+		noPrompt bool
+		confirm  bool
+		run      func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer)
+	}{
+		{"shows the account type and deletes once the user types the name", "deploy key", false, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+			_ = qa.ExpectQuestion(t, &survey.Input{
+				Message: `You are about to delete the SSH Key Pair account "Deploy Key" ` + output.Dimf("(%s)", "Accounts-1") + `. This action cannot be reversed. To confirm, type the SSH Key Pair account name:`,
+			}).AnswerWith("Deploy Key")
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/accounts/Accounts-1").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+		}},
+		{"finds the account by ID", "Accounts-2", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/accounts/Accounts-2").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+			assert.Contains(t, out.String(), `The Token account, "Deploy"`)
+		}},
+		{"prints only the ID under --quiet", "Accounts-2", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			output.IsQuiet = true
+			defer func() { output.IsQuiet = false }()
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/accounts/Accounts-2").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+			assert.Equal(t, "Accounts-2\n", out.String())
+		}},
+		{"lists the candidates when the name is ambiguous", "DEPLOY", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+
+			assert.EqualError(t, <-errReceiver, "the name 'DEPLOY' matches more than one account: Deploy (Accounts-2, Token), deploy (Accounts-3, UsernamePassword). Use the ID of the one you want instead")
+		}},
+		{"requires --confirm when prompting is disabled", "Accounts-1", true, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+
+			assert.EqualError(t, <-errReceiver, "deleting the account 'Accounts-1' cannot be undone; pass --confirm to delete it when prompting is disabled")
+		}},
+		{"reports an unknown account", "Accounts-99", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+
+			assert.EqualError(t, <-errReceiver, "cannot find an account with name or ID of 'Accounts-99'")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api, qa := testutil.NewMockServerAndAsker()
+			out := &bytes.Buffer{}
+			opts := delete.NewDeleteOptions(question.NewConfirmFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out, NoPrompt: test.noPrompt}, test.idOrName)
+			opts.Confirm.Value = test.confirm
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer testutil.Close(api, qa)
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Ask = qa.AsAsker()
+				opts.Client = octopus
+				return delete.DeleteRun(opts)
+			})
+
+			test.run(t, api, qa, errReceiver, out)
+		})
+	}
+}
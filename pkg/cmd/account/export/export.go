@@ -0,0 +1,290 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/create"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FlagType       = "type"
+	FlagOutFile    = "out-file"
+	FlagIncludeIds = "include-ids"
+)
+
+type ExportFlags struct {
+	Type       *flag.Flag[string]
+	OutFile    *flag.Flag[string]
+	IncludeIds *flag.Flag[bool]
+}
+
+func NewExportFlags() *ExportFlags {
+	return &ExportFlags{
+		Type:       flag.New[string](FlagType, false),
+		OutFile:    flag.New[string](FlagOutFile, false),
+		IncludeIds: flag.New[bool](FlagIncludeIds, false),
+	}
+}
+
+func NewCmdExport(f factory.Factory) *cobra.Command {
+	exportFlags := NewExportFlags()
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write account definitions to a file",
+		Long: heredoc.Docf(`
+			Write the definitions of accounts in Octopus Deploy as JSON, or as YAML with --output-format yaml, in the
+			form 'account import' reads.
+
+			Secrets such as tokens, passwords and private keys are never written; each one is replaced with %[1]s,
+			which import treats as not given, so it asks for the secret again.
+		`, helper.KeepExisting),
+		Example: heredoc.Docf(`
+			$ %[1]s account export
+			$ %[1]s account export --type SshKeyPair --output-format yaml --out-file accounts.yaml
+		`, constants.ExecutableName),
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
+		RunE: func(c *cobra.Command, _ []string) error {
+			return ExportRun(c, f, exportFlags)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&exportFlags.Type.Value, exportFlags.Type.Name, "t", "", "Only export accounts of the given type, e.g. SshKeyPair")
+	flags.StringVar(&exportFlags.OutFile.Value, exportFlags.OutFile.Name, "", "Write the definitions to this file rather than stdout")
+	flags.BoolVar(&exportFlags.IncludeIds.Value, exportFlags.IncludeIds.Name, false, "Include each account's ID, which import ignores")
+	return cmd
+}
+
+func ExportRun(c *cobra.Command, f factory.Factory, flags *ExportFlags) error {
+	// the definitions are always a document, so the default table format means JSON here
+	format := output.GetOutputFormat(c)
+	switch format {
+	case constants.OutputFormatTable, "":
+		format = constants.OutputFormatJson
+	case constants.OutputFormatJson, constants.OutputFormatYaml:
+	default:
+		return fmt.Errorf("account export can't write --%s %s. Valid values are %s and %s", constants.FlagOutputFormat, format, constants.OutputFormatJson, constants.OutputFormatYaml)
+	}
+	var typeFilter accounts.AccountType
+	if flags.Type.Value != "" {
+		var err error
+		if typeFilter, err = create.ParseAccountType(flags.Type.Value); err != nil {
+			return err
+		}
+	}
+
+	client, err := f.GetSpacedClient(apiclient.NewRequester(c))
+	if err != nil {
+		return err
+	}
+	items, err := client.Accounts.GetAll()
+	if err != nil {
+		return err
+	}
+	// accounts only store environment IDs, so look up the names if any account has some
+	environmentNames := map[string]string{}
+	if len(util.SliceFilter(items, func(item accounts.IAccount) bool { return len(item.GetEnvironmentIDs()) > 0 })) > 0 {
+		environments, err := client.Environments.GetAll()
+		if err != nil {
+			return err
+		}
+		for _, environment := range environments {
+			environmentNames[environment.GetID()] = environment.Name
+		}
+	}
+
+	definitions := []definition{}
+	var skipped []string
+	for _, item := range items {
+		if typeFilter != "" && item.GetAccountType() != typeFilter {
+			continue
+		}
+		d, reason := accountDefinition(item, environmentNames, flags.IncludeIds.Value)
+		if reason != "" {
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", item.GetName(), reason))
+			continue
+		}
+		definitions = append(definitions, d)
+	}
+	if len(skipped) > 0 {
+		output.Infof(c, "Skipped accounts which can't be imported: %s\n", strings.Join(skipped, ", "))
+	}
+
+	var data []byte
+	if format == constants.OutputFormatYaml {
+		data, err = yaml.Marshal(definitions)
+	} else {
+		data, err = marshalJson(definitions, "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	if flags.OutFile.Value == "" {
+		_, err = c.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(flags.OutFile.Value, data, 0600); err != nil {
+		return err
+	}
+	output.Infof(c, "Exported %d accounts to %s\n", len(definitions), flags.OutFile.Value)
+	return nil
+}
+
+// accountDefinition describes account with the flags of its create command, or returns the reason why the create
+// command can't make the same account
+func accountDefinition(account accounts.IAccount, environmentNames map[string]string, includeIds bool) (definition, string) {
+	d := definition{{"type", string(account.GetAccountType())}}
+	if includeIds {
+		d = d.with("id", account.GetID())
+	}
+	d = d.with("name", account.GetName())
+	if account.GetDescription() != "" {
+		d = d.with("description", account.GetDescription())
+	}
+
+	switch a := account.(type) {
+	case *accounts.AmazonWebServicesAccount:
+		d = d.with("access-key", a.AccessKey).withSecret("secret-key", a.SecretKey)
+	case *accounts.AzureServicePrincipalAccount:
+		d = d.with("subscription-id", uuidString(a.SubscriptionID)).
+			with("tenant-id", uuidString(a.TenantID)).
+			with("application-id", uuidString(a.ApplicationID)).
+			withSecret("application-key", a.ApplicationPassword)
+		if a.AzureEnvironment != "" {
+			d = d.with("azure-environment", a.AzureEnvironment).
+				with("ad-endpoint-base-uri", a.AuthenticationEndpoint).
+				with("resource-management-base-uri", a.ResourceManagerEndpoint)
+		}
+	case *accounts.GoogleCloudPlatformAccount:
+		d = d.withSecret("key-file", a.JsonKey)
+	case *accounts.SSHKeyAccount:
+		d = d.with("username", a.Username).
+			withSecret("private-key", a.PrivateKeyFile).
+			withSecret("passphrase", a.PrivateKeyPassphrase)
+	case *accounts.TokenAccount:
+		d = d.withSecret("token", a.Token)
+	case *accounts.UsernamePasswordAccount:
+		d = d.with("username", a.Username).withSecret("password", a.Password)
+	default:
+		return nil, fmt.Sprintf("the CLI can't create %s accounts", account.GetAccountType())
+	}
+
+	if environmentIDs := account.GetEnvironmentIDs(); len(environmentIDs) > 0 {
+		// names read better and carry over to other spaces and servers; IDs are kept for environments we can't see
+		d = d.with(helper.FlagEnvironment, util.SliceTransform(environmentIDs, func(id string) string {
+			if name, ok := environmentNames[id]; ok {
+				return name
+			}
+			return id
+		}))
+	}
+	// the create commands can only scope accounts to tenants through tags, and only SSH accounts at that
+	if len(account.GetTenantIDs()) > 0 {
+		return nil, "it is scoped to tenants by name, which import can't set"
+	}
+	if account.GetTenantedDeploymentMode() == core.TenantedDeploymentModeTenanted {
+		return nil, "it can only be used by tenanted deployments, which import can't set"
+	}
+	if len(account.GetTenantTags()) > 0 {
+		if _, ok := account.(*accounts.SSHKeyAccount); !ok {
+			return nil, "only SSH key pair accounts can be created with tenant tags"
+		}
+		d = d.with("tenant-tag", account.GetTenantTags())
+	}
+	return d, ""
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+type setting struct {
+	key   string
+	value any
+}
+
+// definition is an account's settings in the order they're written, which neither encoding keeps for a map
+type definition []setting
+
+func (d definition) with(key string, value any) definition {
+	return append(d, setting{key, value})
+}
+
+// withSecret adds a placeholder for value, if it's set
+func (d definition) withSecret(key string, value *core.SensitiveValue) definition {
+	if value == nil || !value.HasValue {
+		return d
+	}
+	return d.with(key, helper.KeepExisting)
+}
+
+func (d definition) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, s := range d {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := marshalJson(s.key, "")
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalJson(s.value, "")
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (d definition) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range d {
+		value := &yaml.Node{}
+		if err := value.Encode(s.value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: s.key}, value)
+	}
+	return node, nil
+}
+
+// marshalJson is json.MarshalIndent without the escaping for HTML, which would turn KeepExisting into \u003ckeep...
+// It ends with a newline.
+func marshalJson(v any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,164 @@
+package export_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestAccountExport(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	secret := &core.SensitiveValue{HasValue: true} // as the server returns it
+
+	sshAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", secret)
+	sshAccount.ID = "Accounts-1"
+	sshAccount.Description = "Deploys the web servers"
+	sshAccount.EnvironmentIDs = []string{"Environments-1", "Environments-7"}
+	sshAccount.TenantTags = []string{"Region/us-east"}
+	tokenAccount, _ := accounts.NewTokenAccount("Registry", secret)
+	tokenAccount.ID = "Accounts-2"
+	azureAccount, _ := accounts.NewAzureSubscriptionAccount("Old Azure", uuid.New())
+	azureAccount.ID = "Accounts-3"
+	tenantedAccount, _ := accounts.NewTokenAccount("Tenant registry", secret)
+	tenantedAccount.ID = "Accounts-4"
+	tenantedAccount.TenantIDs = []string{"Tenants-1"}
+	tenantedAccount.TenantedDeploymentMode = core.TenantedDeploymentModeTenanted
+	allAccounts := []accounts.IAccount{sshAccount, tokenAccount, azureAccount, tenantedAccount}
+	allEnvironments := []*environments.Environment{fixtures.NewEnvironment("Spaces-1", "Environments-1", "Production")}
+
+	tests := []struct {
+		name string
+		args []string
+		out  string
+		err  string
+	}{
+		{"writes JSON without secrets", nil, heredoc.Doc(`
+			[
+			  {
+			    "type": "SshKeyPair",
+			    "name": "Web deploy",
+			    "description": "Deploys the web servers",
+			    "username": "deploy",
+			    "private-key": "<keep existing>",
+			    "environment": [
+			      "Production",
+			      "Environments-7"
+			    ],
+			    "tenant-tag": [
+			      "Region/us-east"
+			    ]
+			  },
+			  {
+			    "type": "Token",
+			    "name": "Registry",
+			    "token": "<keep existing>"
+			  }
+			]
+		`), ""},
+		{"writes YAML with IDs", []string{"-f", "yaml", "--include-ids"}, heredoc.Doc(`
+			- type: SshKeyPair
+			  id: Accounts-1
+			  name: Web deploy
+			  description: Deploys the web servers
+			  username: deploy
+			  private-key: <keep existing>
+			  environment:
+			    - Production
+			    - Environments-7
+			  tenant-tag:
+			    - Region/us-east
+			- type: Token
+			  id: Accounts-2
+			  name: Registry
+			  token: <keep existing>
+		`), ""},
+		{"rejects output formats other than json and yaml", []string{"-f", "csv"}, "", "account export can't write --output-format csv. Valid values are json and yaml"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			out, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			rootCmd.SetOut(out)
+			rootCmd.SetErr(stderr)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"account", "export", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			if test.err == "" {
+				api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+				api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+				api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+				api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			}
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			if test.err == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, test.out, out.String())
+				assert.Equal(t, "Skipped accounts which can't be imported: Old Azure (the CLI can't create AzureSubscription accounts), Tenant registry (it is scoped to tenants by name, which import can't set)\n", stderr.String())
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestAccountExportToFile(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	tokenAccount, _ := accounts.NewTokenAccount("Registry", &core.SensitiveValue{HasValue: true})
+	tokenAccount.ID = "Accounts-2"
+	outFile := filepath.Join(t.TempDir(), "accounts.json")
+
+	api, qa := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(qa.AsAsker())
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+	out, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(stderr)
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		rootCmd.SetArgs([]string{"account", "export", "--type", "token", "--out-file", outFile, "--no-prompt"})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{tokenAccount})
+
+	_, err := testutil.ReceivePair(cmdReceiver)
+	assert.Nil(t, err)
+	assert.Equal(t, "", out.String())
+	assert.Equal(t, "Exported 1 accounts to "+outFile+"\n", stderr.String())
+	data, err := os.ReadFile(outFile)
+	assert.Nil(t, err)
+	assert.Equal(t, `[
+  {
+    "type": "Token",
+    "name": "Registry",
+    "token": "<keep existing>"
+  }
+]
+`, string(data))
+}
@@ -0,0 +1,102 @@
+package find
+
+import (
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/list"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/spf13/cobra"
+)
+
+const FlagFingerprint = "fingerprint"
+
+func NewCmdFind(f factory.Factory) *cobra.Command {
+	var fingerprint string
+	cmd := &cobra.Command{
+		Use:   "find",
+		Short: "Find accounts by key fingerprint",
+		Long: heredoc.Doc(`
+			Find the accounts in Octopus Deploy which use the key with the given fingerprint.
+
+			Octopus Deploy never returns private key material, so only accounts for which the server reports a
+			fingerprint or thumbprint can be matched. Currently that is the certificate thumbprint of Azure
+			Subscription accounts; SSH key pair accounts can't be searched this way.
+		`),
+		Example: heredoc.Docf(`
+			$ %[1]s account find --fingerprint 8A4B1C2D3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8B
+			$ %[1]s account find --fingerprint 8a:4b:1c:2d:3e:4f:5a:6b:7c:8d:9e:0f:1a:2b:3c:4d:5e:6f:7a:8b -f json
+		`, constants.ExecutableName),
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return findRun(f, cmd, fingerprint)
+		},
+	}
+
+	cmd.Flags().StringVar(&fingerprint, FlagFingerprint, "", "The fingerprint or thumbprint of the key to look for, in hex. Case, colons and spaces are ignored")
+	_ = cmd.MarkFlagRequired(FlagFingerprint)
+
+	return cmd
+}
+
+func findRun(f factory.Factory, cmd *cobra.Command, fingerprint string) error {
+	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
+	if err != nil {
+		return err
+	}
+
+	items, err := client.Accounts.GetAll()
+	if err != nil {
+		return err
+	}
+
+	wanted := NormalizeFingerprint(fingerprint)
+	items = util.SliceFilter(items, func(item accounts.IAccount) bool {
+		found := Fingerprint(item)
+		return found != "" && NormalizeFingerprint(found) == wanted
+	})
+
+	type AccountJson struct {
+		Id          string
+		Slug        string
+		Name        string
+		Type        string
+		Fingerprint string
+	}
+
+	return output.PrintArray(items, cmd, output.Mappers[accounts.IAccount]{
+		Json: func(item accounts.IAccount) any {
+			return AccountJson{Id: item.GetID(), Slug: item.GetSlug(), Name: item.GetName(), Type: string(item.GetAccountType()), Fingerprint: Fingerprint(item)}
+		},
+		Table: output.TableDefinition[accounts.IAccount]{
+			Header: []string{"NAME", "TYPE", "ID"},
+			Row: func(item accounts.IAccount) []string {
+				return []string{output.Bold(item.GetName()), list.AccountTypeMap[item.GetAccountType()], item.GetID()}
+			}},
+		Basic: func(item accounts.IAccount) string {
+			return item.GetName()
+		},
+	})
+}
+
+// Fingerprint returns the fingerprint of the key an account uses, as reported by the server, or an empty
+// string if the server doesn't report one for this type of account.
+func Fingerprint(account accounts.IAccount) string {
+	switch a := account.(type) {
+	case *accounts.AzureSubscriptionAccount:
+		return a.CertificateThumbprint
+	default:
+		return ""
+	}
+}
+
+// NormalizeFingerprint puts a hex fingerprint in a canonical form, so that 'AB:CD' and 'abcd' compare as equal
+func NormalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", " ", "", "-", "").Replace(strings.TrimSpace(fingerprint)))
+}
@@ -0,0 +1,93 @@
+package find_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestAccountFind(t *testing.T) {
+	const spaceID = "Spaces-1"
+	const thumbprint = "8A4B1C2D3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8B"
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+
+	azureAccount, _ := accounts.NewAzureSubscriptionAccount("Azure Prod", uuid.MustParse("7a1b2c3d-4e5f-4a6b-8c9d-0e1f2a3b4c5d"))
+	azureAccount.ID = "Accounts-1"
+	azureAccount.CertificateThumbprint = thumbprint
+	otherAzureAccount, _ := accounts.NewAzureSubscriptionAccount("Azure Test", uuid.MustParse("1f2e3d4c-5b6a-4978-8a9b-0c1d2e3f4a5b"))
+	otherAzureAccount.ID = "Accounts-2"
+	otherAzureAccount.CertificateThumbprint = "0000000000000000000000000000000000000000"
+	sshAccount, _ := accounts.NewSSHKeyAccount("Deploy Key", "deploy", core.NewSensitiveValue(""))
+	sshAccount.ID = "Accounts-3"
+	allAccounts := []accounts.IAccount{azureAccount, otherAzureAccount, sshAccount}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer)
+	}{
+		{"finds the account using the key", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "find", "--fingerprint", "8a:4b:1c:2d:3e:4f:5a:6b:7c:8d:9e:0f:1a:2b:3c:4d:5e:6f:7a:8b", "--no-prompt", "-f", "json"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+
+			type AccountJson struct {
+				Id          string
+				Slug        string
+				Name        string
+				Type        string
+				Fingerprint string
+			}
+			results, err := testutil.ParseJsonStrict[[]AccountJson](stdOut)
+			assert.Nil(t, err)
+			assert.Equal(t, []AccountJson{{Id: "Accounts-1", Name: "Azure Prod", Type: "AzureSubscription", Fingerprint: thumbprint}}, results)
+		}},
+
+		{"finds nothing for an unknown key", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "find", "--fingerprint", "ffff", "--no-prompt", "-f", "basic"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Equal(t, "", stdOut.String())
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			test.run(t, api, rootCmd, stdout)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package create
 
 import (
 	b64 "encoding/base64"
+	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"os"
@@ -50,17 +51,18 @@ func NewCreateFlags() *CreateFlags {
 
 func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
 	return &CreateOptions{
-		CreateFlags:  flags,
-		Dependencies: dependencies,
-		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
-			return selectors.GetAllEnvironments(dependencies.Client)
-		},
+		CreateFlags:                flags,
+		Dependencies:               dependencies,
+		GetAllEnvironmentsCallback: dependencies.GetAllEnvironments,
 	}
 }
 
 func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 	descriptionFilePath := ""
+	strict := false
+	anyEnvironment := false
+	createMissingEnvironments := false
 
 	cmd := &cobra.Command{
 		Use:     "create",
@@ -70,32 +72,25 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 		Aliases: []string{"new"},
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
+			if descriptionFilePath == util.StdinPath && opts.KeyFilePath.Value == util.StdinPath {
+				return errors.New("only one of --description-file and --key-file can be read from stdin")
+			}
 			if descriptionFilePath != "" {
-				if err := validation.IsExistingFile(descriptionFilePath); err != nil {
-					return err
-				}
-				data, err := os.ReadFile(descriptionFilePath)
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
 				if err != nil {
 					return err
 				}
 				opts.Description.Value = string(data)
 			}
 			if opts.KeyFilePath.Value != "" {
-				if err := validation.IsExistingFile(opts.KeyFilePath.Value); err != nil {
-					return err
-				}
-				data, err := os.ReadFile(opts.KeyFilePath.Value)
+				data, err := util.ReadFileOrStdin(opts.KeyFilePath.Value, c.InOrStdin())
 				if err != nil {
 					return err
 				}
 				opts.KeyFileData = data
 			}
-			if opts.Environments.Value != nil {
-				env, err := helper.ResolveEnvironmentNames(opts.Environments.Value, opts.Client)
-				if err != nil {
-					return err
-				}
-				opts.Environments.Value = env
+			if err := helper.ResolveEnvironmentFlags(c, opts.Environments, opts.Dependencies, strict, anyEnvironment, createMissingEnvironments); err != nil {
+				return err
 			}
 			return CreateRun(opts)
 		},
@@ -104,9 +99,13 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "A short, memorable, unique name for this account.")
 	flags.StringVarP(&createFlags.Description.Value, createFlags.Description.Name, "d", "", "A summary explaining the use of the account to other users.")
-	flags.StringVarP(&createFlags.KeyFilePath.Value, createFlags.KeyFilePath.Name, "K", "", "The json key file to use when authenticating against Google Cloud.")
+	flags.StringVarP(&createFlags.KeyFilePath.Value, createFlags.KeyFilePath.Name, "K", "", "The json key file to use when authenticating against Google Cloud, or - to read it from stdin.")
 	flags.StringArrayVarP(&createFlags.Environments.Value, createFlags.Environments.Name, "e", nil, "The environments that are allowed to use this account")
-	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`")
+	helper.RegisterEnvironmentCompletion(cmd, f, createFlags.Environments.Name)
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+	helper.RegisterStrictFlag(cmd, &strict)
+	helper.RegisterAnyEnvironmentFlag(cmd, &anyEnvironment)
+	helper.RegisterCreateMissingEnvironmentsFlag(cmd, &createMissingEnvironments)
 
 	return cmd
 }
@@ -129,13 +128,16 @@ func CreateRun(opts *CreateOptions) error {
 
 	createdAccount, err := opts.Client.Accounts.Add(gcpAccount)
 	if err != nil {
-		return err
+		return helper.ExplainEnvironmentLimitError(err, opts.Environments.Value)
 	}
 
-	_, err = fmt.Fprintf(opts.Out, "Successfully created GCP account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
+	err = output.Successf(opts.Out, createdAccount.GetID(), "Successfully created GCP account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), createdAccount.GetID())
 	_, _ = fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", link)
 	if !opts.NoPrompt {
@@ -4,6 +4,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
@@ -13,11 +14,12 @@ import (
 
 func NewCmdList(f factory.Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List Google Cloud accounts",
-		Long:    "List Google Cloud accounts in Octopus Deploy",
-		Example: heredoc.Docf("$ %s account gcp list", constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Use:         "list",
+		Short:       "List Google Cloud accounts",
+		Long:        "List Google Cloud accounts in Octopus Deploy",
+		Example:     heredoc.Docf("$ %s account gcp list", constants.ExecutableName),
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
 			if err != nil {
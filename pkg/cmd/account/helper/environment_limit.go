@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	FlagStrict = "strict"
+
+	// DefaultMaxEnvironments is how many environments an account can be scoped to before we
+	// start warning about it; override it with OCTOPUS_MAX_ACCOUNT_ENVIRONMENTS.
+	DefaultMaxEnvironments = 50
+)
+
+// RegisterStrictFlag adds the --strict flag, which turns the environment count warning into an error.
+func RegisterStrictFlag(cmd *cobra.Command, value *bool) {
+	cmd.Flags().BoolVar(value, FlagStrict, false, "Fail instead of warning when more environments are given than the configured maximum.")
+}
+
+// GetMaxEnvironments returns the configured soft cap on how many environments an account can be scoped to.
+func GetMaxEnvironments() (int, error) {
+	value := viper.GetString(constants.ConfigMaxAccountEnvironments)
+	if value == "" {
+		return DefaultMaxEnvironments, nil
+	}
+	maxEnvironments, err := strconv.Atoi(value)
+	if err != nil || maxEnvironments < 1 {
+		return 0, &cliErrors.OsEnvironmentInvalidError{
+			EnvironmentVariable: constants.EnvMaxAccountEnvironments,
+			Value:               value,
+			Reason:              "it must be a whole number greater than zero",
+		}
+	}
+	return maxEnvironments, nil
+}
+
+// CheckEnvironmentCount warns when an account is about to be scoped to more environments than the
+// configured maximum, which usually means a shell glob expanded to more than was intended.
+// If strict is set, it returns an error instead.
+func CheckEnvironmentCount(cmd *cobra.Command, envs []string, strict bool) error {
+	maxEnvironments, err := GetMaxEnvironments()
+	if err != nil {
+		return err
+	}
+	if len(envs) <= maxEnvironments {
+		return nil
+	}
+	message := fmt.Sprintf("%d environments were specified, which is more than the maximum of %d", len(envs), maxEnvironments)
+	if strict {
+		return fmt.Errorf("%s; specify fewer environments, or set %s to raise the limit", message, constants.EnvMaxAccountEnvironments)
+	}
+	output.Warnf(cmd, "Warning: %s. Check the --environment values are what you intended.\n", message)
+	return nil
+}
+
+var serverEnvironmentLimitRE = regexp.MustCompile(`(?i)(?:maximum of|at most|up to|limit of|more than)\s+(\d+)\s+environments?`)
+
+// ExplainEnvironmentLimitError checks whether err is the Octopus Server rejecting an account for
+// being scoped to too many environments, and if so, rewrites it to state the server's limit clearly.
+// Any other error is returned unchanged.
+func ExplainEnvironmentLimitError(err error, envs []string) error {
+	if err == nil {
+		return nil
+	}
+	matches := serverEnvironmentLimitRE.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return err
+	}
+	return fmt.Errorf("the Octopus Server allows an account to be scoped to at most %s environments, but %d were specified: %w", matches[1], len(envs), err)
+}
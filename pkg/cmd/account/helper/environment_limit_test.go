@@ -0,0 +1,68 @@
+package helper_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEnvironmentCount(t *testing.T) {
+	newCmd := func() (*cobra.Command, *bytes.Buffer) {
+		stdErr := &bytes.Buffer{}
+		cmd := &cobra.Command{}
+		cmd.SetErr(stdErr)
+		return cmd, stdErr
+	}
+	envs := []string{"dev", "test", "staging", "production"}
+
+	t.Run("stays quiet when under the limit", func(t *testing.T) {
+		viper.Reset()
+		cmd, stdErr := newCmd()
+		assert.Nil(t, helper.CheckEnvironmentCount(cmd, envs, true))
+		assert.Equal(t, "", stdErr.String())
+	})
+
+	t.Run("warns when over the limit", func(t *testing.T) {
+		viper.Reset()
+		viper.Set(constants.ConfigMaxAccountEnvironments, "3")
+		cmd, stdErr := newCmd()
+		assert.Nil(t, helper.CheckEnvironmentCount(cmd, envs, false))
+		assert.Contains(t, stdErr.String(), "Warning: 4 environments were specified, which is more than the maximum of 3. Check the --environment values are what you intended.")
+	})
+
+	t.Run("fails when over the limit with strict", func(t *testing.T) {
+		viper.Reset()
+		viper.Set(constants.ConfigMaxAccountEnvironments, "3")
+		cmd, stdErr := newCmd()
+		err := helper.CheckEnvironmentCount(cmd, envs, true)
+		assert.EqualError(t, err, "4 environments were specified, which is more than the maximum of 3; specify fewer environments, or set OCTOPUS_MAX_ACCOUNT_ENVIRONMENTS to raise the limit")
+		assert.Equal(t, "", stdErr.String())
+	})
+
+	t.Run("rejects an invalid limit", func(t *testing.T) {
+		viper.Reset()
+		viper.Set(constants.ConfigMaxAccountEnvironments, "0")
+		cmd, _ := newCmd()
+		assert.Error(t, helper.CheckEnvironmentCount(cmd, envs, false))
+	})
+	viper.Reset()
+}
+
+func TestExplainEnvironmentLimitError(t *testing.T) {
+	envs := []string{"dev", "test", "staging", "production"}
+
+	serverErr := errors.New("octopus deploy api returned an error on endpoint /api/Spaces-1/accounts - [An account can be scoped to a maximum of 3 environments.]")
+	err := helper.ExplainEnvironmentLimitError(serverErr, envs)
+	assert.EqualError(t, err, "the Octopus Server allows an account to be scoped to at most 3 environments, but 4 were specified: "+serverErr.Error())
+	assert.True(t, errors.Is(err, serverErr))
+
+	otherErr := errors.New("name already in use")
+	assert.Equal(t, otherErr, helper.ExplainEnvironmentLimitError(otherErr, envs))
+	assert.Nil(t, helper.ExplainEnvironmentLimitError(nil, envs))
+}
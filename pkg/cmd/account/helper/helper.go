@@ -1,36 +1,364 @@
 package helper
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
 	"strings"
 
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
 )
 
-// ResolveEnvironmentNames takes in an array of names and trys to find an exact match.
-// If a match is found it will return its corresponding ID. If no match is found
-// it will return the name as is, in assumption it is an ID.
-func ResolveEnvironmentNames(envs []string, octopus *client.Client) ([]string, error) {
+const (
+	FlagEnvironment               = "environment"
+	FlagAnyEnvironment            = "any-environment"
+	FlagCreateMissingEnvironments = "create-missing-environments"
+	FlagEnvironmentsFile          = "environments-file"
+
+	CreatedEnvironmentDescription = "Created automatically by the Octopus CLI when creating an account."
+
+	// KeepExisting stands in for a secret in exported account definitions, which never contain the secret itself.
+	// Import treats a setting with this value as not given.
+	KeepExisting = "<keep existing>"
+)
+
+var environmentIDRE = regexp.MustCompile(`^(?i)Environments-\d+$`)
+
+// RegisterCreateMissingEnvironmentsFlag adds the --create-missing-environments flag.
+func RegisterCreateMissingEnvironmentsFlag(cmd *cobra.Command, value *bool) {
+	cmd.Flags().BoolVar(value, FlagCreateMissingEnvironments, false, "Create any environment given by name which doesn't exist yet, rather than failing.")
+}
+
+// RegisterAnyEnvironmentFlag adds the --any-environment flag, which can't be given along with --environment, so
+// it must come after that flag.
+func RegisterAnyEnvironmentFlag(cmd *cobra.Command, value *bool) {
+	cmd.Flags().BoolVar(value, FlagAnyEnvironment, false, "Allow the account to be used for deployments to any environment. Leaving out --environment does the same, but this says so on purpose and isn't asked about.")
+	cmd.MarkFlagsMutuallyExclusive(FlagAnyEnvironment, FlagEnvironment)
+}
+
+// RegisterEnvironmentsFileFlag adds the --environments-file flag.
+func RegisterEnvironmentsFileFlag(cmd *cobra.Command, value *string) {
+	cmd.Flags().StringVar(value, FlagEnvironmentsFile, "", "Read environment names from `file`, one per line, in addition to any given by --environment. Blank lines and lines starting with # are ignored.")
+}
+
+// ReadEnvironmentsFile returns the environment names listed in the file at path, one per line, with
+// surrounding whitespace trimmed. Blank lines and lines starting with # are skipped, so the file can have comments.
+func ReadEnvironmentsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// nameIndex finds the ID of a resource from its name, ignoring case, or from its ID. The resolvers below
+// fill one from a single GetAll and then answer from it.
+type nameIndex struct {
+	ids      map[string][]string // lowercased name -> IDs, more than one if names differ only by case
+	knownIDs map[string]string   // lowercased ID -> ID
+}
+
+func newNameIndex(capacity int) *nameIndex {
+	return &nameIndex{ids: make(map[string][]string, capacity), knownIDs: make(map[string]string, capacity)}
+}
+
+func (n *nameIndex) add(name string, id string) {
+	lowerName := strings.ToLower(name)
+	n.ids[lowerName] = append(n.ids[lowerName], id)
+	n.knownIDs[strings.ToLower(id)] = id
+}
+
+// find matches nameOrID against names first and then IDs. If it is a name shared by more than one resource,
+// find doesn't pick one; it returns them all as ambiguous instead.
+func (n *nameIndex) find(nameOrID string) (id string, ambiguous []string, ok bool) {
+	if matches := n.ids[strings.ToLower(nameOrID)]; len(matches) == 1 {
+		return matches[0], nil, true
+	} else if len(matches) > 1 {
+		return "", matches, false
+	}
+	id, ok = n.knownIDs[strings.ToLower(nameOrID)]
+	return id, nil, ok
+}
+
+// EnvironmentResolver turns environment names into IDs. It loads the space's environments the first time
+// it's asked, and then answers from memory, so that resolving the environments for many accounts in one run
+// only costs a single request. Use one per command invocation; nothing is persisted.
+type EnvironmentResolver struct {
+	Client        *client.Client
+	CreateMissing bool
+	// GetAllEnvironments loads the environments to match against; it defaults to fetching them with Client
+	GetAllEnvironments func() ([]*environments.Environment, error)
+
+	// the environments we created because CreateMissing was set
+	Created []*environments.Environment
+
+	environments *nameIndex // nil until loaded
+}
+
+func NewEnvironmentResolver(octopus *client.Client, createMissing bool) *EnvironmentResolver {
+	return &EnvironmentResolver{Client: octopus, CreateMissing: createMissing, GetAllEnvironments: octopus.Environments.GetAll}
+}
+
+// Resolve returns the ID of each environment in envs, matching names case-insensitively, then IDs.
+// If CreateMissing is set, an environment is created for each name which doesn't match (IDs never are).
+// Otherwise, every entry which matches neither a name nor an ID is reported together in one multierror,
+// so that a long list can be fixed in a single pass. A name which matches more than one environment is
+// reported the same way, rather than picking one of them; those have to be given by ID.
+func (r *EnvironmentResolver) Resolve(envs []string) ([]string, error) {
+	if r.environments == nil {
+		allEnvironments, err := r.GetAllEnvironments()
+		if err != nil {
+			return nil, err
+		}
+		r.environments = newNameIndex(len(allEnvironments))
+		for _, env := range allEnvironments {
+			r.environments.add(env.Name, env.ID)
+		}
+	}
+
+	unresolved := &multierror.Error{}
 	envIds := make([]string, 0, len(envs))
-loop:
 	for _, envName := range envs {
-		matches, err := octopus.Environments.Get(environments.EnvironmentsQuery{
-			Name: envName,
-		})
+		envID, ambiguous, ok := r.environments.find(envName)
+		if ok {
+			envIds = append(envIds, envID)
+			continue
+		}
+		if len(ambiguous) > 0 {
+			unresolved = multierror.Append(unresolved, fmt.Errorf("more than one environment is named '%s' (%s); give the ID of the one you want instead", envName, strings.Join(ambiguous, ", ")))
+			continue
+		}
+		if r.CreateMissing && !environmentIDRE.MatchString(envName) {
+			environment := environments.NewEnvironment(envName)
+			environment.Description = CreatedEnvironmentDescription
+			createdEnvironment, err := r.Client.Environments.Add(environment)
+			if err != nil {
+				return nil, err
+			}
+			r.Created = append(r.Created, createdEnvironment)
+			r.environments.add(createdEnvironment.Name, createdEnvironment.ID)
+			envIds = append(envIds, createdEnvironment.ID)
+			continue
+		}
+		unresolved = multierror.Append(unresolved, fmt.Errorf("cannot find an environment with name or ID of '%s'", envName))
+	}
+	if err := unresolved.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return envIds, nil
+}
+
+// ProjectGroupResolver turns project group names into IDs, loading the space's project groups once in the
+// same way as EnvironmentResolver.
+type ProjectGroupResolver struct {
+	Client *client.Client
+	// GetAllProjectGroups loads the project groups to match against; it defaults to fetching them with Client
+	GetAllProjectGroups func() ([]*projectgroups.ProjectGroup, error)
+
+	projectGroups *nameIndex // nil until loaded
+}
+
+func NewProjectGroupResolver(octopus *client.Client) *ProjectGroupResolver {
+	return &ProjectGroupResolver{Client: octopus, GetAllProjectGroups: octopus.ProjectGroups.GetAll}
+}
+
+// Resolve returns the ID of each project group in groups, matching names case-insensitively, then IDs. Every
+// entry which can't be resolved, or whose name is shared by more than one project group, is reported together
+// in one multierror.
+func (r *ProjectGroupResolver) Resolve(groups []string) ([]string, error) {
+	if r.projectGroups == nil {
+		allProjectGroups, err := r.GetAllProjectGroups()
 		if err != nil {
 			return nil, err
 		}
-		allMatches, err := matches.GetAllPages(octopus.Environments.GetClient())
+		r.projectGroups = newNameIndex(len(allProjectGroups))
+		for _, group := range allProjectGroups {
+			r.projectGroups.add(group.Name, group.GetID())
+		}
+	}
+
+	unresolved := &multierror.Error{}
+	groupIds := make([]string, 0, len(groups))
+	for _, groupName := range groups {
+		groupID, ambiguous, ok := r.projectGroups.find(groupName)
+		if ok {
+			groupIds = append(groupIds, groupID)
+		} else if len(ambiguous) > 0 {
+			unresolved = multierror.Append(unresolved, fmt.Errorf("more than one project group is named '%s' (%s); give the ID of the one you want instead", groupName, strings.Join(ambiguous, ", ")))
+		} else {
+			unresolved = multierror.Append(unresolved, fmt.Errorf("cannot find a project group with name or ID of '%s'", groupName))
+		}
+	}
+	if err := unresolved.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return groupIds, nil
+}
+
+// ResolveEnvironmentNames takes in an array of names or IDs and returns the ID of each. Any which can't be found
+// are all reported together in a multierror.
+func ResolveEnvironmentNames(envs []string, octopus *client.Client) ([]string, error) {
+	return NewEnvironmentResolver(octopus, false).Resolve(envs)
+}
+
+// ResolveProjectGroupNames takes in an array of project group names or IDs and returns the ID of each. Any which
+// can't be found are all reported together in a multierror.
+func ResolveProjectGroupNames(groups []string, octopus *client.Client) ([]string, error) {
+	return NewProjectGroupResolver(octopus).Resolve(groups)
+}
+
+// ResolveOrCreateEnvironmentNames is the same as ResolveEnvironmentNames, but if createMissing is set,
+// names which don't match an environment are created (IDs never are), and each one we create is reported on stderr.
+// The environments come from dependencies, so a command which also prompts for environments only fetches them once.
+func ResolveOrCreateEnvironmentNames(c *cobra.Command, envs []string, dependencies *cmd.Dependencies, createMissing bool) ([]string, error) {
+	resolver := NewEnvironmentResolver(dependencies.Client, createMissing)
+	resolver.GetAllEnvironments = dependencies.GetAllEnvironments
+	envIds, err := resolver.Resolve(envs)
+	for _, env := range resolver.Created {
+		dependencies.AddEnvironment(env)
+		output.Infof(c, "Created environment %s %s\n", env.Name, output.Dimf("(%s)", env.ID))
+	}
+	return envIds, err
+}
+
+// ResolveEnvironmentFlags applies an account command's environment flags to environments: --any-environment
+// empties it, so that we don't ask for environments either; otherwise any environments given are checked against
+// the limit and turned into IDs, creating those which don't exist if createMissing is set.
+// If no environments were given at all it is left nil, for the command to ask.
+func ResolveEnvironmentFlags(c *cobra.Command, environments *flag.Flag[[]string], dependencies *cmd.Dependencies, strict bool, anyEnvironment bool, createMissing bool) error {
+	if anyEnvironment {
+		environments.Value = []string{}
+		return nil
+	}
+	if environments.Value == nil {
+		return nil
+	}
+	if err := CheckEnvironmentCount(c, environments.Value, strict); err != nil {
+		return err
+	}
+	envIds, err := ResolveOrCreateEnvironmentNames(c, environments.Value, dependencies, createMissing)
+	if err != nil {
+		return err
+	}
+	environments.Value = envIds
+	return nil
+}
+
+// RegisterEnvironmentCompletion makes the repeatable environments flag called flagName tab-complete the names
+// of the environments in the space. Environments already given to the flag aren't offered again.
+func RegisterEnvironmentCompletion(cmd *cobra.Command, f factory.Factory, flagName string) {
+	_ = cmd.RegisterFlagCompletionFunc(flagName, func(c *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		octopus, err := f.GetSpacedClient(apiclient.NewRequester(c))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		allEnvironments, err := octopus.Environments.GetAll()
 		if err != nil {
-			return nil, err
+			return nil, cobra.ShellCompDirectiveError
 		}
-		for _, match := range allMatches {
-			if strings.EqualFold(envName, match.Name) {
-				envIds = append(envIds, match.ID)
-				continue loop
+		alreadyGiven, _ := c.Flags().GetStringArray(flagName)
+		var names []string
+		for _, env := range allEnvironments {
+			if !strings.HasPrefix(strings.ToLower(env.Name), strings.ToLower(toComplete)) {
+				continue
+			}
+			given := false
+			for _, g := range alreadyGiven {
+				if strings.EqualFold(g, env.Name) || strings.EqualFold(g, env.ID) {
+					given = true
+					break
+				}
+			}
+			if !given {
+				names = append(names, env.Name)
 			}
 		}
-		envIds = append(envIds, envName)
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// AccountEnvVars are the variables common to every account type for the env output format. Account
+// commands append their type-specific, non-sensitive fields to these.
+func AccountEnvVars(account accounts.IAccount) []output.EnvVar {
+	return []output.EnvVar{
+		{Key: "OCTOPUS_ACCOUNT_ID", Value: account.GetID()},
+		{Key: "OCTOPUS_ACCOUNT_NAME", Value: account.GetName()},
+		{Key: "OCTOPUS_ACCOUNT_SLUG", Value: account.GetSlug()},
+		{Key: "OCTOPUS_ACCOUNT_TYPE", Value: string(account.GetAccountType())},
+		{Key: "OCTOPUS_SPACE_ID", Value: account.GetSpaceID()},
+	}
+}
+
+// FindAccountByName returns the account of the given type with this name, ignoring case, or nil if there isn't
+// one. It's how create commands find out whether an earlier attempt already created the account
+func FindAccountByName(octopus *client.Client, name string, accountType accounts.AccountType) (accounts.IAccount, error) {
+	allAccounts, err := octopus.Accounts.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range allAccounts {
+		if account.GetAccountType() == accountType && strings.EqualFold(account.GetName(), name) {
+			return account, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindAccount looks up an account of any type by name or ID. As with spaces, a match on the name is preferred,
+// falling back to the ID. Account names are only unique within a type, so if the name matches more than one
+// account it's an error, which lists them so the user can pick one by ID instead.
+func FindAccount(octopus *client.Client, idOrName string) (accounts.IAccount, error) {
+	allAccounts, err := octopus.Accounts.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var foundByName []accounts.IAccount
+	var foundByID accounts.IAccount
+	for _, account := range allAccounts {
+		if strings.EqualFold(account.GetName(), idOrName) {
+			foundByName = append(foundByName, account)
+		}
+		if strings.EqualFold(account.GetID(), idOrName) {
+			foundByID = account
+		}
+	}
+	switch {
+	case len(foundByName) == 1:
+		return foundByName[0], nil
+	case len(foundByName) > 1:
+		candidates := make([]string, 0, len(foundByName))
+		for _, account := range foundByName {
+			candidates = append(candidates, fmt.Sprintf("%s (%s, %s)", account.GetName(), account.GetID(), account.GetAccountType()))
+		}
+		return nil, fmt.Errorf("the name '%s' matches more than one account: %s. Use the ID of the one you want instead", idOrName, strings.Join(candidates, ", "))
+	case foundByID != nil:
+		return foundByID, nil
+	default:
+		return nil, fmt.Errorf("cannot find an account with name or ID of '%s'", idOrName)
 	}
-	return envIds, nil
 }
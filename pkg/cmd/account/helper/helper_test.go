@@ -0,0 +1,312 @@
+package helper_test
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func TestResolveOrCreateEnvironmentNames(t *testing.T) {
+	const spaceID = "Spaces-1"
+	devEnvironment := fixtures.NewEnvironment(spaceID, "Environments-1", "Dev")
+	stagingEnvironment := fixtures.NewEnvironment(spaceID, "Environments-2", "Staging")
+
+	noEnvironments := []*environments.Environment{}
+
+	t.Run("creates environments which don't exist when asked to", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		stdErr := &bytes.Buffer{}
+		c := &cobra.Command{}
+		c.SetErr(stdErr)
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.ResolveOrCreateEnvironmentNames(c, []string{"dev", "Staging"}, &cmd.Dependencies{Client: octopus}, true)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment})
+
+		req := api.ExpectRequest(t, "POST", "/api/Spaces-1/environments")
+		requestBody, err := testutil.ReadJson[environments.Environment](req.Request.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, "Staging", requestBody.Name)
+		assert.Equal(t, helper.CreatedEnvironmentDescription, requestBody.Description)
+		req.RespondWithStatus(201, "201 Created", stagingEnvironment)
+
+		envIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Environments-1", "Environments-2"}, envIds)
+		assert.Contains(t, stdErr.String(), "Created environment Staging")
+	})
+
+	t.Run("never creates environments given by ID", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		stdErr := &bytes.Buffer{}
+		c := &cobra.Command{}
+		c.SetErr(stdErr)
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.ResolveOrCreateEnvironmentNames(c, []string{"Environments-99"}, &cmd.Dependencies{Client: octopus}, true)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(noEnvironments)
+
+		envIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, envIds)
+		assert.ErrorContains(t, err, "cannot find an environment with name or ID of 'Environments-99'")
+		assert.Equal(t, "", stdErr.String())
+	})
+
+	t.Run("reports every unmatched name at once by default", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		c := &cobra.Command{}
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.ResolveOrCreateEnvironmentNames(c, []string{"Stagign", "Dev", "Environments-1", "Prod"}, &cmd.Dependencies{Client: octopus}, false)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment})
+
+		envIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, envIds)
+		merr, ok := err.(*multierror.Error)
+		assert.True(t, ok)
+		assert.Equal(t, 2, merr.Len())
+		assert.EqualError(t, merr.Errors[0], "cannot find an environment with name or ID of 'Stagign'")
+		assert.EqualError(t, merr.Errors[1], "cannot find an environment with name or ID of 'Prod'")
+	})
+
+	t.Run("shares the environments fetched for prompting in the same command run", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		c := &cobra.Command{}
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			dependencies := &cmd.Dependencies{Client: octopus}
+			if _, err := dependencies.GetAllEnvironments(); err != nil {
+				return nil, err
+			}
+			return helper.ResolveOrCreateEnvironmentNames(c, []string{"staging", "Dev"}, dependencies, false)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		// the only request for environments; resolving the names must reuse it
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment, stagingEnvironment})
+
+		envIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Environments-2", "Environments-1"}, envIds)
+	})
+}
+
+func TestEnvironmentResolver(t *testing.T) {
+	const spaceID = "Spaces-1"
+	devEnvironment := fixtures.NewEnvironment(spaceID, "Environments-1", "Dev")
+	testEnvironment := fixtures.NewEnvironment(spaceID, "Environments-2", "Test")
+	prodEnvironment := fixtures.NewEnvironment(spaceID, "Environments-3", "Production")
+
+	t.Run("loads the environments once for many rows", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+
+		receiver := testutil.GoBegin2(func() ([][]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			resolver := helper.NewEnvironmentResolver(octopus, false)
+			var results [][]string
+			for _, row := range [][]string{{"Dev", "Test"}, {"dev"}, {"Test", "Production"}, {"Environments-1"}} {
+				envIds, err := resolver.Resolve(row)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, envIds)
+			}
+			return results, nil
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		// the only request for environments; the resolver must answer every row after this from memory
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment, testEnvironment, prodEnvironment})
+
+		results, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]string{
+			{"Environments-1", "Environments-2"},
+			{"Environments-1"},
+			{"Environments-2", "Environments-3"},
+			{"Environments-1"},
+		}, results)
+	})
+
+	t.Run("refuses to guess between environments whose names differ only by case", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		upperDevEnvironment := fixtures.NewEnvironment(spaceID, "Environments-4", "DEV")
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.NewEnvironmentResolver(octopus, true).Resolve([]string{"Dev", "Test"})
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment, testEnvironment, upperDevEnvironment})
+
+		envIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, envIds)
+		assert.EqualError(t, err.(*multierror.Error).Errors[0], "more than one environment is named 'Dev' (Environments-1, Environments-4); give the ID of the one you want instead")
+	})
+
+	t.Run("accepts the IDs of environments whose names differ only by case", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		upperDevEnvironment := fixtures.NewEnvironment(spaceID, "Environments-4", "DEV")
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.NewEnvironmentResolver(octopus, false).Resolve([]string{"environments-4", "Test"})
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{devEnvironment, testEnvironment, upperDevEnvironment})
+
+		envIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Environments-4", "Environments-2"}, envIds)
+	})
+}
+
+func TestResolveProjectGroupNames(t *testing.T) {
+	newProjectGroup := func(id string, name string) *projectgroups.ProjectGroup {
+		group := projectgroups.NewProjectGroup(name)
+		group.ID = id
+		return group
+	}
+	defaultGroup := newProjectGroup("ProjectGroups-1", "Default Project Group")
+	financeGroup := newProjectGroup("ProjectGroups-2", "Finance")
+
+	t.Run("resolves names case-insensitively and IDs", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.ResolveProjectGroupNames([]string{"finance", "ProjectGroups-1"}, octopus)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/projectgroups/all").RespondWith([]*projectgroups.ProjectGroup{defaultGroup, financeGroup})
+
+		groupIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ProjectGroups-2", "ProjectGroups-1"}, groupIds)
+	})
+
+	t.Run("reports every unresolved name together", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		upperFinanceGroup := newProjectGroup("ProjectGroups-3", "FINANCE")
+
+		receiver := testutil.GoBegin2(func() ([]string, error) {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			return helper.ResolveProjectGroupNames([]string{"Marketing", "Default Project Group", "Finance"}, octopus)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/projectgroups/all").RespondWith([]*projectgroups.ProjectGroup{defaultGroup, financeGroup, upperFinanceGroup})
+
+		groupIds, err := testutil.ReceivePair(receiver)
+		assert.Nil(t, groupIds)
+		merr, ok := err.(*multierror.Error)
+		assert.True(t, ok)
+		assert.Len(t, merr.Errors, 2)
+		assert.EqualError(t, merr.Errors[0], "cannot find a project group with name or ID of 'Marketing'")
+		assert.EqualError(t, merr.Errors[1], "more than one project group is named 'Finance' (ProjectGroups-2, ProjectGroups-3); give the ID of the one you want instead")
+	})
+}
+
+func TestRegisterEnvironmentCompletion(t *testing.T) {
+	space := fixtures.NewSpace("Spaces-1", "Default Space")
+	allEnvironments := []*environments.Environment{
+		fixtures.NewEnvironment(space.ID, "Environments-1", "Dev"),
+		fixtures.NewEnvironment(space.ID, "Environments-2", "Staging"),
+		fixtures.NewEnvironment(space.ID, "Environments-3", "Production"),
+		fixtures.NewEnvironment(space.ID, "Environments-4", "Pre-Production"),
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"offers every environment", []string{"-e", ""}, "Dev\nStaging\nProduction\nPre-Production\n"},
+		{"offers the environments starting with what's typed", []string{"-e", "pr"}, "Production\nPre-Production\n"},
+		{"leaves out environments already given", []string{"-e", "dev", "-e", "Environments-3", "-e", ""}, "Staging\nPre-Production\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			stdout := &bytes.Buffer{}
+			var environmentsFlag []string
+			cmd := &cobra.Command{Use: "create", Run: func(*cobra.Command, []string) {}}
+			cmd.Flags().StringArrayVarP(&environmentsFlag, "environment", "e", nil, "")
+			helper.RegisterEnvironmentCompletion(cmd, testutil.NewMockFactoryWithSpace(api, space), "environment")
+			rootCmd := &cobra.Command{Use: "octopus"}
+			rootCmd.AddCommand(cmd)
+			rootCmd.SetOut(stdout)
+
+			receiver := testutil.GoBegin(func() error {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{cobra.ShellCompNoDescRequestCmd, "create"}, test.args...))
+				return rootCmd.Execute()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+
+			assert.Nil(t, <-receiver)
+			assert.Equal(t, test.want+":4\n", stdout.String())
+		})
+	}
+}
+
+func TestReadEnvironmentsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "environments.txt")
+	err := os.WriteFile(path, []byte("# the usual pipeline\nDev\n  Staging  \n\n#Test\nProduction\r\n"), 0600)
+	assert.Nil(t, err)
+
+	names, err := helper.ReadEnvironmentsFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Dev", "Staging", "Production"}, names)
+
+	_, err = helper.ReadEnvironmentsFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.NotNil(t, err)
+}
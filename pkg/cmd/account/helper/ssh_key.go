@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"bytes"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"errors"
+
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// the flags which set the key, its passphrase and its encoding, which are named in validation errors
+const (
+	flagPrivateKey  = "private-key"
+	flagPassphrase  = "passphrase"
+	FlagKeyIsBase64 = "key-is-base64"
+)
+
+var pemHeader = []byte("-----BEGIN ")
+
+// ValidateSshPrivateKey checks that data holds an SSH private key before we send it to the Octopus Server, which
+// would otherwise accept anything and leave the problem to show up at deployment time. If the key is encrypted,
+// passphrase must decrypt it.
+func ValidateSshPrivateKey(data []byte, passphrase string) error {
+	_, err := ssh.ParseRawPrivateKey(data)
+	if err == nil {
+		return nil
+	}
+	var passphraseMissing *ssh.PassphraseMissingError
+	if errors.As(err, &passphraseMissing) {
+		if passphrase == "" {
+			return cliErrors.NewValidationError(flagPassphrase, cliErrors.ValidationCodeRequired, "the private key is encrypted; give its passphrase with --passphrase")
+		}
+		if _, err := ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(passphrase)); err != nil {
+			if errors.Is(err, x509.IncorrectPasswordError) {
+				return cliErrors.NewValidationError(flagPassphrase, cliErrors.ValidationCodeInvalid, "the passphrase does not decrypt the private key")
+			}
+			return cliErrors.NewValidationError(flagPrivateKey, cliErrors.ValidationCodeInvalid, "the provided file does not contain a valid SSH private key")
+		}
+		return nil
+	}
+	if _, _, _, _, err := ssh.ParseAuthorizedKey(data); err == nil {
+		return cliErrors.NewValidationError(flagPrivateKey, cliErrors.ValidationCodeInvalid, "the provided file does not contain a valid SSH private key; it looks like a public key, which is usually the file ending in .pub")
+	}
+	return cliErrors.NewValidationError(flagPrivateKey, cliErrors.ValidationCodeInvalid, "the provided file does not contain a valid SSH private key")
+}
+
+// DecodeSshPrivateKey returns the raw key material from a key file. The server is sent the key base64 encoded, so a
+// file that is already base64 would otherwise be encoded twice. With isBase64 the data must decode; without it,
+// data that isn't PEM but decodes to PEM is decoded, and anything else is returned as it is.
+func DecodeSshPrivateKey(data []byte, isBase64 bool) ([]byte, error) {
+	if !isBase64 && bytes.Contains(data, pemHeader) {
+		return data, nil
+	}
+	// base64 is often wrapped across lines, which the decoder doesn't allow
+	decoded, err := b64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(data), nil)))
+	if isBase64 {
+		if err != nil {
+			return nil, cliErrors.NewValidationError(flagPrivateKey, cliErrors.ValidationCodeInvalid, "the private key file is not valid base64; leave out --"+FlagKeyIsBase64+" if it holds the key itself")
+		}
+		return decoded, nil
+	}
+	if err == nil && bytes.Contains(decoded, pemHeader) {
+		return decoded, nil
+	}
+	return data, nil
+}
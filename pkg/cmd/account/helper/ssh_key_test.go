@@ -0,0 +1,79 @@
+package helper_test
+
+import (
+	b64 "encoding/base64"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSshPrivateKey(t *testing.T) {
+	encryptedKey := fixtures.NewEncryptedSshPrivateKey("correct horse")
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		err        string
+	}{
+		{"accepts a private key", fixtures.NewSshPrivateKey(), "", ""},
+		{"accepts an encrypted private key with its passphrase", encryptedKey, "correct horse", ""},
+		{"rejects an encrypted private key without a passphrase", encryptedKey, "", "the private key is encrypted; give its passphrase with --passphrase"},
+		{"rejects the wrong passphrase", encryptedKey, "battery staple", "the passphrase does not decrypt the private key"},
+		{"rejects a public key", []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGiP3/y0bPzHDgHZ5Sp0xkO3D8rZL0l3pN9c3+0nzZ1e deploy@web\n"), "", "the provided file does not contain a valid SSH private key; it looks like a public key, which is usually the file ending in .pub"},
+		{"rejects anything else", []byte("not a key"), "", "the provided file does not contain a valid SSH private key"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := helper.ValidateSshPrivateKey(test.data, test.passphrase)
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestDecodeSshPrivateKey(t *testing.T) {
+	key := fixtures.NewSshPrivateKey()
+	encoded := []byte(b64.StdEncoding.EncodeToString(key))
+	// wrapped at 64 columns, the way openssl base64 writes it
+	var wrapped []byte
+	for rest := encoded; len(rest) > 0; {
+		n := 64
+		if len(rest) < n {
+			n = len(rest)
+		}
+		wrapped = append(append(wrapped, rest[:n]...), '\n')
+		rest = rest[n:]
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		isBase64 bool
+		want     []byte
+		err      string
+	}{
+		{"leaves a PEM key as it is", key, false, key, ""},
+		{"decodes a base64 PEM key", encoded, false, key, ""},
+		{"decodes a base64 PEM key wrapped across lines", wrapped, false, key, ""},
+		{"leaves anything else for validation to reject", []byte("not a key"), false, []byte("not a key"), ""},
+		{"decodes when told the key is base64", encoded, true, key, ""},
+		{"rejects a PEM key when told the key is base64", key, true, nil, "the private key file is not valid base64; leave out --key-is-base64 if it holds the key itself"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := helper.DecodeSshPrivateKey(test.data, test.isBase64)
+			if test.err == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, test.want, data)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package _import
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	accountCreate "github.com/OctopusDeploy/cli/pkg/cmd/account/create"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FlagContinueOnError = "continue-on-error"
+
+	// typeKey is the setting in each definition which says what type of account it is; the rest are flags
+	typeKey = "type"
+	// idKey is the account's ID, which 'account export --include-ids' writes; new accounts get new IDs, so it's ignored
+	idKey = "id"
+)
+
+type ImportFlags struct {
+	ContinueOnError *flag.Flag[bool]
+}
+
+func NewImportFlags() *ImportFlags {
+	return &ImportFlags{
+		ContinueOnError: flag.New[bool](FlagContinueOnError, false),
+	}
+}
+
+func NewCmdImport(f factory.Factory) *cobra.Command {
+	importFlags := NewImportFlags()
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Create accounts from a file of definitions",
+		Long: heredoc.Docf(`
+			Create accounts from a JSON or YAML array of definitions, or - to read them from stdin.
+
+			Each definition has a type, which is any type accepted by 'account create --type', and then the flags
+			of the create command for that type, keyed by flag name. Lists, such as environment, can have several values.
+			Secrets such as tokens and passwords can be kept in the file too, so keep it somewhere private.
+			A secret given as %[1]s, as 'account export' writes them, is treated as not given, and asked for.
+			Accounts are created in order, and by default the import stops at the first one which fails.
+		`, helper.KeepExisting),
+		Example: heredoc.Docf(`
+			$ %[1]s account import accounts.yaml
+			$ %[1]s account import accounts.json --continue-on-error --no-prompt
+
+			A definition file looks like this:
+			  - type: SshKeyPair
+			    name: Web deploy
+			    username: deploy
+			    private-key: keys/web
+			    environment: [Development, Production]
+			  - type: Token
+			    name: Registry
+			    token: ...
+		`, constants.ExecutableName),
+		Args: usage.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return ImportRun(c, f, args[0], importFlags.ContinueOnError.Value)
+		},
+	}
+
+	cmd.Flags().BoolVar(&importFlags.ContinueOnError.Value, importFlags.ContinueOnError.Name, false, "Carry on with the rest of the accounts after one fails to be created, rather than stopping.")
+	return cmd
+}
+
+// ImportRun creates each account defined in the file at path, by running the create command for its type with
+// the definition's settings as flags, and reports every account which couldn't be created together
+func ImportRun(c *cobra.Command, f factory.Factory, path string, continueOnError bool) error {
+	data, err := util.ReadFileOrStdin(path, c.InOrStdin())
+	if err != nil {
+		return err
+	}
+	// JSON is also YAML, so this reads either
+	var definitions []map[string]any
+	if err := yaml.Unmarshal(data, &definitions); err != nil {
+		return fmt.Errorf("cannot read account definitions from %s; it must be a JSON or YAML array of objects: %v", path, err)
+	}
+	if len(definitions) == 0 {
+		return fmt.Errorf("there are no account definitions in %s", path)
+	}
+
+	// every account's create command shares these, so the environments are only fetched once
+	dependencies := cmd.NewDependencies(f, c)
+
+	out := c.OutOrStdout()
+	importErrors := &multierror.Error{}
+	created := 0
+	for i, definition := range definitions {
+		if err := importAccount(c, f, dependencies, definition); err != nil {
+			// not printed here; the returned error lists every failure
+			importErrors = multierror.Append(importErrors, fmt.Errorf("%s: %w", describeDefinition(i, definition), err))
+			if !continueOnError {
+				break
+			}
+			continue
+		}
+		created++
+	}
+
+	// under --quiet, each create command has already printed the ID of its account, which is all that's wanted
+	if output.IsQuiet {
+		return importErrors.ErrorOrNil()
+	}
+	if importErrors.Len() == 0 {
+		fmt.Fprintf(out, "Successfully created %d accounts\n", created)
+		return nil
+	}
+	if skipped := len(definitions) - created - importErrors.Len(); skipped > 0 {
+		fmt.Fprintf(out, "Created %d of %d accounts, and stopped at the first failure; use --%s to carry on past failures\n", created, len(definitions), FlagContinueOnError)
+	} else {
+		fmt.Fprintf(out, "Created %d of %d accounts\n", created, len(definitions))
+	}
+	return importErrors.ErrorOrNil()
+}
+
+// importAccount runs the create command for the definition's type with its settings as flags. The command is
+// attached to the tree where it would normally be, e.g. under 'account ssh', so that it picks up the persistent
+// flags like --output-format, and it describes itself as that command when it generates automation commands.
+func importAccount(c *cobra.Command, f factory.Factory, dependencies *cmd.Dependencies, definition map[string]any) error {
+	typeName, _ := definition[typeKey].(string)
+	if typeName == "" {
+		return errors.New("it has no type, e.g. SshKeyPair")
+	}
+	accountType, err := accountCreate.ParseAccountType(typeName)
+	if err != nil {
+		return err
+	}
+	createCmd, err := accountCreate.NewCmdCreateForType(f, accountType)
+	if err != nil {
+		return err
+	}
+	typeCmd, _, err := c.Parent().Find([]string{accountCreate.SubcommandName(accountType)})
+	if err != nil {
+		return err
+	}
+	typeCmd.AddCommand(createCmd)
+	defer typeCmd.RemoveCommand(createCmd)
+
+	args, err := definitionArgs(createCmd, accountType, definition)
+	if err != nil {
+		return err
+	}
+	createCmd.SetContext(cmd.WithSharedDependencies(c.Context(), dependencies))
+	// the checks cobra would make if the command was run from the command line
+	if err := createCmd.ParseFlags(args); err != nil {
+		return err
+	}
+	if err := createCmd.ValidateArgs(createCmd.Flags().Args()); err != nil {
+		return err
+	}
+	// e.g. copying flag aliases like skip-description onto the flags they stand for
+	if createCmd.PreRunE != nil {
+		if err := createCmd.PreRunE(createCmd, createCmd.Flags().Args()); err != nil {
+			return err
+		}
+	}
+	if err := createCmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	if err := createCmd.ValidateFlagGroups(); err != nil {
+		return err
+	}
+	return createCmd.RunE(createCmd, createCmd.Flags().Args())
+}
+
+// definitionArgs turns a definition into command line arguments for createCmd, checking that each of its settings
+// is one of the command's flags
+func definitionArgs(createCmd *cobra.Command, accountType accounts.AccountType, definition map[string]any) ([]string, error) {
+	names := make([]string, 0, len(definition))
+	for name := range definition {
+		if name != typeKey && name != idKey {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var args []string
+	for _, name := range names {
+		// only the command's own flags; those it inherits, like --space, apply to the whole import
+		if createCmd.LocalNonPersistentFlags().Lookup(name) == nil {
+			return nil, fmt.Errorf("'%s' isn't a setting of %s accounts", name, accountType)
+		}
+		values, isList := definition[name].([]any)
+		if !isList {
+			values = []any{definition[name]}
+		}
+		for _, value := range values {
+			if value == helper.KeepExisting {
+				// a secret left out by export, so leave it out here too and let the create command ask for it
+				continue
+			}
+			arg, err := flagValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' %s", name, err)
+			}
+			// --name=value, so that a value starting with - isn't taken for a flag
+			args = append(args, fmt.Sprintf("--%s=%s", name, arg))
+		}
+	}
+	return args, nil
+}
+
+func flagValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case nil:
+		return "", errors.New("has no value")
+	default:
+		return "", errors.New("must be text, a number, true or false, or a list of those")
+	}
+}
+
+// describeDefinition names the account a definition is for in messages, e.g. account 2 ("Web deploy")
+func describeDefinition(index int, definition map[string]any) string {
+	if name, ok := definition["name"].(string); ok && name != "" {
+		return fmt.Sprintf("account %d (%q)", index+1, name)
+	}
+	return fmt.Sprintf("account %d", index+1)
+}
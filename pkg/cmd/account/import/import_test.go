@@ -0,0 +1,321 @@
+package _import_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestAccountImport(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewTokenAccount("Registry", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-1"
+
+	const twoTokens = `
+- type: token
+  name: Registry
+  token: abc
+- type: Token
+  name: Feed
+  token: def
+`
+
+	tests := []struct {
+		name       string
+		definition string
+		args       []string
+		run        func(t *testing.T, api *testutil.MockHttpServer)
+		out        string
+		err        string
+	}{
+		{"creates each account in the file", twoTokens + "  environment: [Development, Production]\n", nil, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			req.RespondWithStatus(201, "", createdAccount)
+			assert.Equal(t, "Registry", requestBody["Name"])
+			assert.Equal(t, "Token", requestBody["AccountType"])
+
+			// the second account's environments are a list, which is given as the flag repeated
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{
+				fixtures.NewEnvironment("Spaces-1", "Environments-1", "Development"),
+				fixtures.NewEnvironment("Spaces-1", "Environments-2", "Production"),
+			})
+			req = api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err = testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			req.RespondWithStatus(201, "", createdAccount)
+			assert.Equal(t, "Feed", requestBody["Name"])
+			assert.Equal(t, []any{"Environments-1", "Environments-2"}, requestBody["EnvironmentIds"])
+		}, "Successfully created 2 accounts\n", ""},
+		{"reads JSON too", `[{"type": "Token", "name": "Registry", "token": "abc"}]`, nil, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+		}, "Successfully created 1 accounts\n", ""},
+		{"stops at the first account which fails", twoTokens, nil, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(400, "", map[string]any{"ErrorMessage": "name is in use"})
+		}, "Created 0 of 2 accounts, and stopped at the first failure; use --continue-on-error to carry on past failures\n", `account 1 ("Registry")`},
+		{"carries on past failures with --continue-on-error", twoTokens, []string{"--continue-on-error"}, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(400, "", map[string]any{"ErrorMessage": "name is in use"})
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+		}, "Created 1 of 2 accounts\n", `account 1 ("Registry")`},
+		{"reports definitions it can't use", `[{"name": "Untyped"}, {"type": "Token", "name": "Feed", "tokn": "abc"}, {"type": "Token", "token": {"value": "abc"}}]`, []string{"--continue-on-error"}, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		}, "Created 0 of 3 accounts\n", "3 errors occurred:\n" +
+			"\t* account 1 (\"Untyped\"): it has no type, e.g. SshKeyPair\n" +
+			"\t* account 2 (\"Feed\"): 'tokn' isn't a setting of Token accounts\n" +
+			"\t* account 3: 'token' must be text, a number, true or false, or a list of those\n"},
+		{"ignores IDs and secrets left out by export", `[{"type": "Token", "id": "Accounts-9", "name": "Registry", "token": "<keep existing>"}]`, nil, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			req.RespondWithStatus(201, "", createdAccount)
+			assert.Nil(t, requestBody["Id"])
+			assert.Equal(t, false, requestBody["Token"].(map[string]any)["HasValue"])
+		}, "Successfully created 1 accounts\n", ""},
+		{"rejects a file which isn't a list", `{"type": "Token"}`, nil, nil, "", "it must be a JSON or YAML array of objects"},
+		{"rejects an empty file", `[]`, nil, nil, "", "there are no account definitions in"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			definitionFile := filepath.Join(t.TempDir(), "accounts.yaml")
+			assert.Nil(t, os.WriteFile(definitionFile, []byte(test.definition), 0600))
+
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			out := &bytes.Buffer{}
+			rootCmd.SetOut(out)
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"account", "import", definitionFile, "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			if test.run != nil {
+				test.run(t, api)
+			}
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+			if test.out != "" {
+				assert.Contains(t, out.String(), test.out)
+			}
+		})
+	}
+}
+
+func TestAccountImportSharesEnvironments(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewTokenAccount("Registry", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-1"
+	definitionFile := filepath.Join(t.TempDir(), "accounts.yaml")
+	assert.Nil(t, os.WriteFile(definitionFile, []byte(`
+- type: Token
+  name: Registry
+  token: abc
+  environment: [Development]
+- type: Token
+  name: Feed
+  token: def
+  environment: [Development, Production]
+`), 0600))
+
+	api, qa := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(qa.AsAsker())
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		rootCmd.SetArgs([]string{"account", "import", definitionFile, "--no-prompt"})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+	// both accounts are scoped to environments, but they're only fetched for the first
+	api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{
+		fixtures.NewEnvironment("Spaces-1", "Environments-1", "Development"),
+		fixtures.NewEnvironment("Spaces-1", "Environments-2", "Production"),
+	})
+	for _, want := range [][]any{{"Environments-1"}, {"Environments-1", "Environments-2"}} {
+		req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+		requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+		assert.Nil(t, err)
+		req.RespondWithStatus(201, "", createdAccount)
+		assert.Equal(t, want, requestBody["EnvironmentIds"])
+	}
+
+	_, err := testutil.ReceivePair(cmdReceiver)
+	assert.Nil(t, err)
+}
+
+func TestAccountImportOutputFormat(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-1"
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "id_web")
+	assert.Nil(t, os.WriteFile(keyFile, fixtures.NewSshPrivateKey(), 0600))
+	definitionFile := filepath.Join(dir, "accounts.json")
+	definition, _ := json.Marshal([]map[string]any{{"type": "SshKeyPair", "name": "Web deploy", "username": "deploy", "private-key": keyFile}})
+	assert.Nil(t, os.WriteFile(definitionFile, definition, 0600))
+
+	api, qa := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(qa.AsAsker())
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		rootCmd.SetArgs([]string{"account", "import", definitionFile, "--no-prompt", "--output-format", "env"})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+	api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+
+	_, err := testutil.ReceivePair(cmdReceiver)
+	assert.Nil(t, err)
+	// the create command goes by the import's --output-format, which it inherits from the root command
+	assert.Contains(t, out.String(), "OCTOPUS_ACCOUNT_ID=Accounts-1\n")
+}
+
+func TestAccountImportReportsFailuresOnce(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewTokenAccount("Registry", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-1"
+	definitionFile := filepath.Join(t.TempDir(), "accounts.yaml")
+	assert.Nil(t, os.WriteFile(definitionFile, []byte(`
+- type: Token
+  name: Registry
+  token: abc
+- type: Token
+  name: Feed
+  token: def
+`), 0600))
+
+	for _, quiet := range []bool{false, true} {
+		name := "prints a summary"
+		if quiet {
+			name = "prints only the created IDs under --quiet"
+		}
+		t.Run(name, func(t *testing.T) {
+			if quiet {
+				output.IsQuiet = true
+				defer func() { output.IsQuiet = false }()
+			}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			out, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			rootCmd.SetOut(out)
+			rootCmd.SetErr(stderr)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "import", definitionFile, "--no-prompt", "--continue-on-error"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(400, "", map[string]any{"ErrorMessage": "name is in use"})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.ErrorContains(t, err, `account 2 ("Feed")`)
+			// the error is what reports the failure, so it isn't printed as well
+			assert.NotContains(t, stderr.String(), "Feed")
+			if quiet {
+				assert.Equal(t, "Accounts-1\n", out.String())
+			} else {
+				assert.Contains(t, out.String(), "Created 1 of 2 accounts\n")
+			}
+		})
+	}
+}
+
+func TestAccountImportFlagAliases(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-1"
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "id_web")
+	assert.Nil(t, os.WriteFile(keyFile, fixtures.NewSshPrivateKey(), 0600))
+	definitionFile := filepath.Join(dir, "accounts.json")
+	// skip-description is an alias of no-description, so the description isn't asked for
+	definition, _ := json.Marshal([]map[string]any{{"type": "SshKeyPair", "name": "Web deploy", "username": "deploy", "private-key": keyFile, "tenant-tag": "Region/us-east", "skip-description": true}})
+	assert.Nil(t, os.WriteFile(definitionFile, definition, 0600))
+
+	api, qa := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(qa.AsAsker())
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer testutil.Close(api, qa)
+		rootCmd.SetArgs([]string{"account", "import", definitionFile})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+	_ = qa.ExpectQuestion(t, &survey.Password{
+		Message: "Passphrase",
+		Help:    "The passphrase for the private key, if required.",
+	}).AnswerWith("")
+	api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{fixtures.NewEnvironment("Spaces-1", "Environments-1", "Production")})
+	_ = qa.ExpectQuestion(t, &survey.MultiSelect{
+		Message: "Choose the environments that are allowed to use this account.\nIf nothing is selected, the account can be used for deployments to any environment.",
+		Options: []string{"Production"},
+	}).AnswerWith([]string{})
+	req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+	requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "Web deploy", requestBody["Name"])
+	req.RespondWithStatus(201, "", createdAccount)
+
+	_, err = testutil.ReceivePair(cmdReceiver)
+	assert.Nil(t, err)
+}
@@ -1,70 +1,106 @@
 package list
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
 )
 
+const FlagType = "type"
+
+// AccountTypeMap maps each account type onto the friendly name we display for it
+var AccountTypeMap = map[accounts.AccountType]string{
+	accounts.AccountTypeAmazonWebServicesAccount:   "AWS Account",
+	accounts.AccountTypeAzureSubscription:          "Azure Subscription",
+	accounts.AccountTypeAzureServicePrincipal:      "Azure Service Principal",
+	accounts.AccountTypeGoogleCloudPlatformAccount: "Google Cloud Account",
+	accounts.AccountTypeSSHKeyPair:                 "SSH Key Pair",
+	accounts.AccountTypeUsernamePassword:           "Username/Password",
+	accounts.AccountTypeToken:                      "Token",
+}
+
 func NewCmdList(f factory.Factory) *cobra.Command {
+	var accountType string
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List accounts",
-		Long:    "List accounts in Octopus Deploy",
-		Example: heredoc.Docf("$ %s account list", constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Use:   "list",
+		Short: "List accounts",
+		Long:  "List accounts in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s account list
+			$ %[1]s account list --type SshKeyPair
+		`, constants.ExecutableName),
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
-			if err != nil {
-				return err
-			}
+			return listRun(f, cmd, accountType)
+		},
+	}
 
-			accountResoures, err := client.Accounts.Get()
-			if err != nil {
-				return err
-			}
+	cmd.Flags().StringVarP(&accountType, FlagType, "t", "", "Only list accounts of the given type, e.g. SshKeyPair or AmazonWebServicesAccount")
 
-			items, err := accountResoures.GetAllPages(client.Accounts.GetClient())
-			if err != nil {
-				return err
-			}
+	return cmd
+}
 
-			type AccountJson struct {
-				Id   string
-				Slug string
-				Name string
-				Type string
+func listRun(f factory.Factory, cmd *cobra.Command, accountType string) error {
+	var typeFilter accounts.AccountType
+	if accountType != "" {
+		for t := range AccountTypeMap {
+			if strings.EqualFold(string(t), accountType) {
+				typeFilter = t
+				break
 			}
+		}
+		if typeFilter == "" {
+			validTypes := util.SliceTransform(maps.Keys(AccountTypeMap), func(t accounts.AccountType) string { return string(t) })
+			sort.Strings(validTypes)
+			return fmt.Errorf("unknown account type '%s'. Valid values are %s", accountType, output.FormatAsList(validTypes))
+		}
+	}
 
-			accountTypeMap := map[accounts.AccountType]string{
-				accounts.AccountTypeAmazonWebServicesAccount:   "AWS Account",
-				accounts.AccountTypeAzureSubscription:          "Azure Subscription",
-				accounts.AccountTypeAzureServicePrincipal:      "Azure Service Principal",
-				accounts.AccountTypeGoogleCloudPlatformAccount: "Google Cloud Account",
-				accounts.AccountTypeSSHKeyPair:                 "SSH Key Pair",
-				accounts.AccountTypeUsernamePassword:           "Username/Password",
-				accounts.AccountTypeToken:                      "Token",
-			}
+	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
+	if err != nil {
+		return err
+	}
 
-			return output.PrintArray(items, cmd, output.Mappers[accounts.IAccount]{
-				Json: func(item accounts.IAccount) any {
-					return AccountJson{Id: item.GetID(), Slug: item.GetSlug(), Name: item.GetName(), Type: string(item.GetAccountType())}
-				},
-				Table: output.TableDefinition[accounts.IAccount]{
-					Header: []string{"NAME", "TYPE", "SLUG"},
-					Row: func(item accounts.IAccount) []string {
-						return []string{item.GetName(), accountTypeMap[item.GetAccountType()], item.GetSlug()}
-					}},
-				Basic: func(item accounts.IAccount) string {
-					return item.GetName()
-				},
-			})
-		},
+	items, err := client.Accounts.GetAll()
+	if err != nil {
+		return err
 	}
 
-	return cmd
+	if typeFilter != "" {
+		items = util.SliceFilter(items, func(item accounts.IAccount) bool { return item.GetAccountType() == typeFilter })
+	}
+
+	type AccountJson struct {
+		Id          string
+		Slug        string
+		Name        string
+		Type        string
+		Description string
+	}
+
+	return output.PrintArray(items, cmd, output.Mappers[accounts.IAccount]{
+		Json: func(item accounts.IAccount) any {
+			return AccountJson{Id: item.GetID(), Slug: item.GetSlug(), Name: item.GetName(), Type: string(item.GetAccountType()), Description: item.GetDescription()}
+		},
+		Table: output.TableDefinition[accounts.IAccount]{
+			Header: []string{"NAME", "TYPE", "DESCRIPTION", "ID"},
+			Row: func(item accounts.IAccount) []string {
+				return []string{output.Bold(item.GetName()), AccountTypeMap[item.GetAccountType()], item.GetDescription(), item.GetID()}
+			}},
+		Basic: func(item accounts.IAccount) string {
+			return item.GetName()
+		},
+	})
 }
@@ -0,0 +1,95 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestAccountList(t *testing.T) {
+	const spaceID = "Spaces-1"
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+
+	sshAccount, _ := accounts.NewSSHKeyAccount("Deploy Key", "deploy", core.NewSensitiveValue(""))
+	sshAccount.ID = "Accounts-1"
+	sshAccount.Description = "Used by the web servers"
+	tokenAccount, _ := accounts.NewTokenAccount("API Token", core.NewSensitiveValue(""))
+	tokenAccount.ID = "Accounts-2"
+	allAccounts := []accounts.IAccount{sshAccount, tokenAccount}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"lists all accounts as a table", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "list", "--no-prompt", "-f", "table"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Equal(t, heredoc.Doc(`
+				NAME        TYPE          DESCRIPTION              ID
+				Deploy Key  SSH Key Pair  Used by the web servers  Accounts-1
+				API Token   Token                                  Accounts-2
+			`), stdOut.String())
+		}},
+
+		{"filters by account type", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "list", "--type", "token", "--no-prompt", "-f", "basic"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(allAccounts)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.Equal(t, "API Token\n", stdOut.String())
+		}},
+
+		{"rejects an unknown account type", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "list", "--type", "carrier-pigeon", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "unknown account type 'carrier-pigeon'. Valid values are AmazonWebServicesAccount, AzureServicePrincipal, AzureSubscription, GoogleCloudAccount, SshKeyPair, Token, UsernamePassword")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, rootCmd, stdout, stderr)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package create
 
 import (
+	"bytes"
 	b64 "encoding/base64"
+	"errors"
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"io"
 	"os"
 
 	"github.com/OctopusDeploy/cli/pkg/util"
@@ -12,32 +15,61 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/config"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/cli/pkg/surveyext"
 	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/cli/pkg/validation"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
 	"github.com/spf13/cobra"
 )
 
+// StdinPath is the value for --private-key which reads the key material from stdin rather than a file
+const StdinPath = util.StdinPath
+
+// FlagFromJson reads the account's settings from a JSON definition, keyed by flag name
+const FlagFromJson = "from-json"
+
+// DraftName is the name the answers are saved under if the interactive flow is interrupted
+const DraftName = "account-ssh-create"
+
 type CreateFlags struct {
 	Name         *flag.Flag[string]
 	Description  *flag.Flag[string]
 	KeyFilePath  *flag.Flag[string]
+	KeyIsBase64  *flag.Flag[bool]
 	Username     *flag.Flag[string]
 	Passphrase   *flag.Flag[string]
 	Environments *flag.Flag[[]string]
+	TenantTags   *flag.Flag[[]string]
+
+	CopyScopeFrom *flag.Flag[string]
+	NoDescription *flag.Flag[bool]
+	DryRun        *flag.Flag[bool]
+	IfNotExists   *flag.Flag[bool]
 }
 
 type CreateOptions struct {
 	*CreateFlags
 	*cmd.Dependencies
 	KeyFileData []byte
+
+	// tenant scope copied from another account by --copy-scope-from, on top of any tenant tags
+	TenantIDs              []string
+	TenantedDeploymentMode core.TenantedDeploymentMode
+
+	// the name --if-not-exists has already looked for, so that it isn't looked for twice
+	existenceCheckedFor string
+
 	selectors.GetAllEnvironmentsCallback
+	selectors.GetAllTagSetsCallback
 }
 
 func NewCreateFlags() *CreateFlags {
@@ -45,18 +77,26 @@ func NewCreateFlags() *CreateFlags {
 		Name:         flag.New[string]("name", false),
 		Description:  flag.New[string]("description", false),
 		KeyFilePath:  flag.New[string]("private-key", false),
+		KeyIsBase64:  flag.New[bool](helper.FlagKeyIsBase64, false),
 		Username:     flag.New[string]("username", false),
 		Passphrase:   flag.New[string]("passphrase", true),
 		Environments: flag.New[[]string]("environment", false),
+		TenantTags:   flag.New[[]string]("tenant-tag", false),
+
+		CopyScopeFrom: flag.New[string]("copy-scope-from", false),
+		NoDescription: flag.New[bool]("no-description", false),
+		DryRun:        flag.New[bool](constants.FlagDryRun, false),
+		IfNotExists:   flag.New[bool](constants.FlagIfNotExists, false),
 	}
 }
 
 func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
 	return &CreateOptions{
-		CreateFlags:  flags,
-		Dependencies: dependencies,
-		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
-			return selectors.GetAllEnvironments(dependencies.Client)
+		CreateFlags:                flags,
+		Dependencies:               dependencies,
+		GetAllEnvironmentsCallback: dependencies.GetAllEnvironments,
+		GetAllTagSetsCallback: func() ([]*tagsets.TagSet, error) {
+			return selectors.GetAllTagSets(dependencies.Client)
 		},
 	}
 }
@@ -64,26 +104,67 @@ func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *Creat
 func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 	descriptionFilePath := ""
+	environmentsFilePath := ""
+	fromJsonPath := ""
+	strict := false
+	anyEnvironment := false
+	createMissingEnvironments := false
+	resumeDraft := false
+	flagAliases := make(map[string][]string, 1)
 
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create a SSH Key Pair account",
-		Long:    "Create a SSH Key Pair account in Octopus Deploy",
-		Example: heredoc.Docf("$ %s account ssh create", constants.ExecutableName),
+		Use:   "create",
+		Short: "Create a SSH Key Pair account",
+		Long:  "Create a SSH Key Pair account in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s account ssh create
+			$ %[1]s account ssh create --name "Web deploy" --username deploy --private-key ~/.ssh/web --copy-scope-from "DB deploy"
+			$ %[1]s account ssh create --name "Web deploy" --username deploy --private-key ~/.ssh/web --environment Production --dry-run
+			$ %[1]s account ssh create --name "Web deploy" --username deploy --private-key ~/.ssh/web --if-not-exists --no-prompt
+			$ %[1]s account ssh create --from-json accounts/web-deploy.json --passphrase "$KEY_PASSPHRASE" --no-prompt
+		`, constants.ExecutableName),
 		Aliases: []string{"new"},
+		PreRunE: func(c *cobra.Command, _ []string) error {
+			util.ApplyFlagAliases(c.Flags(), flagAliases)
+			return nil
+		},
 		RunE: func(c *cobra.Command, _ []string) error {
 			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
-			if descriptionFilePath != "" {
-				if err := validation.IsExistingFile(descriptionFilePath); err != nil {
+			if fromJsonPath != "" {
+				// first, so that the private key it names is read and checked just like one given by --private-key
+				data, err := util.ReadFileOrStdin(fromJsonPath, c.InOrStdin())
+				if err != nil {
 					return err
 				}
-				data, err := os.ReadFile(descriptionFilePath)
+				if err := flag.ApplyJson(data, c.Flags(), JsonFlags(opts)...); err != nil {
+					return fmt.Errorf("cannot create the account from %s; %w", fromJsonPath, err)
+				}
+				if fromJsonPath == util.StdinPath && (descriptionFilePath == util.StdinPath || opts.KeyFilePath.Value == StdinPath) {
+					return errors.New("the definition was read from stdin, so the description and private key can't be too")
+				}
+			}
+			if descriptionFilePath == util.StdinPath && opts.KeyFilePath.Value == StdinPath {
+				return errors.New("only one of --description-file and --private-key can be read from stdin")
+			}
+			if descriptionFilePath != "" {
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
 				if err != nil {
 					return err
 				}
 				opts.Description.Value = string(data)
 			}
-			if opts.KeyFilePath.Value != "" {
+			if opts.KeyFilePath.Value == StdinPath {
+				data, err := io.ReadAll(c.InOrStdin())
+				if err != nil {
+					return err
+				}
+				if len(bytes.TrimSpace(data)) == 0 {
+					return errors.New("no private key data was read from stdin")
+				}
+				opts.KeyFileData = data
+				// stdin has been consumed by the key, so there is nothing left to answer prompts with
+				opts.NoPrompt = true
+			} else if opts.KeyFilePath.Value != "" {
 				if err := validation.IsExistingFile(opts.KeyFilePath.Value); err != nil {
 					return err
 				}
@@ -93,35 +174,129 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 				}
 				opts.KeyFileData = data
 			}
-			if opts.Environments.Value != nil {
-				env, err := helper.ResolveEnvironmentNames(opts.Environments.Value, opts.Client)
+			if environmentsFilePath != "" {
+				if err := validation.IsExistingFile(environmentsFilePath); err != nil {
+					return err
+				}
+				names, err := helper.ReadEnvironmentsFile(environmentsFilePath)
 				if err != nil {
 					return err
 				}
-				opts.Environments.Value = env
+				opts.Environments.Value = append(opts.Environments.Value, names...)
+			}
+			// look for the account before --create-missing-environments creates anything, so that running the
+			// command again really does change nothing
+			if opts.IfNotExists.Value && createMissingEnvironments && opts.Name.Value == "" {
+				return fmt.Errorf("--%s with --%s needs --%s, so that the account can be looked for before any environment is created", constants.FlagIfNotExists, helper.FlagCreateMissingEnvironments, opts.Name.Name)
 			}
-			return CreateRun(opts)
+			existingAccount, err := findExistingAccount(opts)
+			if err != nil {
+				return err
+			}
+			if existingAccount != nil {
+				return printExistingAccount(opts, existingAccount)
+			}
+			if err := helper.ResolveEnvironmentFlags(c, opts.Environments, opts.Dependencies, strict, anyEnvironment, createMissingEnvironments); err != nil {
+				return err
+			}
+			if opts.CopyScopeFrom.Value != "" {
+				source, err := helper.FindAccount(opts.Client, opts.CopyScopeFrom.Value)
+				if err != nil {
+					return err
+				}
+				CopyScope(opts, source)
+			}
+
+			draftPath, draftPathErr := config.GetDraftPath(DraftName)
+			if resumeDraft {
+				if draftPathErr != nil {
+					return draftPathErr
+				}
+				if err := question.LoadDraft(draftPath, DraftFlags(opts)...); err != nil {
+					return err
+				}
+			}
+			err = CreateRun(opts)
+			if draftPathErr == nil {
+				if question.IsInterrupt(err) {
+					if saveErr := question.SaveDraft(draftPath, DraftFlags(opts)...); saveErr == nil {
+						output.Infof(c, "\nYour answers so far have been saved, apart from the private key and passphrase. Run %s --%s to carry on.\n", opts.CmdPath, question.FlagResumeDraft)
+					}
+				} else if err == nil && !opts.DryRun.Value {
+					question.DeleteDraft(draftPath)
+				}
+			}
+			return err
 		},
 	}
 
 	flags := cmd.Flags()
 	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "A short, memorable, unique name for this account.")
 	flags.StringVarP(&createFlags.Description.Value, createFlags.Description.Name, "d", "", "A summary explaining the use of the account to other users.")
-	flags.StringVarP(&createFlags.KeyFilePath.Value, createFlags.KeyFilePath.Name, "K", "", "Path to the private key file portion of the key pair.")
+	flags.StringVarP(&createFlags.KeyFilePath.Value, createFlags.KeyFilePath.Name, "K", "", "Path to the private key file portion of the key pair, or - to read it from stdin.")
+	flags.BoolVar(&createFlags.KeyIsBase64.Value, createFlags.KeyIsBase64.Name, false, "The private key file is already base64 encoded, so don't encode it again. Base64 of a PEM key is detected without this.")
 	flags.StringVarP(&createFlags.Username.Value, createFlags.Username.Name, "u", "", "The username to use when authenticating against the remote host.")
 	flags.StringVarP(&createFlags.Passphrase.Value, createFlags.Passphrase.Name, "p", "", "The passphrase for the private key, if required.")
 	flags.StringArrayVarP(&createFlags.Environments.Value, createFlags.Environments.Name, "e", nil, "The environments that are allowed to use this account.")
-	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`.")
+	helper.RegisterEnvironmentCompletion(cmd, f, createFlags.Environments.Name)
+	flags.StringArrayVar(&createFlags.TenantTags.Value, createFlags.TenantTags.Name, nil, "The tenant tags which can use this account, in the format 'tag set name/tag name'.")
+	flags.StringVar(&createFlags.CopyScopeFrom.Value, createFlags.CopyScopeFrom.Name, "", "Name or ID of an existing account whose environment and tenant scope this account should copy. --environment and --tenant-tag override it.")
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+	flags.BoolVar(&createFlags.NoDescription.Value, createFlags.NoDescription.Name, false, "Don't ask for a description; the account is created without one.")
+	util.AddFlagAliasesBool(flags, createFlags.NoDescription.Name, flagAliases, "skip-description")
+	cmd.MarkFlagsMutuallyExclusive(createFlags.Description.Name, "description-file", createFlags.NoDescription.Name)
+	flags.BoolVar(&createFlags.DryRun.Value, createFlags.DryRun.Name, false, "Ask and check everything as usual, then show the account that would be created, with secrets masked, instead of creating it.")
+	flags.BoolVar(&createFlags.IfNotExists.Value, createFlags.IfNotExists.Name, false, "If an SSH account with this name already exists, print its ID instead of creating another, so the command is safe to run again.")
+	flags.StringVar(&fromJsonPath, FlagFromJson, "", "Read the account's settings from a JSON `file`, or - for stdin, keyed by flag name, e.g. {\"name\": \"Web deploy\", \"private-key\": \"keys/web\"}. Flags given on the command line override it. The passphrase can't be kept in it.")
+	helper.RegisterEnvironmentsFileFlag(cmd, &environmentsFilePath)
+	helper.RegisterStrictFlag(cmd, &strict)
+	helper.RegisterAnyEnvironmentFlag(cmd, &anyEnvironment)
+	cmd.MarkFlagsMutuallyExclusive(helper.FlagAnyEnvironment, helper.FlagEnvironmentsFile)
+	helper.RegisterCreateMissingEnvironmentsFlag(cmd, &createMissingEnvironments)
+	question.RegisterResumeDraftFlag(cmd, &resumeDraft)
+	// a dry run must not change anything, and creating environments would
+	cmd.MarkFlagsMutuallyExclusive(createFlags.DryRun.Name, helper.FlagCreateMissingEnvironments)
 
 	return cmd
 }
 
+// DraftFlags are the answers kept in a draft. The passphrase is secure, so drafts never store it. The private
+// key path is left out too: it isn't secret, but the key itself would need reading again, so we ask for it again.
+func DraftFlags(opts *CreateOptions) []flag.Generatable {
+	return []flag.Generatable{opts.Name, opts.Description, opts.NoDescription, opts.Username, opts.Passphrase, opts.Environments, opts.TenantTags}
+}
+
+// JsonFlags are the settings --from-json can give. The passphrase is among them only so that ApplyJson can say
+// why it isn't allowed.
+func JsonFlags(opts *CreateOptions) []flag.Generatable {
+	return []flag.Generatable{opts.Name, opts.Description, opts.KeyFilePath, opts.KeyIsBase64, opts.Username, opts.Passphrase, opts.Environments, opts.TenantTags, opts.CopyScopeFrom}
+}
+
 func CreateRun(opts *CreateOptions) error {
 	if !opts.NoPrompt {
 		if err := PromptMissing(opts); err != nil {
 			return err
 		}
 	}
+	if err := ValidateFlags(opts); err != nil {
+		return err
+	}
+	keyData, err := helper.DecodeSshPrivateKey(opts.KeyFileData, opts.KeyIsBase64.Value)
+	if err != nil {
+		return err
+	}
+	opts.KeyFileData = keyData
+	// the server takes whatever it's given, so a bad key wouldn't show up until a deployment tried to use it
+	if err := helper.ValidateSshPrivateKey(opts.KeyFileData, opts.Passphrase.Value); err != nil {
+		return err
+	}
+	existingAccount, err := findExistingAccount(opts)
+	if err != nil {
+		return err
+	}
+	if existingAccount != nil {
+		return printExistingAccount(opts, existingAccount)
+	}
 	sshAccount, err := accounts.NewSSHKeyAccount(
 		opts.Name.Value,
 		opts.Username.Value,
@@ -132,28 +307,93 @@ func CreateRun(opts *CreateOptions) error {
 	}
 	sshAccount.Description = opts.Description.Value
 	sshAccount.EnvironmentIDs = opts.Environments.Value
+	if len(opts.TenantTags.Value) > 0 {
+		sshAccount.TenantTags = opts.TenantTags.Value
+		sshAccount.TenantedDeploymentMode = core.TenantedDeploymentModeTenantedOrUntenanted
+	}
+	if len(opts.TenantIDs) > 0 {
+		sshAccount.TenantIDs = opts.TenantIDs
+		sshAccount.TenantedDeploymentMode = core.TenantedDeploymentModeTenantedOrUntenanted
+	}
+	if opts.TenantedDeploymentMode != "" {
+		sshAccount.TenantedDeploymentMode = opts.TenantedDeploymentMode
+	}
 	if opts.Passphrase.Value != "" {
 		sshAccount.PrivateKeyPassphrase = core.NewSensitiveValue(opts.Passphrase.Value)
 	}
 
+	if opts.DryRun.Value {
+		if !constants.IsProgrammaticOutputFormat(opts.OutputFormat) {
+			_, _ = fmt.Fprintln(opts.Out, "Dry run: this SSH account would be created.")
+		}
+		return output.PrintDryRun(opts.Out, sshAccount)
+	}
+
 	createdAccount, err := opts.Client.Accounts.Add(sshAccount)
 	if err != nil {
-		return err
+		return helper.ExplainEnvironmentLimitError(err, opts.Environments.Value)
+	}
+
+	if opts.OutputFormat == constants.OutputFormatEnv {
+		return output.PrintEnv(opts.Out, EnvVars(createdAccount, opts.Username.Value))
 	}
 
-	_, err = fmt.Fprintf(opts.Out, "Successfully created SSH account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
+	err = output.Successf(opts.Out, createdAccount.GetID(), "Successfully created SSH account %s %s.\n", createdAccount.GetName(), output.Dimf("(%s)", createdAccount.GetSlug()))
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), createdAccount.GetID())
 	_, _ = fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", link)
 	if !opts.NoPrompt {
-		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.Name, opts.KeyFilePath, opts.Passphrase, opts.Description, opts.Environments)
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.Name, opts.KeyFilePath, opts.KeyIsBase64, opts.Passphrase, opts.Description, opts.Environments, opts.TenantTags)
 		_, _ = fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
 	}
 	return nil
 }
 
+// findExistingAccount returns the SSH account with the name being created if --if-not-exists was given and there
+// is one, or nil. Each name is only looked for once, so the early check in RunE isn't repeated by CreateRun
+func findExistingAccount(opts *CreateOptions) (accounts.IAccount, error) {
+	if !opts.IfNotExists.Value || opts.Name.Value == "" || opts.Name.Value == opts.existenceCheckedFor {
+		return nil, nil
+	}
+	opts.existenceCheckedFor = opts.Name.Value
+	return helper.FindAccountByName(opts.Client, opts.Name.Value, accounts.AccountTypeSSHKeyPair)
+}
+
+// printExistingAccount reports the account --if-not-exists found in the same shapes as a newly created one,
+// so scripts don't need to care which happened
+func printExistingAccount(opts *CreateOptions, account accounts.IAccount) error {
+	if opts.OutputFormat == constants.OutputFormatEnv {
+		username := opts.Username.Value
+		if sshAccount, ok := account.(*accounts.SSHKeyAccount); ok {
+			username = sshAccount.Username
+		}
+		return output.PrintEnv(opts.Out, EnvVars(account, username))
+	}
+	return output.Successf(opts.Out, account.GetID(), "SSH account %s %s already exists, so nothing was created.\n", account.GetName(), output.Dimf("(%s)", account.GetID()))
+}
+
+// ValidateFlags checks the answers PromptMissing would have insisted on, for when it didn't get to ask
+func ValidateFlags(opts *CreateOptions) error {
+	if opts.Name.Value == "" {
+		return cliErrors.NewValidationError(opts.Name.Name, cliErrors.ValidationCodeRequired, "a name is required; use --name")
+	}
+	if len(opts.Name.Value) > 200 {
+		return cliErrors.NewValidationError(opts.Name.Name, cliErrors.ValidationCodeTooLong, "the name can't be longer than 200 characters")
+	}
+	if opts.Username.Value == "" {
+		return cliErrors.NewValidationError(opts.Username.Name, cliErrors.ValidationCodeRequired, "a username is required; use --username")
+	}
+	if len(opts.KeyFileData) == 0 {
+		return cliErrors.NewValidationError(opts.KeyFilePath.Name, cliErrors.ValidationCodeRequired, "a private key is required; use --private-key")
+	}
+	return nil
+}
+
 func PromptMissing(opts *CreateOptions) error {
 	if opts.Name.Value == "" {
 		if err := opts.Ask(&survey.Input{
@@ -168,7 +408,7 @@ func PromptMissing(opts *CreateOptions) error {
 		}
 	}
 
-	if opts.Description.Value == "" {
+	if opts.Description.Value == "" && !opts.NoDescription.Value {
 		if err := opts.Ask(&surveyext.OctoEditor{
 			Editor: &survey.Editor{
 				Message:  "Description",
@@ -179,6 +419,8 @@ func PromptMissing(opts *CreateOptions) error {
 		}, &opts.Description.Value); err != nil {
 			return err
 		}
+		// skipping (or leaving it blank) is an answer too, so a resumed draft doesn't ask again
+		opts.NoDescription.Value = opts.Description.Value == ""
 	}
 
 	if opts.Username.Value == "" {
@@ -227,5 +469,35 @@ func PromptMissing(opts *CreateOptions) error {
 		}
 		opts.Environments.Value = util.SliceTransform(envs, func(e *environments.Environment) string { return e.ID })
 	}
+
+	if opts.TenantTags.Value == nil {
+		tags, err := selectors.TagsMultiSelect(opts.Ask, opts.GetAllTagSetsCallback,
+			"Choose the tenant tags which can use this account.\n"+
+				output.Dim("If nothing is selected, the account is not restricted to any tenants."), false)
+		if err != nil {
+			return err
+		}
+		opts.TenantTags.Value = tags
+	}
 	return nil
 }
+
+// CopyScope takes the environment and tenant scope of source as the defaults for the new account. Anything
+// already given on the command line is left alone, so explicit flags override the copied scope.
+func CopyScope(opts *CreateOptions, source accounts.IAccount) {
+	if opts.Environments.Value == nil {
+		// never nil, even when the source isn't scoped, so that we don't prompt for what was copied
+		opts.Environments.Value = append([]string{}, source.GetEnvironmentIDs()...)
+	}
+	if opts.TenantTags.Value == nil {
+		opts.TenantTags.Value = append([]string{}, source.GetTenantTags()...)
+		opts.TenantIDs = source.GetTenantIDs()
+		opts.TenantedDeploymentMode = source.GetTenantedDeploymentMode()
+	}
+}
+
+// EnvVars are the variables printed for an SSH account by the env output format. The private key and
+// passphrase are deliberately left out.
+func EnvVars(account accounts.IAccount, username string) []output.EnvVar {
+	return append(helper.AccountEnvVars(account), output.EnvVar{Key: "OCTOPUS_ACCOUNT_USERNAME", Value: username})
+}
@@ -3,15 +3,23 @@ package create_test
 import (
 	"bytes"
 	"encoding/base64"
+	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/create"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/surveyext"
 	"github.com/OctopusDeploy/cli/test/fixtures"
 	"github.com/OctopusDeploy/cli/test/testutil"
@@ -19,6 +27,8 @@ import (
 	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +52,11 @@ func TestGCPAccountCreatePromptMissing(t *testing.T) {
 		GetAllEnvironmentsCallback: func() ([]*environments.Environment, error) {
 			return []*environments.Environment{env}, nil
 		},
+		GetAllTagSetsCallback: func() ([]*tagsets.TagSet, error) {
+			tagSet := tagsets.NewTagSet("Region")
+			tagSet.Tags = []*tagsets.Tag{{Name: "us-east", CanonicalTagName: "Region/us-east"}}
+			return []*tagsets.TagSet{tagSet}, nil
+		},
 	}
 
 	opts.KeyFileData = []byte{1, 1}
@@ -86,6 +101,11 @@ func TestGCPAccountCreatePromptMissing(t *testing.T) {
 		Options: []string{"testenv"},
 	}).AnswerWith([]string{"testenv"})
 
+	_ = qa.ExpectQuestion(t, &survey.MultiSelect{
+		Message: "Choose the tenant tags which can use this account.\nIf nothing is selected, the account is not restricted to any tenants.",
+		Options: []string{"Region/us-east"},
+	}).AnswerWith([]string{"Region/us-east"})
+
 	err := <-errReceiver
 	assert.Nil(t, err)
 
@@ -94,6 +114,49 @@ func TestGCPAccountCreatePromptMissing(t *testing.T) {
 	assert.Equal(t, "TestAccount", opts.Name.Value)
 	assert.Equal(t, "username123", opts.Username.Value)
 	assert.Equal(t, "password123", opts.Passphrase.Value)
+	assert.Equal(t, []string{"Region/us-east"}, opts.TenantTags.Value)
+}
+
+func TestSshAccountCreateNoDescription(t *testing.T) {
+	newOpts := func() *create.CreateOptions {
+		opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{})
+		opts.Name.Value = "TestAccount"
+		opts.Username.Value = "username123"
+		opts.Passphrase.Value = "password123"
+		opts.KeyFileData = []byte{1, 1}
+		opts.Environments.Value = []string{}
+		opts.TenantTags.Value = []string{}
+		return opts
+	}
+
+	t.Run("doesn't open the editor when --no-description is given", func(t *testing.T) {
+		opts := newOpts()
+		opts.NoDescription.Value = true
+		opts.Ask = func(p survey.Prompt, _ interface{}, _ ...survey.AskOpt) error {
+			t.Fatalf("unexpected prompt %#v", p)
+			return nil
+		}
+
+		assert.Nil(t, create.PromptMissing(opts))
+		assert.Equal(t, "", opts.Description.Value)
+	})
+
+	t.Run("remembers that the description was skipped", func(t *testing.T) {
+		opts := newOpts()
+		asked := 0
+		opts.Ask = func(p survey.Prompt, _ interface{}, _ ...survey.AskOpt) error {
+			asked++
+			return nil // the user pressed enter to skip
+		}
+
+		assert.Nil(t, create.PromptMissing(opts))
+		assert.Equal(t, 1, asked)
+		assert.True(t, opts.NoDescription.Value)
+
+		// as happens when a draft is resumed
+		assert.Nil(t, create.PromptMissing(opts))
+		assert.Equal(t, 1, asked)
+	})
 }
 
 func TestGCPAccountCreateNoPrompt(t *testing.T) {
@@ -111,7 +174,7 @@ func TestGCPAccountCreateNoPrompt(t *testing.T) {
 	opts.Space.ID = spaceID
 
 	opts.Name.Value = "testaccount"
-	opts.KeyFileData = []byte{1, 1}
+	opts.KeyFileData = fixtures.NewSshPrivateKey()
 	opts.Username.Value = "username123"
 	opts.Passphrase.Value = "passphrase"
 
@@ -152,3 +215,562 @@ func TestGCPAccountCreateNoPrompt(t *testing.T) {
 		output.Bluef("%s/app#/%s/infrastructure/accounts/%s", "", opts.Space.GetID(), testAccount.ID),
 	), res)
 }
+
+func TestSshAccountCreateEnvOutput(t *testing.T) {
+	const spaceID = "Spaces-1"
+	api, qa := testutil.NewMockServerAndAsker()
+	out := &bytes.Buffer{}
+
+	opts := &create.CreateOptions{
+		CreateFlags:  create.NewCreateFlags(),
+		Dependencies: &cmd.Dependencies{Space: &spaces.Space{}, OutputFormat: constants.OutputFormatEnv},
+	}
+	opts.Space.ID = spaceID
+	opts.Name.Value = "deploy's key"
+	opts.KeyFileData = fixtures.NewSshPrivateKey()
+	opts.Username.Value = "deploy"
+	opts.Passphrase.Value = "secret-passphrase"
+
+	errReceiver := testutil.GoBegin(func() error {
+		defer testutil.Close(api, qa)
+		octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+		opts.Ask = qa.AsAsker()
+		opts.Client = octopus
+		opts.Out = out
+		opts.NoPrompt = true
+		return create.CreateRun(opts)
+	})
+
+	createdAccount, err := accounts.NewSSHKeyAccount(opts.Name.Value, opts.Username.Value, core.NewSensitiveValue(""))
+	assert.Nil(t, err)
+	createdAccount.ID = "Accounts-1"
+	createdAccount.Slug = "deploys-key"
+	createdAccount.SpaceID = spaceID
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+
+	err = <-errReceiver
+	assert.Nil(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		OCTOPUS_ACCOUNT_ID=Accounts-1
+		OCTOPUS_ACCOUNT_NAME='deploy'\''s key'
+		OCTOPUS_ACCOUNT_SLUG=deploys-key
+		OCTOPUS_ACCOUNT_TYPE=SshKeyPair
+		OCTOPUS_SPACE_ID=Spaces-1
+		OCTOPUS_ACCOUNT_USERNAME=deploy
+	`), out.String())
+	assert.NotContains(t, out.String(), "secret-passphrase")
+}
+
+func TestSshAccountCreateIfNotExists(t *testing.T) {
+	const spaceID = "Spaces-1"
+	newExistingAccounts := func() []accounts.IAccount {
+		sshAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", core.NewSensitiveValue(""))
+		sshAccount.ID = "Accounts-7"
+		sshAccount.SpaceID = spaceID
+		// same name, different type: account names only have to be unique within a type
+		tokenAccount, _ := accounts.NewTokenAccount("Web Deploy", core.NewSensitiveValue(""))
+		tokenAccount.ID = "Accounts-8"
+		return []accounts.IAccount{tokenAccount, sshAccount}
+	}
+
+	run := func(t *testing.T, name string, outputFormat string, respond func(api *testutil.MockHttpServer)) (string, error) {
+		api, qa := testutil.NewMockServerAndAsker()
+		out := &bytes.Buffer{}
+		opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Space: &spaces.Space{}, OutputFormat: outputFormat, Out: out, NoPrompt: true})
+		opts.Space.ID = spaceID
+		opts.Name.Value = name
+		opts.KeyFileData = fixtures.NewSshPrivateKey()
+		opts.Username.Value = "deploy"
+		opts.IfNotExists.Value = true
+
+		errReceiver := testutil.GoBegin(func() error {
+			defer testutil.Close(api, qa)
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			opts.Client = octopus
+			return create.CreateRun(opts)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith(newExistingAccounts())
+		respond(api)
+
+		err := <-errReceiver
+		return out.String(), err
+	}
+
+	t.Run("prints the existing account instead of creating another", func(t *testing.T) {
+		out, err := run(t, "web deploy", "", func(api *testutil.MockHttpServer) {})
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("SSH account Web deploy %s already exists, so nothing was created.\n", output.Dimf("(Accounts-7)")), out)
+	})
+
+	t.Run("prints the existing account as environment variables", func(t *testing.T) {
+		out, err := run(t, "Web deploy", constants.OutputFormatEnv, func(api *testutil.MockHttpServer) {})
+		assert.Nil(t, err)
+		assert.Contains(t, out, "OCTOPUS_ACCOUNT_ID=Accounts-7\n")
+		assert.Contains(t, out, "OCTOPUS_ACCOUNT_USERNAME=deploy\n")
+	})
+
+	t.Run("creates the account when there isn't one", func(t *testing.T) {
+		out, err := run(t, "DB deploy", "", func(api *testutil.MockHttpServer) {
+			createdAccount, _ := accounts.NewSSHKeyAccount("DB deploy", "deploy", core.NewSensitiveValue(""))
+			createdAccount.ID = "Accounts-9"
+			createdAccount.Slug = "db-deploy"
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, out, "Successfully created SSH account DB deploy")
+	})
+}
+
+func TestSshAccountCreateIfNotExistsBeforeCreatingEnvironments(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	existingAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", core.NewSensitiveValue(""))
+	existingAccount.ID = "Accounts-7"
+	keyFile := filepath.Join(t.TempDir(), "id_web")
+	assert.Nil(t, os.WriteFile(keyFile, fixtures.NewSshPrivateKey(), 0600))
+
+	run := func(t *testing.T, args []string, respond func(api *testutil.MockHttpServer)) (string, error) {
+		api, qa := testutil.NewMockServerAndAsker()
+		askProvider := question.NewAskProvider(qa.AsAsker())
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+
+		cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+			defer api.Close()
+			rootCmd.SetArgs(append([]string{"account", "ssh", "create", "--username", "deploy", "--private-key", keyFile, "--environment", "Staging", "--create-missing-environments", "--if-not-exists", "--no-prompt"}, args...))
+			return rootCmd.ExecuteC()
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		respond(api)
+
+		_, err := testutil.ReceivePair(cmdReceiver)
+		return out.String(), err
+	}
+
+	t.Run("finds the account without creating the environment", func(t *testing.T) {
+		out, err := run(t, []string{"--name", "Web deploy"}, func(api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{existingAccount})
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, out, "already exists, so nothing was created")
+	})
+
+	t.Run("only looks for the account once", func(t *testing.T) {
+		createdEnvironment := fixtures.NewEnvironment("Spaces-1", "Environments-3", "Staging")
+		createdAccount, _ := accounts.NewSSHKeyAccount("DB deploy", "deploy", core.NewSensitiveValue(""))
+		createdAccount.ID = "Accounts-9"
+		out, err := run(t, []string{"--name", "DB deploy"}, func(api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{existingAccount})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{})
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/environments").RespondWithStatus(201, "", createdEnvironment)
+			api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts").RespondWithStatus(201, "", createdAccount)
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, out, "Successfully created SSH account DB deploy")
+	})
+
+	t.Run("needs a name to look for", func(t *testing.T) {
+		_, err := run(t, nil, func(api *testutil.MockHttpServer) {})
+		assert.EqualError(t, err, "--if-not-exists with --create-missing-environments needs --name, so that the account can be looked for before any environment is created")
+	})
+}
+
+func TestSshAccountCreateDryRun(t *testing.T) {
+	out := &bytes.Buffer{}
+	opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Out: out, NoPrompt: true})
+	opts.Name.Value = "Web deploy"
+	opts.KeyFileData = fixtures.NewSshPrivateKey()
+	opts.Username.Value = "deploy"
+	opts.Passphrase.Value = "secret-passphrase"
+	opts.Environments.Value = []string{"Environments-1"}
+	opts.DryRun.Value = true
+
+	// there's no Octopus client, so this would panic if it tried to create the account
+	assert.Nil(t, create.CreateRun(opts))
+
+	assert.True(t, strings.HasPrefix(out.String(), "Dry run: this SSH account would be created.\n"))
+	assert.Contains(t, out.String(), `"Name": "Web deploy"`)
+	assert.Contains(t, out.String(), `"EnvironmentIds": [
+    "Environments-1"
+  ]`)
+	assert.Contains(t, out.String(), `"NewValue": "***"`)
+	assert.NotContains(t, out.String(), "secret-passphrase")
+	assert.NotContains(t, out.String(), base64.StdEncoding.EncodeToString(opts.KeyFileData))
+}
+
+func TestSshAccountCreateRejectsInvalidKey(t *testing.T) {
+	opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Out: &bytes.Buffer{}, NoPrompt: true})
+	opts.Name.Value = "Web deploy"
+	opts.Username.Value = "deploy"
+	opts.KeyFileData = []byte("not a key")
+
+	// there's no Octopus client, so this shows the key is checked before anything is sent
+	assert.EqualError(t, create.CreateRun(opts), "the provided file does not contain a valid SSH private key")
+}
+
+func TestSshAccountCreateValidateFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(opts *create.CreateOptions)
+		err   *cliErrors.ValidationError
+	}{
+		{"accepts everything it needs", func(opts *create.CreateOptions) {}, nil},
+		{"requires a name", func(opts *create.CreateOptions) { opts.Name.Value = "" }, cliErrors.NewValidationError("name", cliErrors.ValidationCodeRequired, "a name is required; use --name")},
+		{"limits the name to 200 characters", func(opts *create.CreateOptions) { opts.Name.Value = strings.Repeat("a", 201) }, cliErrors.NewValidationError("name", cliErrors.ValidationCodeTooLong, "the name can't be longer than 200 characters")},
+		{"requires a username", func(opts *create.CreateOptions) { opts.Username.Value = "" }, cliErrors.NewValidationError("username", cliErrors.ValidationCodeRequired, "a username is required; use --username")},
+		{"requires a private key", func(opts *create.CreateOptions) { opts.KeyFileData = nil }, cliErrors.NewValidationError("private-key", cliErrors.ValidationCodeRequired, "a private key is required; use --private-key")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{})
+			opts.Name.Value = "Web deploy"
+			opts.Username.Value = "deploy"
+			opts.KeyFileData = fixtures.NewSshPrivateKey()
+			test.setup(opts)
+
+			err := create.ValidateFlags(opts)
+			if test.err == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, test.err, err)
+			}
+		})
+	}
+}
+
+func TestSshAccountCreateKeyFromStdin(t *testing.T) {
+	const spaceID = "Spaces-1"
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer)
+	}{
+		{"reads the private key from stdin and skips prompting", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			keyData := string(fixtures.NewSshPrivateKey())
+			rootCmd.SetIn(strings.NewReader(keyData))
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "ssh", "create", "--name", "piped", "--username", "deploy", "--private-key", "-"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, "piped", requestBody["Name"])
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(keyData)), requestBody["PrivateKeyFile"].(map[string]any)["NewValue"])
+
+			createdAccount, _ := accounts.NewSSHKeyAccount("piped", "deploy", core.NewSensitiveValue(""))
+			createdAccount.ID = "Accounts-1"
+			createdAccount.Slug = "piped"
+			req.RespondWithStatus(201, "", createdAccount)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+			assert.NotContains(t, stdOut.String(), "Automation Command")
+		}},
+
+		{"errors when stdin is empty", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			rootCmd.SetIn(strings.NewReader("  \n"))
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "ssh", "create", "--name", "piped", "--username", "deploy", "--private-key", "-"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "no private key data was read from stdin")
+		}},
+
+		{"reads the description from stdin", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			keyFile := filepath.Join(t.TempDir(), "id_rsa")
+			assert.Nil(t, os.WriteFile(keyFile, fixtures.NewSshPrivateKey(), 0600))
+			rootCmd.SetIn(strings.NewReader("Generated by the pipeline"))
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "ssh", "create", "--name", "piped", "--username", "deploy", "--private-key", keyFile, "--description-file", "-", "--no-prompt"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, "Generated by the pipeline", requestBody["Description"])
+
+			createdAccount, _ := accounts.NewSSHKeyAccount("piped", "deploy", core.NewSensitiveValue(""))
+			createdAccount.ID = "Accounts-1"
+			req.RespondWithStatus(201, "", createdAccount)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+		}},
+
+		{"errors when both the key and the description are read from stdin", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs([]string{"account", "ssh", "create", "--name", "piped", "--username", "deploy", "--private-key", "-", "--description-file", "-"})
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, "only one of --description-file and --private-key can be read from stdin")
+		}},
+
+		{"errors when --any-environment and --environment are both given", func(t *testing.T, api *testutil.MockHttpServer, rootCmd *cobra.Command, stdOut *bytes.Buffer, stdErr *bytes.Buffer) {
+			defer api.Close()
+			rootCmd.SetArgs([]string{"account", "ssh", "create", "--name", "everywhere", "--any-environment", "--environment", "Production"})
+			_, err := rootCmd.ExecuteC()
+			assert.EqualError(t, err, "if any flags in the group [any-environment environment] are set none of the others can be; [any-environment environment] were all set")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+			test.run(t, api, rootCmd, stdout, stderr)
+		})
+	}
+}
+
+func TestSshAccountCreateFromJson(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	createdAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-1"
+
+	tests := []struct {
+		name       string
+		definition string
+		args       []string
+		run        func(t *testing.T, requestBody map[string]any) // nil when the command should fail
+		err        string
+	}{
+		{"creates the account the definition describes", `{"name": "Web deploy", "username": "deploy", "description": "From git"}`, nil, func(t *testing.T, requestBody map[string]any) {
+			assert.Equal(t, "Web deploy", requestBody["Name"])
+			assert.Equal(t, "deploy", requestBody["Username"])
+			assert.Equal(t, "From git", requestBody["Description"])
+		}, ""},
+		{"flags on the command line override the definition", `{"name": "Web deploy", "username": "deploy"}`, []string{"--username", "root"}, func(t *testing.T, requestBody map[string]any) {
+			assert.Equal(t, "Web deploy", requestBody["Name"])
+			assert.Equal(t, "root", requestBody["Username"])
+		}, ""},
+		{"checks the private key the definition names", `{"name": "Web deploy", "username": "deploy", "private-key": "no-such-key"}`, nil, nil,
+			`"no-such-key" is not a valid file path`},
+		{"won't take the passphrase from the definition", `{"name": "Web deploy", "passphrase": "hunter2"}`, nil, nil,
+			"'passphrase' is secret, so it can't be kept there; give it with --passphrase instead"},
+		{"rejects settings it doesn't know", `{"name": "Web deploy", "enviroment": ["Production"]}`, nil, nil,
+			"'enviroment' isn't something it can set; use any of name, description, private-key, key-is-base64, username, environment, tenant-tag, copy-scope-from"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			keyFile := filepath.Join(dir, "id_web")
+			assert.Nil(t, os.WriteFile(keyFile, fixtures.NewSshPrivateKey(), 0600))
+			definitionFile := filepath.Join(dir, "web-deploy.json")
+			assert.Nil(t, os.WriteFile(definitionFile, []byte(test.definition), 0600))
+
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(&bytes.Buffer{})
+
+			args := []string{"account", "ssh", "create", "--from-json", definitionFile, "--no-prompt"}
+			if !strings.Contains(test.definition, "private-key") {
+				args = append(args, "--private-key", keyFile)
+			}
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append(args, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			if test.run != nil {
+				req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+				requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+				assert.Nil(t, err)
+				req.RespondWithStatus(201, "", createdAccount)
+				test.run(t, requestBody)
+			}
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestSshAccountCreateCopyScope(t *testing.T) {
+	const spaceID = "Spaces-1"
+	space1 := fixtures.NewSpace(spaceID, "Default Space")
+
+	sourceAccount, _ := accounts.NewSSHKeyAccount("DB deploy", "deploy", core.NewSensitiveValue(""))
+	sourceAccount.ID = "Accounts-4"
+	sourceAccount.EnvironmentIDs = []string{"Environments-1", "Environments-2"}
+	sourceAccount.TenantTags = []string{"Region/us-east"}
+	sourceAccount.TenantIDs = []string{"Tenants-3"}
+	sourceAccount.TenantedDeploymentMode = core.TenantedDeploymentModeTenanted
+
+	createdAccount, _ := accounts.NewSSHKeyAccount("Web deploy", "deploy", core.NewSensitiveValue(""))
+	createdAccount.ID = "Accounts-5"
+
+	tests := []struct {
+		name string
+		args []string
+		run  func(t *testing.T, api *testutil.MockHttpServer)
+	}{
+		{"copies the scope of the other account", []string{"--copy-scope-from", "db deploy"}, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{sourceAccount})
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			req.RespondWithStatus(201, "", createdAccount)
+
+			assert.Equal(t, []any{"Environments-1", "Environments-2"}, requestBody["EnvironmentIds"])
+			assert.Equal(t, []any{"Region/us-east"}, requestBody["TenantTags"])
+			assert.Equal(t, []any{"Tenants-3"}, requestBody["TenantIds"])
+			assert.Equal(t, "Tenanted", requestBody["TenantedDeploymentParticipation"])
+		}},
+		{"an explicit --environment overrides the copied environments", []string{"--copy-scope-from", "Accounts-4", "--environment", "Production"}, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{fixtures.NewEnvironment(spaceID, "Environments-9", "Production")})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{sourceAccount})
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			req.RespondWithStatus(201, "", createdAccount)
+
+			assert.Equal(t, []any{"Environments-9"}, requestBody["EnvironmentIds"])
+			assert.Equal(t, []any{"Region/us-east"}, requestBody["TenantTags"])
+		}},
+		{"--any-environment overrides the copied environments", []string{"--copy-scope-from", "Accounts-4", "--any-environment"}, func(t *testing.T, api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{sourceAccount})
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			req.RespondWithStatus(201, "", createdAccount)
+
+			assert.Empty(t, requestBody["EnvironmentIds"])
+			assert.Equal(t, []any{"Region/us-east"}, requestBody["TenantTags"])
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyFile := filepath.Join(t.TempDir(), "id_web")
+			assert.Nil(t, os.WriteFile(keyFile, fixtures.NewSshPrivateKey(), 0600))
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(&bytes.Buffer{})
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"account", "ssh", "create", "--name", "Web deploy", "--username", "deploy", "--private-key", keyFile, "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			test.run(t, api)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestSshAccountCreateBase64Key(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	keyData := fixtures.NewSshPrivateKey()
+	encodedKey := base64.StdEncoding.EncodeToString(keyData)
+
+	tests := []struct {
+		name string
+		key  []byte
+		args []string
+	}{
+		{"sends a PEM key encoded once", keyData, nil},
+		{"detects a key that is already base64", []byte(encodedKey + "\n"), nil},
+		{"doesn't encode again with --key-is-base64", []byte(encodedKey), []string{"--key-is-base64"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyFile := filepath.Join(t.TempDir(), "id_rsa")
+			assert.Nil(t, os.WriteFile(keyFile, test.key, 0600))
+			api, qa := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(qa.AsAsker())
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(&bytes.Buffer{})
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"account", "ssh", "create", "--name", "web", "--username", "deploy", "--private-key", keyFile, "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+			req := api.ExpectRequest(t, "POST", "/api/Spaces-1/accounts")
+			requestBody, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, encodedKey, requestBody["PrivateKeyFile"].(map[string]any)["NewValue"])
+
+			createdAccount, _ := accounts.NewSSHKeyAccount("web", "deploy", core.NewSensitiveValue(""))
+			createdAccount.ID = "Accounts-1"
+			req.RespondWithStatus(201, "", createdAccount)
+
+			_, err = testutil.ReceivePair(cmdReceiver)
+			assert.Nil(t, err)
+		})
+	}
+
+	t.Run("rejects a PEM key given with --key-is-base64", func(t *testing.T) {
+		keyFile := filepath.Join(t.TempDir(), "id_rsa")
+		assert.Nil(t, os.WriteFile(keyFile, keyData, 0600))
+		api, qa := testutil.NewMockServerAndAsker()
+		askProvider := question.NewAskProvider(qa.AsAsker())
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+
+		cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+			defer api.Close()
+			rootCmd.SetArgs([]string{"account", "ssh", "create", "--name", "web", "--username", "deploy", "--private-key", keyFile, "--key-is-base64", "--no-prompt"})
+			return rootCmd.ExecuteC()
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+
+		_, err := testutil.ReceivePair(cmdReceiver)
+		assert.EqualError(t, err, "the private key file is not valid base64; leave out --key-is-base64 if it holds the key itself")
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/create"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/list"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/update"
+	cmdUpdateKey "github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/update-key"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/spf13/cobra"
@@ -19,6 +21,8 @@ func NewCmdSsh(f factory.Factory) *cobra.Command {
 
 	cmd.AddCommand(cmdList.NewCmdList(f))
 	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdUpdateKey.NewCmdUpdateKey(f))
 
 	return cmd
 }
@@ -0,0 +1,169 @@
+package update_key
+
+import (
+	"bytes"
+	b64 "encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/create"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/cli/pkg/validation"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagPrivateKey = "private-key"
+	FlagPassphrase = "passphrase"
+	FlagDryRun     = "dry-run"
+)
+
+type UpdateKeyFlags struct {
+	KeyFilePath *flag.Flag[string]
+	KeyIsBase64 *flag.Flag[bool]
+	Passphrase  *flag.Flag[string]
+	DryRun      *flag.Flag[bool]
+}
+
+type UpdateKeyOptions struct {
+	*UpdateKeyFlags
+	*cmd.Dependencies
+	IdOrName    string
+	KeyFileData []byte
+}
+
+func NewUpdateKeyFlags() *UpdateKeyFlags {
+	return &UpdateKeyFlags{
+		KeyFilePath: flag.New[string](FlagPrivateKey, false),
+		KeyIsBase64: flag.New[bool](helper.FlagKeyIsBase64, false),
+		Passphrase:  flag.New[string](FlagPassphrase, true),
+		DryRun:      flag.New[bool](FlagDryRun, false),
+	}
+}
+
+func NewUpdateKeyOptions(flags *UpdateKeyFlags, dependencies *cmd.Dependencies, idOrName string) *UpdateKeyOptions {
+	return &UpdateKeyOptions{
+		UpdateKeyFlags: flags,
+		Dependencies:   dependencies,
+		IdOrName:       idOrName,
+	}
+}
+
+func NewCmdUpdateKey(f factory.Factory) *cobra.Command {
+	updateKeyFlags := NewUpdateKeyFlags()
+
+	cmd := &cobra.Command{
+		Use:   "update-key {<name> | <id>}",
+		Short: "Replace the private key of a SSH Key Pair account",
+		Long: heredoc.Doc(`
+			Replace the private key of a SSH Key Pair account in Octopus Deploy, e.g. when rotating keys.
+			Only the key and its passphrase are changed; the name, description, username and scope of the
+			account are left as they are. An encrypted key needs --passphrase, and a key which isn't encrypted
+			clears the existing passphrase, which belonged to the old key.
+		`),
+		Example: heredoc.Docf(`
+			$ %[1]s account ssh update-key "Web deploy" --private-key ~/.ssh/web_2024
+			$ cat web_2024 | %[1]s account ssh update-key Accounts-1 --private-key - --passphrase secret
+			$ %[1]s account ssh update-key "Web deploy" --private-key ~/.ssh/web_2024 --dry-run
+		`, constants.ExecutableName),
+		Args: usage.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewUpdateKeyOptions(updateKeyFlags, cmd.NewDependencies(f, c), args[0])
+			switch opts.KeyFilePath.Value {
+			case "":
+				return fmt.Errorf("--%s is required; give the path to the new key, or - to read it from stdin", FlagPrivateKey)
+			case create.StdinPath:
+				data, err := io.ReadAll(c.InOrStdin())
+				if err != nil {
+					return err
+				}
+				if len(bytes.TrimSpace(data)) == 0 {
+					return errors.New("no private key data was read from stdin")
+				}
+				opts.KeyFileData = data
+			default:
+				if err := validation.IsExistingFile(opts.KeyFilePath.Value); err != nil {
+					return err
+				}
+				data, err := os.ReadFile(opts.KeyFilePath.Value)
+				if err != nil {
+					return err
+				}
+				opts.KeyFileData = data
+			}
+			return UpdateKeyRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&updateKeyFlags.KeyFilePath.Value, updateKeyFlags.KeyFilePath.Name, "K", "", "Path to the new private key file, or - to read it from stdin.")
+	flags.BoolVar(&updateKeyFlags.KeyIsBase64.Value, updateKeyFlags.KeyIsBase64.Name, false, "The private key file is already base64 encoded, so don't encode it again. Base64 of a PEM key is detected without this.")
+	flags.StringVarP(&updateKeyFlags.Passphrase.Value, updateKeyFlags.Passphrase.Name, "p", "", "The passphrase for the new private key, if required.")
+	flags.BoolVar(&updateKeyFlags.DryRun.Value, updateKeyFlags.DryRun.Name, false, "Show what would change without updating the account.")
+
+	return cmd
+}
+
+// UpdateKeyRun swaps the key material of an existing SSH account. Everything else on the account is sent
+// back to the server exactly as it was loaded, and the key itself is never written to the output.
+func UpdateKeyRun(opts *UpdateKeyOptions) error {
+	if len(opts.KeyFileData) == 0 {
+		return errors.New("a private key is required")
+	}
+	keyData, err := helper.DecodeSshPrivateKey(opts.KeyFileData, opts.KeyIsBase64.Value)
+	if err != nil {
+		return err
+	}
+	opts.KeyFileData = keyData
+	// checked against the passphrase that will be stored with it, as the old one can't be read back
+	if err := helper.ValidateSshPrivateKey(opts.KeyFileData, opts.Passphrase.Value); err != nil {
+		return err
+	}
+
+	account, err := helper.FindAccount(opts.Client, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+	sshAccount, ok := account.(*accounts.SSHKeyAccount)
+	if !ok {
+		return fmt.Errorf("account '%s' is not a SSH Key Pair account", opts.IdOrName)
+	}
+
+	changes := "private key"
+	if opts.Passphrase.Value != "" || sshAccount.PrivateKeyPassphrase != nil && sshAccount.PrivateKeyPassphrase.HasValue {
+		// a new passphrase, or clearing the old key's one
+		changes = "private key and passphrase"
+	}
+
+	if opts.DryRun.Value {
+		_, err = fmt.Fprintf(opts.Out, "Dry run: the %s of SSH account %s %s would be replaced. Nothing else would change.\n", changes, sshAccount.GetName(), output.Dimf("(%s)", sshAccount.GetID()))
+		return err
+	}
+
+	sshAccount.PrivateKeyFile = core.NewSensitiveValue(b64.StdEncoding.EncodeToString(opts.KeyFileData))
+	sshAccount.PrivateKeyPassphrase = core.NewSensitiveValue(opts.Passphrase.Value)
+
+	updatedAccount, err := opts.Client.Accounts.Update(sshAccount)
+	if err != nil {
+		return err
+	}
+
+	err = output.Successf(opts.Out, updatedAccount.GetID(), "Successfully replaced the %s of SSH account %s %s.\n", changes, updatedAccount.GetName(), output.Dimf("(%s)", updatedAccount.GetID()))
+	if err != nil || output.IsQuiet {
+		return err
+	}
+	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), updatedAccount.GetID())
+	_, _ = fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", link)
+	return nil
+}
@@ -0,0 +1,187 @@
+package update
+
+import (
+	b64 "encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/spf13/cobra"
+)
+
+type UpdateFlags struct {
+	Name         *flag.Flag[string]
+	Description  *flag.Flag[string]
+	KeyFilePath  *flag.Flag[string]
+	KeyIsBase64  *flag.Flag[bool]
+	Username     *flag.Flag[string]
+	Passphrase   *flag.Flag[string]
+	Environments *flag.Flag[[]string]
+}
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IdOrName    string
+	KeyFileData []byte
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		Name:         flag.New[string]("name", false),
+		Description:  flag.New[string]("description", false),
+		KeyFilePath:  flag.New[string]("private-key", false),
+		KeyIsBase64:  flag.New[bool](helper.FlagKeyIsBase64, false),
+		Username:     flag.New[string]("username", false),
+		Passphrase:   flag.New[string]("passphrase", true),
+		Environments: flag.New[[]string]("environment", false),
+	}
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, idOrName string) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IdOrName:     idOrName,
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+	descriptionFilePath := ""
+	strict := false
+	anyEnvironment := false
+
+	cmd := &cobra.Command{
+		Use:   "update {<name> | <id>}",
+		Short: "Update a SSH Key Pair account",
+		Long:  "Update a SSH Key Pair account in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s account ssh update "Web deploy" --username deploy
+			$ %[1]s account ssh update Accounts-1 --private-key ./id_rsa --passphrase secret
+		`, constants.ExecutableName),
+		Args: usage.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), args[0])
+			if descriptionFilePath == util.StdinPath && opts.KeyFilePath.Value == util.StdinPath {
+				return errors.New("only one of --description-file and --private-key can be read from stdin")
+			}
+			if descriptionFilePath != "" {
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
+				if err != nil {
+					return err
+				}
+				opts.Description.Value = string(data)
+			}
+			if opts.KeyFilePath.Value != "" {
+				data, err := util.ReadFileOrStdin(opts.KeyFilePath.Value, c.InOrStdin())
+				if err != nil {
+					return err
+				}
+				opts.KeyFileData = data
+			}
+			if err := helper.ResolveEnvironmentFlags(c, opts.Environments, opts.Dependencies, strict, anyEnvironment, false); err != nil {
+				return err
+			}
+			return UpdateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "A short, memorable, unique name for this account.")
+	flags.StringVarP(&updateFlags.Description.Value, updateFlags.Description.Name, "d", "", "A summary explaining the use of the account to other users.")
+	flags.StringVarP(&updateFlags.KeyFilePath.Value, updateFlags.KeyFilePath.Name, "K", "", "Path to a replacement private key file portion of the key pair, or - to read it from stdin.")
+	flags.BoolVar(&updateFlags.KeyIsBase64.Value, updateFlags.KeyIsBase64.Name, false, "The private key file is already base64 encoded, so don't encode it again. Base64 of a PEM key is detected without this.")
+	flags.StringVarP(&updateFlags.Username.Value, updateFlags.Username.Name, "u", "", "The username to use when authenticating against the remote host.")
+	flags.StringVarP(&updateFlags.Passphrase.Value, updateFlags.Passphrase.Name, "p", "", "The passphrase for the private key, if required. A replacement key which isn't encrypted clears the existing passphrase.")
+	flags.StringArrayVarP(&updateFlags.Environments.Value, updateFlags.Environments.Name, "e", nil, "The environments that are allowed to use this account.")
+	helper.RegisterEnvironmentCompletion(cmd, f, updateFlags.Environments.Name)
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+	helper.RegisterStrictFlag(cmd, &strict)
+	helper.RegisterAnyEnvironmentFlag(cmd, &anyEnvironment)
+
+	return cmd
+}
+
+// UpdateRun applies only the values which were supplied on the command line; anything left unset
+// keeps its existing value on the server.
+func UpdateRun(opts *UpdateOptions) error {
+	if err := ValidateFlags(opts); err != nil {
+		return err
+	}
+	if len(opts.KeyFileData) != 0 {
+		keyData, err := helper.DecodeSshPrivateKey(opts.KeyFileData, opts.KeyIsBase64.Value)
+		if err != nil {
+			return err
+		}
+		opts.KeyFileData = keyData
+		// the passphrase stored with the old key can't be read back, so an encrypted replacement needs --passphrase
+		if err := helper.ValidateSshPrivateKey(opts.KeyFileData, opts.Passphrase.Value); err != nil {
+			return err
+		}
+	}
+
+	account, err := helper.FindAccount(opts.Client, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+	sshAccount, ok := account.(*accounts.SSHKeyAccount)
+	if !ok {
+		return fmt.Errorf("account '%s' is not a SSH Key Pair account", opts.IdOrName)
+	}
+
+	if opts.Name.Value != "" {
+		sshAccount.SetName(opts.Name.Value)
+	}
+	if opts.Description.Value != "" {
+		sshAccount.SetDescription(opts.Description.Value)
+	}
+	if opts.Username.Value != "" {
+		sshAccount.Username = opts.Username.Value
+	}
+	if len(opts.KeyFileData) != 0 {
+		sshAccount.PrivateKeyFile = core.NewSensitiveValue(b64.StdEncoding.EncodeToString(opts.KeyFileData))
+		// the old passphrase belongs to the old key; an empty one clears it
+		sshAccount.PrivateKeyPassphrase = core.NewSensitiveValue(opts.Passphrase.Value)
+	} else if opts.Passphrase.Value != "" {
+		sshAccount.PrivateKeyPassphrase = core.NewSensitiveValue(opts.Passphrase.Value)
+	}
+	if opts.Environments.Value != nil {
+		sshAccount.SetEnvironmentIDs(opts.Environments.Value)
+	}
+
+	updatedAccount, err := opts.Client.Accounts.Update(sshAccount)
+	if err != nil {
+		return helper.ExplainEnvironmentLimitError(err, opts.Environments.Value)
+	}
+
+	err = output.Successf(opts.Out, updatedAccount.GetID(), "Successfully updated SSH account %s %s.\n", updatedAccount.GetName(), output.Dimf("(%s)", updatedAccount.GetSlug()))
+	if err != nil || output.IsQuiet {
+		return err
+	}
+	link := output.Bluef("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), updatedAccount.GetID())
+	_, _ = fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", link)
+	return nil
+}
+
+// ValidateFlags checks the values which were supplied before anything is looked up
+func ValidateFlags(opts *UpdateOptions) error {
+	if len(opts.Name.Value) > 200 {
+		return cliErrors.NewValidationError(opts.Name.Name, cliErrors.ValidationCodeTooLong, "the name can't be longer than 200 characters")
+	}
+	if opts.KeyIsBase64.Value && len(opts.KeyFileData) == 0 {
+		return cliErrors.NewValidationError(opts.KeyIsBase64.Name, cliErrors.ValidationCodeInvalid, "--"+opts.KeyIsBase64.Name+" only applies to a replacement key; use it with --"+opts.KeyFilePath.Name)
+	}
+	return nil
+}
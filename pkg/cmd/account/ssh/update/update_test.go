@@ -0,0 +1,130 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/ssh/update"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func newExistingAccount() *accounts.SSHKeyAccount {
+	account, _ := accounts.NewSSHKeyAccount("existing", "olduser", core.NewSensitiveValue(""))
+	account.ID = "Accounts-1"
+	account.Slug = "existing"
+	account.SpaceID = "Spaces-1"
+	account.Description = "old description"
+	account.EnvironmentIDs = []string{"Environments-1"}
+	account.PrivateKeyFile = &core.SensitiveValue{HasValue: true}
+	account.PrivateKeyPassphrase = &core.SensitiveValue{HasValue: true}
+	return account
+}
+
+func TestSshAccountUpdate(t *testing.T) {
+	newKey := fixtures.NewSshPrivateKey()
+	encryptedKey := fixtures.NewEncryptedSshPrivateKey("secret")
+
+	tests := []struct {
+		name     string
+		idOrName string
+		setup    func(opts *update.UpdateOptions)
+		verify   func(t *testing.T, body map[string]any)
+	}{
+		{"leaves unset fields untouched", "Accounts-1", func(opts *update.UpdateOptions) {
+			opts.Username.Value = "newuser"
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, "existing", body["Name"])
+			assert.Equal(t, "newuser", body["Username"])
+			assert.Equal(t, "old description", body["Description"])
+			assert.Equal(t, []any{"Environments-1"}, body["EnvironmentIds"])
+			assert.Nil(t, body["PrivateKeyFile"].(map[string]any)["NewValue"])
+		}},
+		{"replaces the private key when supplied", "existing", func(opts *update.UpdateOptions) {
+			opts.KeyFileData = newKey
+			opts.Environments.Value = []string{"Environments-2"}
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, "olduser", body["Username"])
+			assert.Equal(t, []any{"Environments-2"}, body["EnvironmentIds"])
+			assert.Equal(t, base64.StdEncoding.EncodeToString(newKey), body["PrivateKeyFile"].(map[string]any)["NewValue"])
+			// the new key isn't encrypted, so the old key's passphrase is cleared
+			assert.Equal(t, false, body["PrivateKeyPassphrase"].(map[string]any)["HasValue"])
+		}},
+		{"decodes a key which is already base64", "existing", func(opts *update.UpdateOptions) {
+			opts.KeyFileData = []byte(base64.StdEncoding.EncodeToString(encryptedKey))
+			opts.KeyIsBase64.Value = true
+			opts.Passphrase.Value = "secret"
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, base64.StdEncoding.EncodeToString(encryptedKey), body["PrivateKeyFile"].(map[string]any)["NewValue"])
+			assert.Equal(t, "secret", body["PrivateKeyPassphrase"].(map[string]any)["NewValue"])
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			out := &bytes.Buffer{}
+			opts := update.NewUpdateOptions(update.NewUpdateFlags(), &cmd.Dependencies{Space: &spaces.Space{}, Out: out}, test.idOrName)
+			opts.Space.ID = "Spaces-1"
+			test.setup(opts)
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer api.Close()
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Client = octopus
+				return update.UpdateRun(opts)
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{newExistingAccount()})
+
+			req := api.ExpectRequest(t, "PUT", "/api/Spaces-1/accounts/Accounts-1")
+			body, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			test.verify(t, body)
+			req.RespondWith(newExistingAccount())
+
+			err = <-errReceiver
+			assert.Nil(t, err)
+			assert.Contains(t, out.String(), "Successfully updated SSH account existing")
+		})
+	}
+}
+
+func TestSshAccountUpdateRejectsBadKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        []byte
+		passphrase string
+		err        string
+	}{
+		{"a public key", []byte("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGiP3/y0bPzHDgHZ5Sp0xkO3D8rZL0l3pN9c3+0nzZ1e deploy@web\n"), "", "the provided file does not contain a valid SSH private key; it looks like a public key, which is usually the file ending in .pub"},
+		{"an encrypted key without its passphrase", fixtures.NewEncryptedSshPrivateKey("secret"), "", "the private key is encrypted; give its passphrase with --passphrase"},
+		{"the wrong passphrase", fixtures.NewEncryptedSshPrivateKey("secret"), "guess", "the passphrase does not decrypt the private key"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := update.NewUpdateOptions(update.NewUpdateFlags(), &cmd.Dependencies{Space: &spaces.Space{}, Out: &bytes.Buffer{}}, "existing")
+			opts.KeyFileData = test.key
+			opts.Passphrase.Value = test.passphrase
+
+			// nothing is sent to the server, so there is no client
+			assert.EqualError(t, update.UpdateRun(opts), test.err)
+		})
+	}
+}
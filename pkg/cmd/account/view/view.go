@@ -0,0 +1,217 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/list"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/google/uuid"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWeb = "web"
+)
+
+type ViewFlags struct {
+	Web *flag.Flag[bool]
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Web: flag.New[bool](FlagWeb, false),
+	}
+}
+
+func NewViewOptions(flags *ViewFlags, dependencies *cmd.Dependencies, idOrName string) *ViewOptions {
+	return &ViewOptions{
+		ViewFlags:    flags,
+		Dependencies: dependencies,
+		IdOrName:     idOrName,
+	}
+}
+
+// AccountAsJson is what we print for --output-format json. It is built field by field rather than
+// marshalling the account itself, so that keys, passwords and tokens can never end up in the output.
+type AccountAsJson struct {
+	Id                     string             `json:"Id"`
+	Name                   string             `json:"Name"`
+	Slug                   string             `json:"Slug"`
+	Type                   string             `json:"Type"`
+	Description            string             `json:"Description"`
+	Environments           []output.IdAndName `json:"Environments"`
+	TenantedDeploymentMode string             `json:"TenantedDeploymentMode"`
+	TenantIds              []string           `json:"TenantIds"`
+	Details                map[string]string  `json:"Details"`
+	WebUrl                 string             `json:"WebUrl"`
+}
+
+// detail is a non-sensitive, type-specific field of an account
+type detail struct {
+	Key   string // used for json
+	Label string // used for display
+	Value string
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+
+	cmd := &cobra.Command{
+		Args:  usage.ExactArgs(1),
+		Use:   "view {<name> | <id>}",
+		Short: "View an account",
+		Long:  "View an account of any type in Octopus Deploy. Keys, passwords and tokens are never shown",
+		Example: heredoc.Docf(`
+			$ %[1]s account view "Deploy Key"
+			$ %[1]s account view Accounts-12 --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			return ViewRun(NewViewOptions(viewFlags, cmd.NewDependencies(f, c), args[0]))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+func ViewRun(opts *ViewOptions) error {
+	account, err := helper.FindAccount(opts.Client, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	// accounts only store environment IDs, which mean little to people, so look up the names
+	var accountEnvironments []output.IdAndName
+	if environmentIDs := account.GetEnvironmentIDs(); len(environmentIDs) > 0 {
+		allEnvironments, err := opts.Client.Environments.GetAll()
+		if err != nil {
+			return err
+		}
+		environmentNames := make(map[string]string, len(allEnvironments))
+		for _, environment := range allEnvironments {
+			environmentNames[environment.GetID()] = environment.Name
+		}
+		for _, environmentID := range environmentIDs {
+			accountEnvironments = append(accountEnvironments, output.IdAndName{Id: environmentID, Name: environmentNames[environmentID]})
+		}
+	}
+
+	details := accountDetails(account)
+	url := fmt.Sprintf("%s/app#/%s/infrastructure/accounts/%s", opts.Host, opts.Space.GetID(), account.GetID())
+
+	if opts.OutputFormat == constants.OutputFormatJson {
+		accountJson := &AccountAsJson{
+			Id:                     account.GetID(),
+			Name:                   account.GetName(),
+			Slug:                   account.GetSlug(),
+			Type:                   string(account.GetAccountType()),
+			Description:            account.GetDescription(),
+			Environments:           []output.IdAndName{},
+			TenantedDeploymentMode: string(account.GetTenantedDeploymentMode()),
+			TenantIds:              []string{},
+			Details:                map[string]string{},
+			WebUrl:                 url,
+		}
+		accountJson.Environments = append(accountJson.Environments, accountEnvironments...)
+		accountJson.TenantIds = append(accountJson.TenantIds, account.GetTenantIDs()...)
+		for _, d := range details {
+			accountJson.Details[d.Key] = d.Value
+		}
+		data, err := json.MarshalIndent(output.WrapJson(accountJson), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "%s %s\n", output.Bold(account.GetName()), output.Dimf("(%s)", account.GetID()))
+	if account.GetDescription() == "" {
+		fmt.Fprintln(opts.Out, output.Dim(constants.NoDescription))
+	} else {
+		fmt.Fprintln(opts.Out, output.Dim(account.GetDescription()))
+	}
+	fmt.Fprintf(opts.Out, "Type: %s\n", list.AccountTypeMap[account.GetAccountType()])
+	for _, d := range details {
+		fmt.Fprintf(opts.Out, "%s: %s\n", d.Label, d.Value)
+	}
+
+	if len(accountEnvironments) == 0 {
+		fmt.Fprintf(opts.Out, "Environments: %s\n", output.Dim("All"))
+	} else {
+		environmentNames := make([]string, 0, len(accountEnvironments))
+		for _, environment := range accountEnvironments {
+			if environment.Name == "" {
+				environmentNames = append(environmentNames, environment.Id) // deleted since, or not visible to us
+			} else {
+				environmentNames = append(environmentNames, environment.Name)
+			}
+		}
+		fmt.Fprintf(opts.Out, "Environments: %s\n", output.FormatAsList(environmentNames))
+	}
+	if mode := account.GetTenantedDeploymentMode(); mode != "" {
+		fmt.Fprintf(opts.Out, "Tenanted deployments: %s\n", mode)
+	}
+
+	// footer
+	fmt.Fprintf(opts.Out, "\nView this account on Octopus Deploy: %s\n", output.Blue(url))
+
+	if opts.Web.Value {
+		browser.OpenURL(url)
+	}
+
+	return nil
+}
+
+// accountDetails returns the fields specific to the type of account. Anything secret (private keys,
+// passwords, secret keys, tokens, certificates) is deliberately left out.
+func accountDetails(account accounts.IAccount) []detail {
+	switch a := account.(type) {
+	case *accounts.SSHKeyAccount:
+		return []detail{{"Username", "Username", a.Username}}
+	case *accounts.UsernamePasswordAccount:
+		return []detail{{"Username", "Username", a.Username}}
+	case *accounts.AmazonWebServicesAccount:
+		return []detail{{"AccessKey", "Access key", a.AccessKey}}
+	case *accounts.AzureServicePrincipalAccount:
+		return []detail{
+			{"SubscriptionId", "Subscription ID", uuidString(a.SubscriptionID)},
+			{"TenantId", "Tenant ID", uuidString(a.TenantID)},
+			{"ApplicationId", "Application ID", uuidString(a.ApplicationID)},
+			{"AzureEnvironment", "Azure environment", a.AzureEnvironment},
+		}
+	case *accounts.AzureSubscriptionAccount:
+		return []detail{
+			{"SubscriptionId", "Subscription ID", uuidString(a.SubscriptionID)},
+			{"CertificateThumbprint", "Certificate thumbprint", a.CertificateThumbprint},
+			{"AzureEnvironment", "Azure environment", a.AzureEnvironment},
+		}
+	default: // google cloud and token accounts have nothing but secrets
+		return nil
+	}
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
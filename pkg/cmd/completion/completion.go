@@ -0,0 +1,73 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ShellBash       = "bash"
+	ShellZsh        = "zsh"
+	ShellFish       = "fish"
+	ShellPowerShell = "powershell"
+)
+
+func NewCmdCompletion() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion {bash | zsh | fish | powershell}",
+		Short: "Generate shell completion scripts",
+		Long: heredoc.Docf(`
+			Generate the script which makes your shell tab-complete %[1]s commands, flags, and
+			values such as environment names, which are looked up on the Octopus Server.
+
+			Bash (needs the bash-completion package):
+			  $ source <(%[1]s completion bash)
+			  To load it in every session, add that line to ~/.bashrc
+
+			Zsh:
+			  $ %[1]s completion zsh > "${fpath[1]}/_%[1]s"
+			  Completion must be enabled in ~/.zshrc (autoload -U compinit; compinit). Start a new shell afterwards
+
+			Fish:
+			  $ %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+
+			PowerShell:
+			  PS> %[1]s completion powershell | Out-String | Invoke-Expression
+			  To load it in every session, add that line to your $PROFILE
+		`, constants.ExecutableName),
+		Example: heredoc.Docf(`
+			$ source <(%[1]s completion bash)
+			$ %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+		`, constants.ExecutableName),
+		Args:                  usage.ExactArgs(1),
+		ValidArgs:             []string{ShellBash, ShellZsh, ShellFish, ShellPowerShell},
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			annotations.IsConfiguration: "true",
+			annotations.IsSpaceless:     "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case ShellBash:
+				return root.GenBashCompletionV2(out, true)
+			case ShellZsh:
+				return root.GenZshCompletion(out)
+			case ShellFish:
+				return root.GenFishCompletion(out, true)
+			case ShellPowerShell:
+				return root.GenPowerShellCompletionWithDesc(out)
+			default:
+				return usage.NewUsageError(fmt.Sprintf("unsupported shell '%s'; use one of bash, zsh, fish or powershell", args[0]), cmd)
+			}
+		},
+	}
+
+	return cmd
+}
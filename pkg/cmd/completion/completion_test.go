@@ -0,0 +1,49 @@
+package completion_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		shell  string
+		expect string
+	}{
+		{"bash", "# bash completion V2 for octopus"},
+		{"zsh", "#compdef octopus"},
+		{"fish", "# fish completion for octopus"},
+		{"powershell", "# powershell completion for octopus"},
+	}
+	for _, test := range tests {
+		t.Run(test.shell, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			defer api.Close()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+			stdout := &bytes.Buffer{}
+			rootCmd.SetOut(stdout)
+			rootCmd.SetArgs([]string{"completion", test.shell})
+
+			assert.Nil(t, rootCmd.Execute())
+			assert.Contains(t, stdout.String(), test.expect)
+		})
+	}
+
+	t.Run("rejects other shells", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		defer api.Close()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs([]string{"completion", "tcsh"})
+
+		assert.EqualError(t, rootCmd.Execute(), "unsupported shell 'tcsh'; use one of bash, zsh, fish or powershell")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	getCmd "github.com/OctopusDeploy/cli/pkg/cmd/config/get"
 	listCmd "github.com/OctopusDeploy/cli/pkg/cmd/config/list"
 	setCmd "github.com/OctopusDeploy/cli/pkg/cmd/config/set"
+	viewCmd "github.com/OctopusDeploy/cli/pkg/cmd/config/view"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/spf13/cobra"
@@ -16,11 +17,13 @@ func NewCmdConfig(f factory.Factory) *cobra.Command {
 		Long:  "Manage the CLI configuration",
 		Annotations: map[string]string{
 			annotations.IsConfiguration: "true",
+			annotations.IsSpaceless:     "true",
 		},
 	}
 
 	cmd.AddCommand(getCmd.NewCmdGet(f))
 	cmd.AddCommand(setCmd.NewCmdSet(f))
 	cmd.AddCommand(listCmd.NewCmdList(f))
+	cmd.AddCommand(viewCmd.NewCmdView(f))
 	return cmd
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/config"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -18,7 +19,18 @@ func NewCmdSet(f factory.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set [key] [value]",
 		Short: "Set will write the value for given key to Octopus CLI config file",
-		RunE: func(_ *cobra.Command, args []string) error {
+		Long: heredoc.Doc(`
+			Set will write the value for given key to Octopus CLI config file.
+
+			With --profile, the value is written to that instance profile in config.yaml instead; the keys
+			a profile can hold are host, api-key and space.
+		`),
+		Example: heredoc.Docf(`
+			$ %[1]s config set Space Default
+			$ %[1]s config set host https://octopus.example.com --profile production
+			$ %[1]s config set api-key API-XXXXXXXX --profile production
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
 			key := ""
 			value := ""
 			if len(args) > 0 {
@@ -27,6 +39,9 @@ func NewCmdSet(f factory.Factory) *cobra.Command {
 					value = args[1]
 				}
 			}
+			if profile, _ := c.Flags().GetString(constants.FlagProfile); profile != "" {
+				return setProfileRun(f.IsPromptEnabled(), f.Ask, profile, key, value)
+			}
 			return setRun(f.IsPromptEnabled(), f.Ask, key, value)
 
 		},
@@ -43,6 +58,7 @@ func setRun(isPromptEnabled bool, ask question.Asker, key string, value string)
 
 	localViper := viper.New()
 	config.SetupConfigFile(localViper, configPath)
+	localViper.SetConfigPermissions(config.SecretFilePermissions)
 
 	if err := localViper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -78,7 +94,37 @@ func setRun(isPromptEnabled bool, ask question.Asker, key string, value string)
 	if err := localViper.WriteConfig(); err != nil {
 		return err
 	}
-	return nil
+	return config.RestrictPermissions(localViper.ConfigFileUsed())
+}
+
+func setProfileRun(isPromptEnabled bool, ask question.Asker, profile string, key string, value string) error {
+	if key == "" {
+		if !isPromptEnabled {
+			return fmt.Errorf("a key is required; use host, api-key or space")
+		}
+		if err := ask(&survey.Select{
+			Options: []string{config.ProfileKeyUrl, config.ProfileKeyApiKey, config.ProfileKeySpace},
+			Message: fmt.Sprintf("What key would you like to change in profile %s?", profile),
+		}, &key); err != nil {
+			return err
+		}
+	}
+	profileKey, err := config.ProfileKey(key)
+	if err != nil {
+		return err
+	}
+	if isPromptEnabled && value == "" {
+		message := fmt.Sprintf("Enter the new value for %s", key)
+		var prompt survey.Prompt = &survey.Input{Message: message}
+		if profileKey == config.ProfileKeyApiKey {
+			prompt = &survey.Password{Message: message}
+		}
+		if err := ask(prompt, &value); err != nil {
+			return err
+		}
+		value = strings.TrimSpace(value)
+	}
+	return config.SetProfileValue(profile, key, value)
 }
 
 func promptMissing(ask question.Asker, key string) (string, string, error) {
@@ -0,0 +1,184 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/config"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const FlagShowSecrets = "show-secrets"
+
+const maskedValue = "***"
+
+// secretKeys are the config file keys whose values are masked unless --show-secrets is given
+var secretKeys = []string{strings.ToLower(constants.ConfigApiKey), strings.ToLower(constants.ConfigAccessToken)}
+
+type ViewFlags struct {
+	ShowSecrets *flag.Flag[bool]
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		ShowSecrets: flag.New[bool](FlagShowSecrets, false),
+	}
+}
+
+type ViewOptions struct {
+	Out          io.Writer
+	OutputFormat string
+	ConfigFile   string                     // the CLI config file; empty if there isn't one
+	Profiles     map[string]*config.Profile // keyed by lower-cased profile name
+	ProfileName  string                     // only show this profile
+	ShowSecrets  bool
+}
+
+type ConfigAsJson struct {
+	Config   map[string]string          `json:"Config"`
+	Profiles map[string]*config.Profile `json:"Profiles"`
+}
+
+func NewCmdView(_ factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "View the CLI configuration and instance profiles",
+		Long:  "View the values in the CLI config file and the instance profiles in config.yaml. API keys and access tokens are masked unless --show-secrets is given.",
+		Example: heredoc.Docf(`
+			$ %[1]s config view
+			$ %[1]s config view --profile production --show-secrets
+			$ %[1]s config view --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			profiles, err := config.LoadProfiles()
+			if err != nil {
+				return err
+			}
+			profileName, _ := c.Flags().GetString(constants.FlagProfile)
+			return ViewRun(&ViewOptions{
+				Out:          c.OutOrStdout(),
+				OutputFormat: output.GetOutputFormat(c),
+				ConfigFile:   viper.ConfigFileUsed(),
+				Profiles:     profiles,
+				ProfileName:  profileName,
+				ShowSecrets:  viewFlags.ShowSecrets.Value,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&viewFlags.ShowSecrets.Value, viewFlags.ShowSecrets.Name, false, "Show API keys and access tokens instead of masking them")
+	return cmd
+}
+
+func ViewRun(opts *ViewOptions) error {
+	if opts.ProfileName != "" {
+		profile, ok := opts.Profiles[strings.ToLower(opts.ProfileName)]
+		if !ok || profile == nil {
+			return fmt.Errorf("cannot find profile '%s'", opts.ProfileName)
+		}
+		masked := maskProfile(profile, opts.ShowSecrets)
+		if opts.OutputFormat == constants.OutputFormatJson {
+			return printJson(opts.Out, masked)
+		}
+		return printProfiles(opts.Out, map[string]*config.Profile{strings.ToLower(opts.ProfileName): masked})
+	}
+
+	configValues, err := readConfigFile(opts.ConfigFile)
+	if err != nil {
+		return err
+	}
+	if !opts.ShowSecrets {
+		for _, key := range secretKeys {
+			if configValues[key] != "" {
+				configValues[key] = maskedValue
+			}
+		}
+	}
+	profiles := map[string]*config.Profile{}
+	for name, profile := range opts.Profiles {
+		if profile != nil {
+			profiles[name] = maskProfile(profile, opts.ShowSecrets)
+		}
+	}
+
+	if opts.OutputFormat == constants.OutputFormatJson {
+		return printJson(opts.Out, &ConfigAsJson{Config: configValues, Profiles: profiles})
+	}
+
+	t := output.NewTable(opts.Out)
+	t.AddRow(output.Bold("KEY"), output.Bold("VALUE"))
+	for _, key := range sortedKeys(configValues) {
+		t.AddRow(key, configValues[key])
+	}
+	if err := t.Print(); err != nil {
+		return err
+	}
+	if len(profiles) > 0 {
+		fmt.Fprintln(opts.Out)
+		return printProfiles(opts.Out, profiles)
+	}
+	return nil
+}
+
+func maskProfile(profile *config.Profile, showSecrets bool) *config.Profile {
+	masked := *profile
+	if !showSecrets && masked.ApiKey != "" {
+		masked.ApiKey = maskedValue
+	}
+	return &masked
+}
+
+// readConfigFile returns every value in the CLI config file, without anything from flags or the environment
+func readConfigFile(configFile string) (map[string]string, error) {
+	values := map[string]string{}
+	if configFile == "" {
+		return values, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", configFile, err)
+	}
+	for _, key := range v.AllKeys() {
+		values[key] = v.GetString(key)
+	}
+	return values, nil
+}
+
+func printProfiles(out io.Writer, profiles map[string]*config.Profile) error {
+	t := output.NewTable(out)
+	t.AddRow(output.Bold("PROFILE"), output.Bold("HOST"), output.Bold("API KEY"), output.Bold("SPACE"))
+	for _, name := range sortedKeys(profiles) {
+		profile := profiles[name]
+		t.AddRow(name, profile.Url, profile.ApiKey, profile.Space)
+	}
+	return t.Print()
+}
+
+func printJson(out io.Writer, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,83 @@
+package view_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd/config/view"
+	"github.com/OctopusDeploy/cli/pkg/config"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigView(t *testing.T) {
+	output.IsColorEnabled = false
+	defer func() { output.IsColorEnabled = true }()
+
+	configFile := filepath.Join(t.TempDir(), "cli_config.json")
+	err := os.WriteFile(configFile, []byte(`{"Url": "https://octopus.example.com", "ApiKey": "API-SECRET", "Space": "Default"}`), 0600)
+	assert.Nil(t, err)
+	profiles := map[string]*config.Profile{
+		"production": {Url: "https://prod.example.com", ApiKey: "API-PROD", Space: "Default"},
+		"local":      {Url: "http://localhost:8065"},
+	}
+
+	newOptions := func(out *bytes.Buffer) *view.ViewOptions {
+		return &view.ViewOptions{Out: out, OutputFormat: constants.OutputFormatTable, ConfigFile: configFile, Profiles: profiles}
+	}
+
+	t.Run("masks api keys by default", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.Nil(t, view.ViewRun(newOptions(out)))
+		assert.Equal(t, "KEY     VALUE\n"+
+			"apikey  ***\n"+
+			"space   Default\n"+
+			"url     https://octopus.example.com\n"+
+			"\n"+
+			"PROFILE     HOST                      API KEY  SPACE\n"+
+			"local       http://localhost:8065              \n"+
+			"production  https://prod.example.com  ***      Default\n", out.String())
+		assert.NotContains(t, out.String(), "API-")
+	})
+
+	t.Run("reveals api keys with --show-secrets", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		opts := newOptions(out)
+		opts.ShowSecrets = true
+		assert.Nil(t, view.ViewRun(opts))
+		assert.Contains(t, out.String(), "apikey  API-SECRET\n")
+		assert.Contains(t, out.String(), "production  https://prod.example.com  API-PROD  Default\n")
+	})
+
+	t.Run("prints json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		opts := newOptions(out)
+		opts.OutputFormat = constants.OutputFormatJson
+		assert.Nil(t, view.ViewRun(opts))
+		parsed, err := testutil.ParseJsonStrict[view.ConfigAsJson](out)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"apikey": "***", "space": "Default", "url": "https://octopus.example.com"}, parsed.Config)
+		assert.Equal(t, &config.Profile{Url: "https://prod.example.com", ApiKey: "***", Space: "Default"}, parsed.Profiles["production"])
+	})
+
+	t.Run("shows a single profile", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		opts := newOptions(out)
+		opts.ProfileName = "Production"
+		opts.OutputFormat = constants.OutputFormatJson
+		assert.Nil(t, view.ViewRun(opts))
+		parsed, err := testutil.ParseJsonStrict[config.Profile](out)
+		assert.Nil(t, err)
+		assert.Equal(t, config.Profile{Url: "https://prod.example.com", ApiKey: "***", Space: "Default"}, parsed)
+	})
+
+	t.Run("errors for an unknown profile", func(t *testing.T) {
+		opts := newOptions(&bytes.Buffer{})
+		opts.ProfileName = "staging"
+		assert.EqualError(t, view.ViewRun(opts), "cannot find profile 'staging'")
+	})
+}
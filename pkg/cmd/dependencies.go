@@ -1,12 +1,16 @@
 package cmd
 
 import (
-	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"context"
 	"io"
 
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
 	"github.com/spf13/cobra"
 )
@@ -24,7 +28,24 @@ type Dependencies struct {
 	NoPrompt          bool
 	Ask               question.Asker
 	CmdPath           string
+	OutputFormat      string
 	ShowMessagePrefix bool
+
+	environments *environmentCache // shared with any dependencies made from these; nil until first needed
+}
+
+type environmentCache struct {
+	all []*environments.Environment // nil until GetAllEnvironments first fetches them
+}
+
+type sharedDependenciesKey struct{}
+
+// WithSharedDependencies returns ctx carrying dependencies. Commands run with it share their lookups, such as the
+// space's environments, so a command which runs another for each of many rows (like account import) only loads
+// them once.
+func WithSharedDependencies(ctx context.Context, dependencies *Dependencies) context.Context {
+	dependencies.environmentCache()
+	return context.WithValue(ctx, sharedDependenciesKey{}, dependencies)
 }
 
 func NewDependencies(f factory.Factory, cmd *cobra.Command) *Dependencies {
@@ -45,15 +66,22 @@ func NewSystemDependencies(f factory.Factory, cmd *cobra.Command) *Dependencies
 }
 
 func newDependencies(f factory.Factory, cmd *cobra.Command, client *client.Client) *Dependencies {
-	return &Dependencies{
-		Ask:      f.Ask,
-		CmdPath:  cmd.CommandPath(),
-		Out:      cmd.OutOrStdout(),
-		Client:   client,
-		Host:     f.GetCurrentHost(),
-		NoPrompt: !f.IsPromptEnabled(),
-		Space:    f.GetCurrentSpace(),
+	dependencies := &Dependencies{
+		Ask:          f.Ask,
+		CmdPath:      cmd.CommandPath(),
+		Out:          cmd.OutOrStdout(),
+		Client:       client,
+		Host:         f.GetCurrentHost(),
+		NoPrompt:     !f.IsPromptEnabled(),
+		Space:        f.GetCurrentSpace(),
+		OutputFormat: output.GetOutputFormat(cmd),
 	}
+	if ctx := cmd.Context(); ctx != nil {
+		if shared, ok := ctx.Value(sharedDependenciesKey{}).(*Dependencies); ok && shared.Client == client {
+			dependencies.environments = shared.environments
+		}
+	}
+	return dependencies
 }
 
 func NewDependenciesFromExisting(opts *Dependencies, cmdPath string) *Dependencies {
@@ -65,6 +93,37 @@ func NewDependenciesFromExisting(opts *Dependencies, cmdPath string) *Dependenci
 		Host:              opts.Host,
 		NoPrompt:          opts.NoPrompt,
 		Space:             opts.Space,
+		OutputFormat:      opts.OutputFormat,
 		ShowMessagePrefix: true,
+		environments:      opts.environmentCache(),
+	}
+}
+
+// GetAllEnvironments returns every environment in the space. Only the first call goes to the server; later calls
+// in the same command run get the same list, so prompts and name lookups don't each fetch it.
+func (d *Dependencies) GetAllEnvironments() ([]*environments.Environment, error) {
+	cache := d.environmentCache()
+	if cache.all == nil {
+		allEnvironments, err := d.Client.Environments.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		cache.all = allEnvironments
+	}
+	return cache.all, nil
+}
+
+// AddEnvironment adds an environment the command created to the list GetAllEnvironments returns, if it has
+// already been fetched, so that anything sharing the list finds it too
+func (d *Dependencies) AddEnvironment(environment *environments.Environment) {
+	if cache := d.environmentCache(); cache.all != nil {
+		cache.all = append(cache.all, environment)
+	}
+}
+
+func (d *Dependencies) environmentCache() *environmentCache {
+	if d.environments == nil {
+		d.environments = &environmentCache{}
 	}
+	return d.environments
 }
@@ -0,0 +1,146 @@
+package create
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/surveyext"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/spf13/cobra"
+)
+
+type CreateFlags struct {
+	Name        *flag.Flag[string]
+	Description *flag.Flag[string]
+}
+
+type CreateOptions struct {
+	*CreateFlags
+	*cmd.Dependencies
+}
+
+func NewCreateFlags() *CreateFlags {
+	return &CreateFlags{
+		Name:        flag.New[string]("name", false),
+		Description: flag.New[string]("description", false),
+	}
+}
+
+func NewCreateOptions(flags *CreateFlags, dependencies *cmd.Dependencies) *CreateOptions {
+	return &CreateOptions{
+		CreateFlags:  flags,
+		Dependencies: dependencies,
+	}
+}
+
+func NewCmdCreate(f factory.Factory) *cobra.Command {
+	createFlags := NewCreateFlags()
+	descriptionFilePath := ""
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create an environment",
+		Long:    "Create an environment in Octopus Deploy",
+		Example: heredoc.Docf("$ %s environment create", constants.ExecutableName),
+		Aliases: []string{"new"},
+		RunE: func(c *cobra.Command, _ []string) error {
+			opts := NewCreateOptions(createFlags, cmd.NewDependencies(f, c))
+			if descriptionFilePath != "" {
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
+				if err != nil {
+					return err
+				}
+				opts.Description.Value = string(data)
+			}
+			return CreateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&createFlags.Name.Value, createFlags.Name.Name, "n", "", "A short, memorable, unique name for this environment.")
+	flags.StringVarP(&createFlags.Description.Value, createFlags.Description.Name, "d", "", "A summary explaining the use of the environment to other users.")
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+
+	return cmd
+}
+
+func CreateRun(opts *CreateOptions) error {
+	if !opts.NoPrompt {
+		if err := PromptMissing(opts); err != nil {
+			return err
+		}
+	}
+	if err := ValidateFlags(opts); err != nil {
+		return err
+	}
+	environment := environments.NewEnvironment(opts.Name.Value)
+	environment.Description = opts.Description.Value
+
+	createdEnvironment, err := opts.Client.Environments.Add(environment)
+	if err != nil {
+		return err
+	}
+
+	err = output.Successf(opts.Out, createdEnvironment.GetID(), "Successfully created environment %s %s.\n", createdEnvironment.Name, output.Dimf("(%s)", createdEnvironment.GetID()))
+	if err != nil {
+		return err
+	}
+	if output.IsQuiet {
+		return nil
+	}
+	link := output.Bluef("%s/app#/%s/infrastructure/environments/%s", opts.Host, opts.Space.GetID(), createdEnvironment.GetID())
+	_, _ = fmt.Fprintf(opts.Out, "\nView this environment on Octopus Deploy: %s\n", link)
+	if !opts.NoPrompt {
+		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.Name, opts.Description)
+		_, _ = fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
+	}
+	return nil
+}
+
+// ValidateFlags checks the answers PromptMissing would have insisted on, for when it didn't get to ask
+func ValidateFlags(opts *CreateOptions) error {
+	if opts.Name.Value == "" {
+		return cliErrors.NewValidationError(opts.Name.Name, cliErrors.ValidationCodeRequired, "a name is required; use --name")
+	}
+	if len(opts.Name.Value) > 200 {
+		return cliErrors.NewValidationError(opts.Name.Name, cliErrors.ValidationCodeTooLong, "the name can't be longer than 200 characters")
+	}
+	return nil
+}
+
+func PromptMissing(opts *CreateOptions) error {
+	if opts.Name.Value == "" {
+		if err := opts.Ask(&survey.Input{
+			Message: "Name",
+			Help:    "A short, memorable, unique name for this environment.",
+		}, &opts.Name.Value, survey.WithValidator(survey.ComposeValidators(
+			survey.MaxLength(200),
+			survey.MinLength(1),
+			survey.Required,
+		))); err != nil {
+			return err
+		}
+	}
+
+	if opts.Description.Value == "" {
+		if err := opts.Ask(&surveyext.OctoEditor{
+			Editor: &survey.Editor{
+				Message:  "Description",
+				Help:     "A summary explaining the use of the environment to other users.",
+				FileName: "*.md",
+			},
+			Optional: true,
+		}, &opts.Description.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package create_test
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/create"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/surveyext"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func TestEnvironmentCreatePromptMissing(t *testing.T) {
+	space := fixtures.NewSpace("Spaces-1", "testspace")
+	api, qa := testutil.NewMockServerAndAsker()
+
+	opts := &create.CreateOptions{
+		CreateFlags:  create.NewCreateFlags(),
+		Dependencies: &cmd.Dependencies{Space: space},
+	}
+
+	errReceiver := testutil.GoBegin(func() error {
+		defer testutil.Close(api, qa)
+		opts.Ask = qa.AsAsker()
+		return create.PromptMissing(opts)
+	})
+
+	_ = qa.ExpectQuestion(t, &survey.Input{
+		Message: "Name",
+		Help:    "A short, memorable, unique name for this environment.",
+	}).AnswerWith("Staging")
+
+	_ = qa.ExpectQuestion(t, &surveyext.OctoEditor{
+		Editor: &survey.Editor{
+			Message:  "Description",
+			Help:     "A summary explaining the use of the environment to other users.",
+			FileName: "*.md",
+		},
+		Optional: true,
+	}).AnswerWith("the last stop before production")
+
+	err := <-errReceiver
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Staging", opts.Name.Value)
+	assert.Equal(t, "the last stop before production", opts.Description.Value)
+}
+
+func TestEnvironmentCreateNoPrompt(t *testing.T) {
+	const spaceID = "Spaces-1"
+	space := fixtures.NewSpace(spaceID, "testspace")
+	api, qa := testutil.NewMockServerAndAsker()
+	out := &bytes.Buffer{}
+
+	opts := &create.CreateOptions{
+		CreateFlags:  create.NewCreateFlags(),
+		Dependencies: &cmd.Dependencies{Space: space},
+	}
+	opts.Name.Value = "Staging"
+	opts.Description.Value = "the last stop before production"
+
+	errReceiver := testutil.GoBegin(func() error {
+		defer testutil.Close(api, qa)
+		octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+		opts.Ask = qa.AsAsker()
+		opts.Client = octopus
+		opts.Out = out
+		opts.NoPrompt = true
+		return create.CreateRun(opts)
+	})
+
+	createdEnvironment := fixtures.NewEnvironment(spaceID, "Environments-4", "Staging")
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	req := api.ExpectRequest(t, "POST", "/api/Spaces-1/environments")
+	requestBody, err := testutil.ReadJson[environments.Environment](req.Request.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "Staging", requestBody.Name)
+	assert.Equal(t, "the last stop before production", requestBody.Description)
+	req.RespondWithStatus(201, "201 Created", createdEnvironment)
+
+	err = <-errReceiver
+	assert.Nil(t, err)
+	assert.Equal(t, heredoc.Docf(`
+		Successfully created environment Staging %s.
+
+		View this environment on Octopus Deploy: %s
+	`,
+		output.Dimf("(%s)", "Environments-4"),
+		output.Bluef("%s/app#/%s/infrastructure/environments/%s", "", spaceID, "Environments-4"),
+	), out.String())
+}
+
+func TestEnvironmentCreateNoPromptValidatesName(t *testing.T) {
+	tests := []struct {
+		name string
+		err  string
+	}{
+		{"", "a name is required; use --name"},
+		{strings.Repeat("n", 201), "the name can't be longer than 200 characters"},
+	}
+
+	for _, test := range tests {
+		opts := create.NewCreateOptions(create.NewCreateFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: &bytes.Buffer{}, NoPrompt: true})
+		opts.Name.Value = test.name
+
+		// nothing is sent to the server, so there is no client
+		assert.EqualError(t, create.CreateRun(opts), test.err)
+	}
+}
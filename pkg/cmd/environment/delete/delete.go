@@ -2,96 +2,170 @@ package delete
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
 	"github.com/MakeNowJust/heredoc/v2"
-	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/hashicorp/go-multierror"
 	"github.com/spf13/cobra"
 )
 
+type DeleteOptions struct {
+	*cmd.Dependencies
+	*question.ConfirmFlags
+	IdsOrNames []string
+}
+
+func NewDeleteOptions(confirmFlags *question.ConfirmFlags, dependencies *cmd.Dependencies, idsOrNames []string) *DeleteOptions {
+	return &DeleteOptions{
+		Dependencies: dependencies,
+		ConfirmFlags: confirmFlags,
+		IdsOrNames:   idsOrNames,
+	}
+}
+
 func NewCmdDelete(f factory.Factory) *cobra.Command {
-	var skipConfirmation bool
+	confirmFlags := question.NewConfirmFlags()
 	cmd := &cobra.Command{
-		Use:     "delete {<name> | <id>}",
-		Short:   "Delete an environment",
-		Long:    "Delete an environment in Octopus Deploy",
+		Use:     "delete [{<name> | <id>}...]",
+		Short:   "Delete environments",
+		Long:    "Delete one or more environments in Octopus Deploy",
 		Aliases: []string{"del", "rm", "remove"},
 		Example: heredoc.Docf(`
 			$ %[1]s environment delete
-			$ %[1]s environment rm
+			$ %[1]s environment rm Staging
+			$ %[1]s environment delete Environments-3 --confirm
+			$ %[1]s environment delete "Test 1" "Test 2" Environments-7 --confirm
 		`, constants.ExecutableName),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
-				return deleteRun(f, cmd)
-			}
-
-			itemIDOrName := args[0]
-
-			client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
-			if err != nil {
-				return err
-			}
-
-			// SDK doesn't have accounts.GetByIDOrName so we emulate it here
-			foundEnvironments, err := client.Environments.Get(environments.EnvironmentsQuery{
-				// TODO we can't lookup by ID here because the server will AND it with the ItemName and produce no results
-				PartialName: itemIDOrName,
-			})
-			if err != nil {
-				return err
-			}
-			// need exact match
-			var itemToDelete *environments.Environment
-			for _, item := range foundEnvironments.Items {
-				if item.Name == itemIDOrName {
-					itemToDelete = item
-					break
-				}
-			}
-			if itemToDelete == nil {
-				return fmt.Errorf("cannot find an environment with name or ID of '%s'", itemIDOrName)
-			}
-
-			if !skipConfirmation { // TODO NO_PROMPT env var or whatever we do there
-				return question.DeleteWithConfirmation(f.Ask, "environment", itemToDelete.Name, itemToDelete.GetID(), func() error {
-					return delete(client, itemToDelete)
-				})
-			}
-
-			return delete(client, itemToDelete)
+		RunE: func(c *cobra.Command, args []string) error {
+			return DeleteRun(NewDeleteOptions(confirmFlags, cmd.NewDependencies(f, c), args))
 		},
 	}
 
-	question.RegisterConfirmDeletionFlag(cmd, &skipConfirmation, "environment")
+	question.RegisterConfirmDeletionFlag(cmd, &confirmFlags.Confirm.Value, "environment")
 
 	return cmd
 }
 
-func deleteRun(f factory.Factory, cmd *cobra.Command) error {
-	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
-	if err != nil {
-		return err
+// DeleteRun asks the user to type the environment's name before deleting it, unless --confirm was given.
+// When several environments are given, the user confirms them all at once, and then each is deleted in turn,
+// carrying on past any failures so that one bad name doesn't stop the rest from being cleaned up.
+// With prompting disabled there is nobody to ask, so --confirm is required.
+func DeleteRun(opts *DeleteOptions) error {
+	if opts.NoPrompt && !opts.Confirm.Value {
+		switch len(opts.IdsOrNames) {
+		case 0:
+			return fmt.Errorf("an environment name or ID is required when prompting is disabled")
+		case 1:
+			return fmt.Errorf("deleting the environment '%s' cannot be undone; pass --%s to delete it when prompting is disabled", opts.IdsOrNames[0], question.FlagConfirm)
+		default:
+			return fmt.Errorf("deleting the environments '%s' cannot be undone; pass --%s to delete them when prompting is disabled", strings.Join(opts.IdsOrNames, "', '"), question.FlagConfirm)
+		}
 	}
 
-	existingItems, err := client.Environments.GetAll()
-	if err != nil {
+	if len(opts.IdsOrNames) > 1 {
+		return deleteMany(opts)
+	}
+
+	var itemToDelete *environments.Environment
+	if len(opts.IdsOrNames) == 0 {
+		existingItems, err := opts.Client.Environments.GetAll()
+		if err != nil {
+			return err
+		}
+		if itemToDelete, err = selectors.ByName(opts.Ask, existingItems, "Select the environment you wish to delete:"); err != nil {
+			return err
+		}
+	} else {
+		environment, err := shared.GetEnvironment(opts.Client, opts.IdsOrNames[0])
+		if err != nil {
+			return err
+		}
+		itemToDelete = environment
+	}
+
+	if !opts.Confirm.Value {
+		return question.DeleteWithConfirmation(opts.Ask, "environment", itemToDelete.Name, itemToDelete.GetID(), func() error {
+			return delete(opts, itemToDelete)
+		})
+	}
+
+	if err := delete(opts, itemToDelete); err != nil {
 		return err
 	}
+	return output.Successf(opts.Out, itemToDelete.GetID(), "%s The environment, \"%s\" %s was deleted successfully.\n", output.Red("✔"), itemToDelete.Name, output.Dimf("(%s)", itemToDelete.GetID()))
+}
 
-	itemToDelete, err := selectors.ByName(f.Ask, existingItems, "Select the environment you wish to delete:")
+func deleteMany(opts *DeleteOptions) error {
+	allEnvironments, err := opts.Client.Environments.GetAll()
 	if err != nil {
 		return err
 	}
 
-	return question.DeleteWithConfirmation(f.Ask, "environment", itemToDelete.Name, itemToDelete.GetID(), func() error {
-		return delete(client, itemToDelete)
-	})
+	deleteErrors := &multierror.Error{}
+	var itemsToDelete []*environments.Environment
+	for _, idOrName := range opts.IdsOrNames {
+		environment := shared.FindEnvironment(allEnvironments, idOrName)
+		if environment == nil {
+			notFound := fmt.Errorf("cannot find an environment with name or ID of '%s'", idOrName)
+			deleteErrors = multierror.Append(deleteErrors, notFound)
+			fmt.Fprintf(opts.Out, "%s %s\n", output.Red("✘"), notFound)
+			continue
+		}
+		itemsToDelete = append(itemsToDelete, environment)
+	}
+
+	if !opts.Confirm.Value && len(itemsToDelete) > 0 {
+		fmt.Fprintf(opts.Out, "You are about to delete the following environments:\n")
+		for _, environment := range itemsToDelete {
+			fmt.Fprintf(opts.Out, "%s %s\n", environment.Name, output.Dimf("(%s)", environment.GetID()))
+		}
+		var isConfirmed bool
+		if err := opts.Ask(&survey.Confirm{
+			Message: fmt.Sprintf("Confirm delete of %d environment(s). This action cannot be reversed", len(itemsToDelete)),
+			Default: false,
+		}, &isConfirmed); err != nil {
+			return err
+		}
+		if !isConfirmed {
+			return deleteErrors.ErrorOrNil()
+		}
+	}
+
+	deletedCount := 0
+	for _, environment := range itemsToDelete {
+		if err := delete(opts, environment); err != nil {
+			failed := fmt.Errorf("failed to delete environment %s: %s", environment.Name, err)
+			deleteErrors = multierror.Append(deleteErrors, failed)
+			fmt.Fprintf(opts.Out, "%s %s\n", output.Red("✘"), failed)
+			continue
+		}
+		deletedCount++
+		_ = output.Successf(opts.Out, environment.GetID(), "%s %s %s\n", output.Green("✔"), environment.Name, output.Dimf("(%s)", environment.GetID()))
+	}
+
+	failedCount := deleteErrors.Len()
+	if failedCount == 0 {
+		if !output.IsQuiet {
+			fmt.Fprintf(opts.Out, "Successfully deleted %d environments\n", deletedCount)
+		}
+	} else if deletedCount == 0 {
+		fmt.Fprintf(opts.Out, "Failed to delete %d environments\n", failedCount)
+	} else {
+		fmt.Fprintf(opts.Out, "Deleted %d environments. %d environments failed\n", deletedCount, failedCount)
+	}
+	return deleteErrors.ErrorOrNil()
 }
 
-func delete(client *client.Client, itemToDelete *environments.Environment) error {
-	return client.Environments.DeleteByID(itemToDelete.GetID())
+func delete(opts *DeleteOptions, itemToDelete *environments.Environment) error {
+	return opts.Client.Environments.DeleteByID(itemToDelete.GetID())
 }
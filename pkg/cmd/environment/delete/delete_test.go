@@ -0,0 +1,218 @@
+package delete_test
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/delete"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func TestEnvironmentDelete(t *testing.T) {
+	dev := fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev")
+	staging := fixtures.NewEnvironment("Spaces-1", "Environments-2", "Staging")
+	allEnvironments := []*environments.Environment{dev, staging}
+
+	confirmationPrompt := &survey.Input{
+		Message: `You are about to delete the environment "Staging" ` + output.Dimf("(%s)", "Environments-2") + `. This action cannot be reversed. To confirm, type the environment name:`,
+	}
+
+	tests := []struct {
+		name     string
+		noPrompt bool
+		confirm  bool
+		run      func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer)
+	}{
+		{"deletes once the user types the name", false, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			_ = qa.ExpectQuestion(t, confirmationPrompt).AnswerWith("Staging")
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+		}},
+		{"doesn't delete when the name doesn't match", false, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			_ = qa.ExpectQuestion(t, confirmationPrompt).AnswerWith("Dev")
+
+			assert.EqualError(t, <-errReceiver, "input value Dev does match expected value Staging")
+		}},
+		{"--confirm skips the prompt", false, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+			assert.Contains(t, out.String(), `The environment, "Staging"`)
+		}},
+		{"prints only the ID under --quiet", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			output.IsQuiet = true
+			defer func() { output.IsQuiet = false }()
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+			assert.Equal(t, "Environments-2\n", out.String())
+		}},
+		{"requires --confirm when prompting is disabled", true, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+
+			assert.EqualError(t, <-errReceiver, "deleting the environment 'staging' cannot be undone; pass --confirm to delete it when prompting is disabled")
+		}},
+		{"deletes with --confirm when prompting is disabled", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api, qa := testutil.NewMockServerAndAsker()
+			out := &bytes.Buffer{}
+			opts := delete.NewDeleteOptions(question.NewConfirmFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out, NoPrompt: test.noPrompt}, []string{"staging"})
+			opts.Confirm.Value = test.confirm
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer testutil.Close(api, qa)
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Ask = qa.AsAsker()
+				opts.Client = octopus
+				return delete.DeleteRun(opts)
+			})
+
+			test.run(t, api, qa, errReceiver, out)
+		})
+	}
+}
+
+func TestEnvironmentDeleteMany(t *testing.T) {
+	dev := fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev")
+	test1 := fixtures.NewEnvironment("Spaces-1", "Environments-2", "Test 1")
+	test2 := fixtures.NewEnvironment("Spaces-1", "Environments-3", "Test 2")
+	allEnvironments := []*environments.Environment{dev, test1, test2}
+
+	tests := []struct {
+		name     string
+		noPrompt bool
+		confirm  bool
+		run      func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer)
+	}{
+		{"deletes each environment once confirmed", false, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			_ = qa.ExpectQuestion(t, &survey.Confirm{Message: "Confirm delete of 2 environment(s). This action cannot be reversed"}).AnswerWith(true)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWith(nil)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-3").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+			assert.Contains(t, out.String(), "Successfully deleted 2 environments\n")
+		}},
+		{"prints only the deleted IDs under --quiet", false, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			output.IsQuiet = true
+			defer func() { output.IsQuiet = false }()
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			_ = qa.ExpectQuestion(t, &survey.Confirm{Message: "Confirm delete of 2 environment(s). This action cannot be reversed"}).AnswerWith(true)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWith(nil)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-3").RespondWith(nil)
+
+			assert.Nil(t, <-errReceiver)
+			assert.True(t, strings.HasSuffix(out.String(), "\nEnvironments-2\nEnvironments-3\n"))
+			assert.NotContains(t, out.String(), "Successfully deleted")
+		}},
+		{"requires --confirm when prompting is disabled", true, false, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+
+			assert.EqualError(t, <-errReceiver, "deleting the environments 'test 1', 'Environments-3' cannot be undone; pass --confirm to delete them when prompting is disabled")
+		}},
+		{"carries on past failures and reports them all", true, true, func(t *testing.T, api *testutil.MockHttpServer, qa *testutil.AskMocker, errReceiver chan error, out *bytes.Buffer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-2").RespondWithStatus(400, "400 Bad Request", map[string]string{"ErrorMessage": "environment is in use"})
+			api.ExpectRequest(t, "DELETE", "/api/Spaces-1/environments/Environments-3").RespondWith(nil)
+
+			err := <-errReceiver
+			assert.NotNil(t, err)
+			merr, ok := err.(*multierror.Error)
+			assert.True(t, ok)
+			assert.Equal(t, 2, merr.Len())
+			assert.EqualError(t, merr.Errors[0], "cannot find an environment with name or ID of 'Test 9'")
+			assert.Contains(t, merr.Errors[1].Error(), "failed to delete environment Test 1: ")
+			assert.Equal(t, heredoc.Docf(`
+				%[1]s cannot find an environment with name or ID of 'Test 9'
+				%[1]s %[2]s
+				%[3]s Test 2 %[4]s
+				Deleted 1 environments. 2 environments failed
+			`, output.Red("✘"), merr.Errors[1].Error(), output.Green("✔"), output.Dimf("(%s)", "Environments-3")), out.String())
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api, qa := testutil.NewMockServerAndAsker()
+			out := &bytes.Buffer{}
+			names := []string{"test 1", "Environments-3"}
+			if test.confirm {
+				names = []string{"Test 9", "test 1", "Environments-3"}
+			}
+			opts := delete.NewDeleteOptions(question.NewConfirmFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out, NoPrompt: test.noPrompt}, names)
+			opts.Confirm.Value = test.confirm
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer testutil.Close(api, qa)
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Ask = qa.AsAsker()
+				opts.Client = octopus
+				return delete.DeleteRun(opts)
+			})
+
+			test.run(t, api, qa, errReceiver, out)
+		})
+	}
+	t.Run("still reports names it couldn't find when the delete is declined", func(t *testing.T) {
+		api, qa := testutil.NewMockServerAndAsker()
+		out := &bytes.Buffer{}
+		opts := delete.NewDeleteOptions(question.NewConfirmFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out}, []string{"Test 9", "test 1"})
+
+		errReceiver := testutil.GoBegin(func() error {
+			defer testutil.Close(api, qa)
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+			opts.Ask = qa.AsAsker()
+			opts.Client = octopus
+			return delete.DeleteRun(opts)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+		_ = qa.ExpectQuestion(t, &survey.Confirm{Message: "Confirm delete of 1 environment(s). This action cannot be reversed"}).AnswerWith(false)
+
+		err := <-errReceiver
+		merr, ok := err.(*multierror.Error)
+		assert.True(t, ok)
+		assert.Equal(t, 1, merr.Len())
+		assert.EqualError(t, merr.Errors[0], "cannot find an environment with name or ID of 'Test 9'")
+	})
+}
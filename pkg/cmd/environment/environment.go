@@ -2,8 +2,12 @@ package environment
 
 import (
 	"github.com/MakeNowJust/heredoc/v2"
+	cmdCreate "github.com/OctopusDeploy/cli/pkg/cmd/environment/create"
 	cmdDelete "github.com/OctopusDeploy/cli/pkg/cmd/environment/delete"
 	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/environment/list"
+	cmdReferences "github.com/OctopusDeploy/cli/pkg/cmd/environment/references"
+	cmdUpdate "github.com/OctopusDeploy/cli/pkg/cmd/environment/update"
+	cmdView "github.com/OctopusDeploy/cli/pkg/cmd/environment/view"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
@@ -16,15 +20,22 @@ func NewCmdEnvironment(f factory.Factory) *cobra.Command {
 		Short: "Manage environments",
 		Long:  "Manage environments in Octopus Deploy",
 		Example: heredoc.Docf(`
+			$ %[1]s environment create
 			$ %[1]s environment list
 			$ %[1]s environment ls
+			$ %[1]s environment update Staging --sort-order 2
+			$ %[1]s environment view Production
 		`, constants.ExecutableName),
 		Annotations: map[string]string{
 			annotations.IsInfrastructure: "true",
 		},
 	}
 
+	cmd.AddCommand(cmdCreate.NewCmdCreate(f))
 	cmd.AddCommand(cmdList.NewCmdList(f))
+	cmd.AddCommand(cmdUpdate.NewCmdUpdate(f))
+	cmd.AddCommand(cmdView.NewCmdView(f))
+	cmd.AddCommand(cmdReferences.NewCmdReferences(f))
 	cmd.AddCommand(cmdDelete.NewCmdDelete(f))
 	return cmd
 }
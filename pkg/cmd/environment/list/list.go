@@ -1,59 +1,202 @@
 package list
 
 import (
-	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/spf13/cobra"
 )
 
+const (
+	FlagSort   = "sort"
+	FlagLimit  = "limit"
+	FlagFilter = "filter"
+
+	SortByName      = "name"
+	SortBySortOrder = "sort-order"
+
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+type ListFlags struct {
+	Sort   *flag.Flag[string]
+	Limit  *flag.Flag[int32]
+	Filter *flag.Flag[string]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		Sort:   flag.New[string](FlagSort, false),
+		Limit:  flag.New[int32](FlagLimit, false),
+		Filter: flag.New[string](FlagFilter, false),
+	}
+}
+
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List environments",
 		Long:  "List environments in Octopus Deploy",
 		Example: heredoc.Docf(`
 			$ %[1]s environment list
-			$ %[1]s environment ls"
+			$ %[1]s environment ls
+			$ %[1]s environment list --sort name:desc --limit 10
+			$ %[1]s environment list --filter prod
+			$ %[1]s environment list --output-format csv > environments.csv
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
-			if err != nil {
-				return err
-			}
-
-			envResources, err := client.Environments.Get(environments.EnvironmentsQuery{})
-			if err != nil {
-				return err
-			}
-			allEnvs, err := envResources.GetAllPages(client.Environments.GetClient())
-			if err != nil {
-				return err
-			}
-
-			return output.PrintArray(allEnvs, cmd, output.Mappers[*environments.Environment]{
-				Json: func(item *environments.Environment) any {
-					return output.IdAndName{Id: item.GetID(), Name: item.Name}
-				},
-				Table: output.TableDefinition[*environments.Environment]{
-					Header: []string{"NAME", "GUIDED FAILURE"},
-					Row: func(item *environments.Environment) []string {
-
-						return []string{output.Bold(item.Name), strconv.FormatBool(item.UseGuidedFailure)}
-					},
-				},
-				Basic: func(item *environments.Environment) string {
-					return item.Name
-				},
-			})
+			return listRun(cmd, f, listFlags)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&listFlags.Sort.Value, listFlags.Sort.Name, "", fmt.Sprintf("sort by %[1]s or %[2]s, optionally followed by :%[3]s or :%[4]s, e.g. %[1]s:%[4]s", SortByName, SortBySortOrder, SortAscending, SortDescending))
+	flags.Int32Var(&listFlags.Limit.Value, listFlags.Limit.Name, 0, "limit the maximum number of results that will be returned")
+	flags.StringVar(&listFlags.Filter.Value, listFlags.Filter.Name, "", "only list environments whose name or description contains this text, ignoring case")
 	return cmd
 }
+
+func listRun(cmd *cobra.Command, f factory.Factory, flags *ListFlags) error {
+	// check the flags before going to the server, so a typo fails fast
+	less, err := sortFunc(flags.Sort.Value)
+	if err != nil {
+		return err
+	}
+
+	printer, err := output.NewArrayPrinter(cmd, output.Mappers[*environments.Environment]{
+		Json: func(item *environments.Environment) any {
+			return output.IdAndName{Id: item.GetID(), Name: item.Name}
+		},
+		Table: output.TableDefinition[*environments.Environment]{
+			Header: []string{"NAME", "GUIDED FAILURE"},
+			Row: func(item *environments.Environment) []string {
+
+				return []string{output.Bold(item.Name), strconv.FormatBool(item.UseGuidedFailure)}
+			},
+		},
+		Csv: output.TableDefinition[*environments.Environment]{
+			Header: []string{"Id", "Name", "Description", "SortOrder", "UseGuidedFailure"},
+			Row: func(item *environments.Environment) []string {
+				return []string{item.GetID(), item.Name, item.Description, strconv.Itoa(item.SortOrder), strconv.FormatBool(item.UseGuidedFailure)}
+			},
+		},
+		Basic: func(item *environments.Environment) string {
+			return item.Name
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
+	if err != nil {
+		return err
+	}
+	progress := apiclient.ProgressFor(client)
+	getPage := func(skip int, take int) (*resources.Resources[*environments.Environment], error) {
+		return client.Environments.Get(environments.EnvironmentsQuery{Skip: skip, Take: take})
+	}
+	// the server can only search names, not descriptions, so the filter is applied to each page as it arrives
+	filter := strings.ToLower(flags.Filter.Value)
+
+	// the server has no sort or limit for environments, so both are applied here, before any output format
+	// sees the list
+	limit := int(flags.Limit.Value)
+	if less != nil {
+		// sorting needs every environment before the first can be printed
+		var allEnvs []*environments.Environment
+		err = apiclient.ForEachPageWithProgress(apiclient.DefaultPageSize, progress, getPage, func(items []*environments.Environment) (bool, error) {
+			allEnvs = append(allEnvs, filterEnvironments(items, filter)...)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(allEnvs, func(i, j int) bool { return less(allEnvs[i], allEnvs[j]) })
+		if limit > 0 && len(allEnvs) > limit {
+			allEnvs = allEnvs[:limit]
+		}
+		if err := printer.Print(allEnvs); err != nil {
+			return err
+		}
+		return printer.Flush()
+	}
+
+	// otherwise each page is printed as it arrives, and we stop asking for more once we have enough
+	pageSize := apiclient.DefaultPageSize
+	if limit > 0 && limit < pageSize && filter == "" {
+		pageSize = limit
+	}
+	printed := 0
+	err = apiclient.ForEachPageWithProgress(pageSize, progress, getPage, func(items []*environments.Environment) (bool, error) {
+		items = filterEnvironments(items, filter)
+		if limit > 0 && printed+len(items) > limit {
+			items = items[:limit-printed]
+		}
+		printed += len(items)
+		return limit <= 0 || printed < limit, printer.Print(items)
+	})
+	if err != nil {
+		return err
+	}
+	return printer.Flush()
+}
+
+// filterEnvironments returns the environments whose name or description contains filter, which must be
+// lowercase. An empty filter matches everything
+func filterEnvironments(items []*environments.Environment, filter string) []*environments.Environment {
+	if filter == "" {
+		return items
+	}
+	matches := make([]*environments.Environment, 0, len(items))
+	for _, env := range items {
+		if strings.Contains(strings.ToLower(env.Name), filter) || strings.Contains(strings.ToLower(env.Description), filter) {
+			matches = append(matches, env)
+		}
+	}
+	return matches
+}
+
+// sortFunc parses the value of --sort, which is a field optionally followed by :asc or :desc, returning nil
+// if no sort was asked for
+func sortFunc(value string) (func(a, b *environments.Environment) bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	field, direction, _ := strings.Cut(strings.ToLower(value), ":")
+
+	var less func(a, b *environments.Environment) bool
+	switch field {
+	case SortByName:
+		less = func(a, b *environments.Environment) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case SortBySortOrder:
+		less = func(a, b *environments.Environment) bool { return a.SortOrder < b.SortOrder }
+	default:
+		return nil, fmt.Errorf("cannot sort environments by '%s'; use %s or %s", field, SortByName, SortBySortOrder)
+	}
+
+	switch direction {
+	case "", SortAscending:
+		return less, nil
+	case SortDescending:
+		return func(a, b *environments.Environment) bool { return less(b, a) }, nil
+	default:
+		return nil, fmt.Errorf("invalid sort direction '%s'; use %s or %s", direction, SortAscending, SortDescending)
+	}
+}
@@ -0,0 +1,143 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestEnvironmentList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+
+	newEnvironment := func(id string, name string, sortOrder int) *environments.Environment {
+		environment := fixtures.NewEnvironment("Spaces-1", id, name)
+		environment.SortOrder = sortOrder
+		return environment
+	}
+	environmentResources := &resources.Resources[*environments.Environment]{
+		Items: []*environments.Environment{
+			newEnvironment("Environments-1", "staging", 2),
+			newEnvironment("Environments-2", "Dev", 1),
+			newEnvironment("Environments-3", "Production", 3),
+		},
+	}
+	environmentResources.Items[2].Description = "Live, customer facing"
+	environmentResources.Items[2].UseGuidedFailure = true
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"lists in the order the server returns", []string{"-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "staging\nDev\nProduction\n", out.String())
+		}},
+		{"sorts by name", []string{"--sort", "name", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "Dev\nProduction\nstaging\n", out.String())
+		}},
+		{"sorts by sort order descending and limits the json output", []string{"--sort", "sort-order:desc", "--limit", "2", "-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			parsed, err := testutil.ParseJsonStrict[[]output.IdAndName](out)
+			assert.Nil(t, err)
+			assert.Equal(t, []output.IdAndName{{Id: "Environments-3", Name: "Production"}, {Id: "Environments-1", Name: "staging"}}, parsed)
+		}},
+		{"filters on name or description, ignoring case", []string{"--filter", "STAG", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "staging\n", out.String())
+		}},
+		{"filters json output on description", []string{"--filter", "customer", "-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			parsed, err := testutil.ParseJsonStrict[[]output.IdAndName](out)
+			assert.Nil(t, err)
+			assert.Equal(t, []output.IdAndName{{Id: "Environments-3", Name: "Production"}}, parsed)
+		}},
+		{"filters table output", []string{"--filter", "d", "--no-color", "-f", "table"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "NAME        GUIDED FAILURE\nDev         false\nProduction  true\n", out.String())
+		}},
+		{"writes csv", []string{"--sort", "name", "-f", "csv"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "Id,Name,Description,SortOrder,UseGuidedFailure\r\n"+
+				"Environments-2,Dev,,1,false\r\n"+
+				"Environments-3,Production,\"Live, customer facing\",3,true\r\n"+
+				"Environments-1,staging,,2,false\r\n", out.String())
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"environment", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?take=100").RespondWith(environmentResources)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+
+	t.Run("prints each page as it arrives, and stops fetching at the limit", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		api, _ := testutil.NewMockServerAndAsker()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+		rootCmd.SetOut(stdout)
+
+		cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+			defer api.Close()
+			rootCmd.SetArgs([]string{"environment", "list", "--no-prompt", "--limit", "3", "-f", "basic"})
+			return rootCmd.ExecuteC()
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?take=3").RespondWith(&resources.Resources[*environments.Environment]{
+			Items:        []*environments.Environment{newEnvironment("Environments-1", "Dev", 1), newEnvironment("Environments-2", "Test", 2)},
+			PagedResults: resources.PagedResults{TotalResults: 10},
+		})
+		secondPage := api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?skip=2&take=3")
+		// the first page is already out before the second is asked for
+		assert.Equal(t, "Dev\nTest\n", stdout.String())
+		secondPage.RespondWith(&resources.Resources[*environments.Environment]{
+			Items:        []*environments.Environment{newEnvironment("Environments-3", "Staging", 3), newEnvironment("Environments-4", "Production", 4)},
+			PagedResults: resources.PagedResults{TotalResults: 10},
+		})
+
+		_, err := testutil.ReceivePair(cmdReceiver)
+		assert.Nil(t, err)
+		assert.Equal(t, "Dev\nTest\nStaging\n", stdout.String())
+	})
+
+	t.Run("rejects an unknown sort field", func(t *testing.T) {
+		api, _ := testutil.NewMockServerAndAsker()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+		rootCmd.SetArgs([]string{"environment", "list", "--no-prompt", "--sort", "colour"})
+		_, err := rootCmd.ExecuteC()
+		assert.EqualError(t, err, "cannot sort environments by 'colour'; use name or sort-order")
+	})
+}
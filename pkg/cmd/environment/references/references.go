@@ -0,0 +1,105 @@
+package references
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/spf13/cobra"
+)
+
+type ReferencesOptions struct {
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewReferencesOptions(dependencies *cmd.Dependencies, idOrName string) *ReferencesOptions {
+	return &ReferencesOptions{
+		Dependencies: dependencies,
+		IdOrName:     idOrName,
+	}
+}
+
+type ReferencesAsJson struct {
+	Environment output.IdAndName `json:"Environment"`
+	*shared.References
+}
+
+func NewCmdReferences(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  usage.ExactArgs(1),
+		Use:   "references {<name> | <id>}",
+		Short: "List what refers to an environment",
+		Long: heredoc.Doc(`
+			List the lifecycle phases, deployment targets, accounts and tenants which refer to an environment in
+			Octopus Deploy, to see what would be affected by changing or deleting it.
+		`),
+		Aliases: []string{"refs"},
+		Example: heredoc.Docf(`
+			$ %[1]s environment references Staging
+			$ %[1]s environment refs Environments-3 --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			return ReferencesRun(NewReferencesOptions(cmd.NewDependencies(f, c), args[0]))
+		},
+	}
+
+	return cmd
+}
+
+func ReferencesRun(opts *ReferencesOptions) error {
+	environment, err := shared.GetEnvironment(opts.Client, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	references, err := shared.FindReferences(opts.Client, environment)
+	if err != nil {
+		return err
+	}
+
+	if opts.OutputFormat == constants.OutputFormatJson {
+		data, err := json.MarshalIndent(output.WrapJson(&ReferencesAsJson{
+			Environment: output.IdAndName{Id: environment.GetID(), Name: environment.Name},
+			References:  references,
+		}), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "%s %s\n", output.Bold(environment.Name), output.Dimf("(%s)", environment.GetID()))
+	if references.Count() == 0 {
+		_, err = fmt.Fprintln(opts.Out, "Nothing refers to this environment.")
+		return err
+	}
+
+	printGroup(opts, "Lifecycles", references.Lifecycles)
+	printGroup(opts, "Deployment targets", references.DeploymentTargets)
+	printGroup(opts, "Accounts", references.Accounts)
+	printGroup(opts, "Tenants", references.Tenants)
+	return nil
+}
+
+func printGroup(opts *ReferencesOptions, heading string, references []*shared.Reference) {
+	fmt.Fprintf(opts.Out, output.Cyan("\n%s:\n"), heading)
+	if len(references) == 0 {
+		fmt.Fprintln(opts.Out, output.Dim("None"))
+		return
+	}
+	for _, reference := range references {
+		if reference.Detail == "" {
+			fmt.Fprintf(opts.Out, "%s %s\n", reference.Name, output.Dimf("(%s)", reference.Id))
+		} else {
+			fmt.Fprintf(opts.Out, "%s %s - %s\n", reference.Name, output.Dimf("(%s)", reference.Id), reference.Detail)
+		}
+	}
+}
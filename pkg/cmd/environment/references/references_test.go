@@ -0,0 +1,119 @@
+package references_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/references"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func TestEnvironmentReferences(t *testing.T) {
+	dev := fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev")
+	staging := fixtures.NewEnvironment("Spaces-1", "Environments-2", "Staging")
+
+	lifecycle := lifecycles.NewLifecycle("Default Lifecycle")
+	lifecycle.ID = "Lifecycles-1"
+	devPhase := lifecycles.NewPhase("Development")
+	devPhase.AutomaticDeploymentTargets = []string{"Environments-1"}
+	testPhase := lifecycles.NewPhase("Testing")
+	testPhase.OptionalDeploymentTargets = []string{"Environments-2"}
+	lifecycle.Phases = []*lifecycles.Phase{devPhase, testPhase}
+	devOnlyLifecycle := lifecycles.NewLifecycle("Dev Only")
+	devOnlyLifecycle.ID = "Lifecycles-2"
+	devOnlyLifecycle.Phases = []*lifecycles.Phase{devPhase}
+
+	machine := machines.NewDeploymentTarget("web01", machines.NewListeningTentacleEndpoint(&url.URL{Scheme: "https", Host: "web01"}, "thumbprint"), []string{"Environments-2"}, []string{"web"})
+	machine.ID = "Machines-1"
+
+	stagingAccount, _ := accounts.NewTokenAccount("Staging Token", core.NewSensitiveValue(""))
+	stagingAccount.ID = "Accounts-1"
+	stagingAccount.EnvironmentIDs = []string{"Environments-2"}
+	unscopedAccount, _ := accounts.NewTokenAccount("Any Token", core.NewSensitiveValue(""))
+	unscopedAccount.ID = "Accounts-2"
+
+	tenant := fixtures.NewTenant("Spaces-1", "Tenants-1", "Acme")
+	tenant.ProjectEnvironments = map[string][]string{"Projects-1": {"Environments-1", "Environments-2"}, "Projects-2": {"Environments-1"}}
+	devTenant := fixtures.NewTenant("Spaces-1", "Tenants-2", "Initech")
+	devTenant.ProjectEnvironments = map[string][]string{"Projects-1": {"Environments-1"}}
+
+	expectRequests := func(api *testutil.MockHttpServer) {
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{dev, staging})
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/lifecycles/all").RespondWith([]*lifecycles.Lifecycle{lifecycle, devOnlyLifecycle})
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/machines?environmentIds=Environments-2").RespondWith(&resources.Resources[*machines.DeploymentTarget]{Items: []*machines.DeploymentTarget{machine}})
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/accounts/all").RespondWith([]accounts.IAccount{stagingAccount, unscopedAccount})
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/tenants/all").RespondWith([]*tenants.Tenant{tenant, devTenant})
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/all").RespondWith([]*projects.Project{
+			fixtures.NewProject("Spaces-1", "Projects-1", "Web Shop", "Lifecycles-1", "ProjectGroups-1", ""),
+		})
+	}
+
+	tests := []struct {
+		name         string
+		outputFormat string
+		verify       func(t *testing.T, out string)
+	}{
+		{"prints the references grouped by type", "", func(t *testing.T, out string) {
+			assert.Contains(t, out, "Staging (Environments-2)\n")
+			assert.Contains(t, out, "Default Lifecycle (Lifecycles-1) - phases: Testing\n")
+			assert.Contains(t, out, "web01 (Machines-1)\n")
+			assert.Contains(t, out, "Staging Token (Accounts-1)\n")
+			assert.Contains(t, out, "Acme (Tenants-1) - projects: Web Shop\n")
+			assert.NotContains(t, out, "Dev Only")
+			assert.NotContains(t, out, "Any Token")
+			assert.NotContains(t, out, "Initech")
+		}},
+		{"prints the references as json", constants.OutputFormatJson, func(t *testing.T, out string) {
+			var parsed map[string]any
+			assert.Nil(t, json.Unmarshal([]byte(out), &parsed))
+			assert.Equal(t, map[string]any{"Id": "Environments-2", "Name": "Staging"}, parsed["Environment"])
+			assert.Equal(t, []any{map[string]any{"Id": "Lifecycles-1", "Name": "Default Lifecycle", "Detail": "phases: Testing"}}, parsed["Lifecycles"])
+			assert.Equal(t, []any{map[string]any{"Id": "Machines-1", "Name": "web01"}}, parsed["DeploymentTargets"])
+			assert.Equal(t, []any{map[string]any{"Id": "Accounts-1", "Name": "Staging Token"}}, parsed["Accounts"])
+			assert.Equal(t, []any{map[string]any{"Id": "Tenants-1", "Name": "Acme", "Detail": "projects: Web Shop"}}, parsed["Tenants"])
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			out := &bytes.Buffer{}
+			opts := references.NewReferencesOptions(&cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out, OutputFormat: test.outputFormat}, "staging")
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer api.Close()
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Client = octopus
+				return references.ReferencesRun(opts)
+			})
+
+			expectRequests(api)
+
+			err := <-errReceiver
+			assert.Nil(t, err)
+			test.verify(t, out.String())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package shared
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+)
+
+// Reference is a single resource which refers to an environment. Detail says how, where that isn't obvious
+// from the type of resource, e.g. which lifecycle phases include the environment.
+type Reference struct {
+	Id     string `json:"Id"`
+	Name   string `json:"Name"`
+	Detail string `json:"Detail,omitempty"`
+}
+
+// References is everything we know how to find that refers to an environment, grouped by type of resource
+type References struct {
+	Lifecycles        []*Reference `json:"Lifecycles"`
+	DeploymentTargets []*Reference `json:"DeploymentTargets"`
+	Accounts          []*Reference `json:"Accounts"`
+	Tenants           []*Reference `json:"Tenants"`
+}
+
+// Count is the total number of references across all resource types
+func (r *References) Count() int {
+	return len(r.Lifecycles) + len(r.DeploymentTargets) + len(r.Accounts) + len(r.Tenants)
+}
+
+// FindReferences finds the lifecycle phases, deployment targets, accounts and tenants which refer to the environment.
+// The server can't answer this in one request, so this makes one per type of resource, plus one for project names
+// if any tenant is connected to the environment.
+func FindReferences(octopus *client.Client, environment *environments.Environment) (*References, error) {
+	environmentID := environment.GetID()
+	references := &References{
+		Lifecycles:        []*Reference{},
+		DeploymentTargets: []*Reference{},
+		Accounts:          []*Reference{},
+		Tenants:           []*Reference{},
+	}
+
+	allLifecycles, err := octopus.Lifecycles.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, lifecycle := range allLifecycles {
+		phases := util.SliceFilter(lifecycle.Phases, func(phase *lifecycles.Phase) bool {
+			return util.SliceContains(phase.AutomaticDeploymentTargets, environmentID) || util.SliceContains(phase.OptionalDeploymentTargets, environmentID)
+		})
+		if len(phases) > 0 {
+			phaseNames := util.SliceTransform(phases, func(phase *lifecycles.Phase) string { return phase.Name })
+			references.Lifecycles = append(references.Lifecycles, &Reference{Id: lifecycle.GetID(), Name: lifecycle.Name, Detail: "phases: " + strings.Join(phaseNames, ", ")})
+		}
+	}
+
+	machineResources, err := octopus.Machines.Get(machines.MachinesQuery{EnvironmentIDs: []string{environmentID}})
+	if err != nil {
+		return nil, err
+	}
+	environmentMachines, err := machineResources.GetAllPages(octopus.Machines.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	for _, machine := range environmentMachines {
+		references.DeploymentTargets = append(references.DeploymentTargets, &Reference{Id: machine.GetID(), Name: machine.Name})
+	}
+
+	allAccounts, err := octopus.Accounts.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range allAccounts {
+		if util.SliceContains(account.GetEnvironmentIDs(), environmentID) {
+			references.Accounts = append(references.Accounts, &Reference{Id: account.GetID(), Name: account.GetName()})
+		}
+	}
+
+	allTenants, err := octopus.Tenants.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	tenantProjectIDs := map[string][]string{}
+	for _, tenant := range allTenants {
+		for projectID, environmentIDs := range tenant.ProjectEnvironments {
+			if util.SliceContains(environmentIDs, environmentID) {
+				tenantProjectIDs[tenant.GetID()] = append(tenantProjectIDs[tenant.GetID()], projectID)
+			}
+		}
+	}
+	if len(tenantProjectIDs) > 0 {
+		allProjects, err := octopus.Projects.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		projectNames := make(map[string]string, len(allProjects))
+		for _, project := range allProjects {
+			projectNames[project.GetID()] = project.Name
+		}
+		for _, tenant := range allTenants {
+			projectIDs, ok := tenantProjectIDs[tenant.GetID()]
+			if !ok {
+				continue
+			}
+			names := util.SliceTransform(projectIDs, func(projectID string) string {
+				if name, ok := projectNames[projectID]; ok {
+					return name
+				}
+				return projectID
+			})
+			// ProjectEnvironments is a map, so put the projects in an order that doesn't change from run to run
+			sort.Strings(names)
+			references.Tenants = append(references.Tenants, &Reference{Id: tenant.GetID(), Name: tenant.Name, Detail: "projects: " + strings.Join(names, ", ")})
+		}
+	}
+
+	return references, nil
+}
@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+)
+
+// FindEnvironment prefers a match on the name, and then falls back to the ID, the same as we do for spaces
+func FindEnvironment(allEnvironments []*environments.Environment, idOrName string) *environments.Environment {
+	var foundByID *environments.Environment
+	for _, environment := range allEnvironments {
+		if strings.EqualFold(environment.Name, idOrName) {
+			return environment
+		}
+		if strings.EqualFold(environment.GetID(), idOrName) {
+			foundByID = environment
+		}
+	}
+	return foundByID
+}
+
+// GetEnvironment looks up a single environment by name or ID, returning an error if there is no match
+func GetEnvironment(octopus *client.Client, idOrName string) (*environments.Environment, error) {
+	allEnvironments, err := octopus.Environments.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	environment := FindEnvironment(allEnvironments, idOrName)
+	if environment == nil {
+		return nil, fmt.Errorf("cannot find an environment with name or ID of '%s'", idOrName)
+	}
+	return environment, nil
+}
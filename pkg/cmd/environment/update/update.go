@@ -0,0 +1,165 @@
+package update
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services"
+	"github.com/spf13/cobra"
+)
+
+type UpdateFlags struct {
+	Name             *flag.Flag[string]
+	Description      *flag.Flag[string]
+	UseGuidedFailure *flag.Flag[string]
+	SortOrder        *flag.Flag[int]
+}
+
+type UpdateOptions struct {
+	*UpdateFlags
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewUpdateFlags() *UpdateFlags {
+	return &UpdateFlags{
+		Name:             flag.New[string]("name", false),
+		Description:      flag.New[string]("description", false),
+		UseGuidedFailure: flag.New[string]("use-guided-failure", false),
+		SortOrder:        flag.New[int]("sort-order", false),
+	}
+}
+
+func NewUpdateOptions(flags *UpdateFlags, dependencies *cmd.Dependencies, idOrName string) *UpdateOptions {
+	return &UpdateOptions{
+		UpdateFlags:  flags,
+		Dependencies: dependencies,
+		IdOrName:     idOrName,
+	}
+}
+
+func NewCmdUpdate(f factory.Factory) *cobra.Command {
+	updateFlags := NewUpdateFlags()
+	descriptionFilePath := ""
+
+	cmd := &cobra.Command{
+		Use:   "update {<name> | <id>}",
+		Short: "Update an environment",
+		Long:  "Update an environment in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s environment update Staging --name "Pre-production"
+			$ %[1]s environment update Environments-3 --use-guided-failure true
+			$ %[1]s environment update Production --sort-order 1
+		`, constants.ExecutableName),
+		Args: usage.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			// UpdateRun takes 0 to mean the flag wasn't given, so a 0 given on the command line has to be caught here
+			if c.Flags().Changed(updateFlags.SortOrder.Name) && updateFlags.SortOrder.Value < 1 {
+				return fmt.Errorf("--%s must be 1 or more", updateFlags.SortOrder.Name)
+			}
+			opts := NewUpdateOptions(updateFlags, cmd.NewDependencies(f, c), args[0])
+			if descriptionFilePath != "" {
+				data, err := util.ReadFileOrStdin(descriptionFilePath, c.InOrStdin())
+				if err != nil {
+					return err
+				}
+				opts.Description.Value = string(data)
+			}
+			return UpdateRun(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&updateFlags.Name.Value, updateFlags.Name.Name, "n", "", "A new name for the environment.")
+	flags.StringVarP(&updateFlags.Description.Value, updateFlags.Description.Name, "d", "", "A summary explaining the use of the environment to other users.")
+	flags.StringVar(&updateFlags.UseGuidedFailure.Value, updateFlags.UseGuidedFailure.Name, "", "Whether deployments to this environment use guided failure mode by default: true or false.")
+	flags.IntVar(&updateFlags.SortOrder.Value, updateFlags.SortOrder.Name, 0, "Move the environment to this position in the list of environments, where 1 is the first.")
+	flags.StringVarP(&descriptionFilePath, "description-file", "D", "", "Read the description from `file`, or - to read it from stdin.")
+
+	return cmd
+}
+
+// UpdateRun applies only the values which were supplied on the command line; anything left unset
+// keeps its existing value on the server. A SortOrder of 0 leaves the environment where it is.
+func UpdateRun(opts *UpdateOptions) error {
+	var useGuidedFailure *bool
+	if opts.UseGuidedFailure.Value != "" {
+		b, err := strconv.ParseBool(opts.UseGuidedFailure.Value)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid value for --%s; use true or false", opts.UseGuidedFailure.Value, opts.UseGuidedFailure.Name)
+		}
+		useGuidedFailure = &b
+	}
+	allEnvironments, err := opts.Client.Environments.GetAll()
+	if err != nil {
+		return err
+	}
+	environment := shared.FindEnvironment(allEnvironments, opts.IdOrName)
+	if environment == nil {
+		return fmt.Errorf("cannot find an environment with name or ID of '%s'", opts.IdOrName)
+	}
+
+	if opts.Name.Value != "" {
+		environment.Name = opts.Name.Value
+	}
+	if opts.Description.Value != "" {
+		environment.Description = opts.Description.Value
+	}
+	if useGuidedFailure != nil {
+		environment.UseGuidedFailure = *useGuidedFailure
+	}
+
+	updatedEnvironment, err := opts.Client.Environments.Update(environment)
+	if err != nil {
+		return err
+	}
+
+	if opts.SortOrder.Value > 0 {
+		if err := moveEnvironment(opts, allEnvironments, updatedEnvironment.GetID(), opts.SortOrder.Value); err != nil {
+			return err
+		}
+	}
+
+	err = output.Successf(opts.Out, updatedEnvironment.GetID(), "Successfully updated environment %s %s.\n", updatedEnvironment.Name, output.Dimf("(%s)", updatedEnvironment.GetID()))
+	if err != nil || output.IsQuiet {
+		return err
+	}
+	link := output.Bluef("%s/app#/%s/infrastructure/environments/%s", opts.Host, opts.Space.GetID(), updatedEnvironment.GetID())
+	_, _ = fmt.Fprintf(opts.Out, "\nView this environment on Octopus Deploy: %s\n", link)
+	return nil
+}
+
+// moveEnvironment puts the environment at the given 1-based position, and sends the new order of all
+// environments to the server. A position past the end of the list moves it to the end.
+func moveEnvironment(opts *UpdateOptions, allEnvironments []*environments.Environment, environmentID string, position int) error {
+	sort.SliceStable(allEnvironments, func(i, j int) bool {
+		return allEnvironments[i].SortOrder < allEnvironments[j].SortOrder
+	})
+	environmentIDs := make([]string, 0, len(allEnvironments))
+	for _, environment := range allEnvironments {
+		if environment.GetID() != environmentID {
+			environmentIDs = append(environmentIDs, environment.GetID())
+		}
+	}
+	index := position - 1
+	if index > len(environmentIDs) {
+		index = len(environmentIDs)
+	}
+	environmentIDs = append(environmentIDs[:index], append([]string{environmentID}, environmentIDs[index:]...)...)
+
+	// the SDK doesn't expose the sort order endpoint, so we call it ourselves
+	path := fmt.Sprintf("/api/%s/environments/sortorder", opts.Space.GetID())
+	_, err := services.ApiUpdate(opts.Client.Environments.GetClient(), environmentIDs, nil, path)
+	return err
+}
@@ -0,0 +1,122 @@
+package update_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/update"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func newEnvironments() []*environments.Environment {
+	dev := fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev")
+	dev.SortOrder = 0
+	staging := fixtures.NewEnvironment("Spaces-1", "Environments-2", "Staging")
+	staging.SortOrder = 1
+	staging.Description = "old description"
+	prod := fixtures.NewEnvironment("Spaces-1", "Environments-3", "Production")
+	prod.SortOrder = 2
+	return []*environments.Environment{dev, staging, prod}
+}
+
+func TestEnvironmentUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		idOrName  string
+		setup     func(opts *update.UpdateOptions)
+		verify    func(t *testing.T, body map[string]any)
+		sortOrder []any
+	}{
+		{"leaves unset fields untouched", "staging", func(opts *update.UpdateOptions) {
+			opts.Name.Value = "Pre-production"
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, "Pre-production", body["Name"])
+			assert.Equal(t, "old description", body["Description"])
+			assert.Equal(t, false, body["UseGuidedFailure"])
+		}, nil},
+		{"sets guided failure", "Environments-2", func(opts *update.UpdateOptions) {
+			opts.UseGuidedFailure.Value = "true"
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, "Staging", body["Name"])
+			assert.Equal(t, true, body["UseGuidedFailure"])
+		}, nil},
+		{"moves the environment to the top", "Staging", func(opts *update.UpdateOptions) {
+			opts.SortOrder.Value = 1
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, "Staging", body["Name"])
+		}, []any{"Environments-2", "Environments-1", "Environments-3"}},
+		{"moves the environment to the end", "Staging", func(opts *update.UpdateOptions) {
+			opts.SortOrder.Value = 99
+		}, func(t *testing.T, body map[string]any) {
+			assert.Equal(t, "Staging", body["Name"])
+		}, []any{"Environments-1", "Environments-3", "Environments-2"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			out := &bytes.Buffer{}
+			opts := update.NewUpdateOptions(update.NewUpdateFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out}, test.idOrName)
+			test.setup(opts)
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer api.Close()
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Client = octopus
+				return update.UpdateRun(opts)
+			})
+
+			allEnvironments := newEnvironments()
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+
+			req := api.ExpectRequest(t, "PUT", "/api/Spaces-1/environments/Environments-2")
+			body, err := testutil.ReadJson[map[string]any](req.Request.Body)
+			assert.Nil(t, err)
+			test.verify(t, body)
+			req.RespondWith(allEnvironments[1])
+
+			if test.sortOrder != nil {
+				req = api.ExpectRequest(t, "PUT", "/api/Spaces-1/environments/sortorder")
+				sortOrder, err := testutil.ReadJson[[]any](req.Request.Body)
+				assert.Nil(t, err)
+				assert.Equal(t, test.sortOrder, sortOrder)
+				req.RespondWith(nil)
+			}
+
+			err = <-errReceiver
+			assert.Nil(t, err)
+			assert.Contains(t, out.String(), "Successfully updated environment")
+		})
+	}
+
+	t.Run("rejects an invalid guided failure value", func(t *testing.T) {
+		opts := update.NewUpdateOptions(update.NewUpdateFlags(), &cmd.Dependencies{}, "Staging")
+		opts.UseGuidedFailure.Value = "sometimes"
+		assert.EqualError(t, update.UpdateRun(opts), "'sometimes' is not a valid value for --use-guided-failure; use true or false")
+	})
+
+	for _, sortOrder := range []string{"0", "-1"} {
+		t.Run("rejects --sort-order "+sortOrder, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			defer api.Close()
+			updateCmd := update.NewCmdUpdate(testutil.NewMockFactory(api))
+			updateCmd.SetArgs([]string{"Staging", "--sort-order", sortOrder})
+			updateCmd.SetOut(&bytes.Buffer{})
+			updateCmd.SetErr(&bytes.Buffer{})
+			assert.EqualError(t, updateCmd.Execute(), "--sort-order must be 1 or more")
+		})
+	}
+}
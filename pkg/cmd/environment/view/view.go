@@ -0,0 +1,153 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/shared"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/usage"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWeb = "web"
+)
+
+type ViewFlags struct {
+	Web *flag.Flag[bool]
+}
+
+type ViewOptions struct {
+	*ViewFlags
+	*cmd.Dependencies
+	IdOrName string
+}
+
+func NewViewFlags() *ViewFlags {
+	return &ViewFlags{
+		Web: flag.New[bool](FlagWeb, false),
+	}
+}
+
+func NewViewOptions(flags *ViewFlags, dependencies *cmd.Dependencies, idOrName string) *ViewOptions {
+	return &ViewOptions{
+		ViewFlags:    flags,
+		Dependencies: dependencies,
+		IdOrName:     idOrName,
+	}
+}
+
+// MachineAsJson is the summary of each deployment target included in the JSON output
+type MachineAsJson struct {
+	Id           string   `json:"Id"`
+	Name         string   `json:"Name"`
+	HealthStatus string   `json:"HealthStatus"`
+	Roles        []string `json:"Roles"`
+	IsDisabled   bool     `json:"IsDisabled"`
+}
+
+type EnvironmentAsJson struct {
+	*environments.Environment
+	Machines []*MachineAsJson `json:"Machines"`
+}
+
+func NewCmdView(f factory.Factory) *cobra.Command {
+	viewFlags := NewViewFlags()
+
+	cmd := &cobra.Command{
+		Args:  usage.ExactArgs(1),
+		Use:   "view {<name> | <id>}",
+		Short: "View an environment",
+		Long:  "View an environment and its deployment targets in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s environment view Production
+			$ %[1]s environment view Environments-3 --output-format json
+		`, constants.ExecutableName),
+		RunE: func(c *cobra.Command, args []string) error {
+			return ViewRun(NewViewOptions(viewFlags, cmd.NewDependencies(f, c), args[0]))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&viewFlags.Web.Value, viewFlags.Web.Name, "w", false, "Open in web browser")
+
+	return cmd
+}
+
+func ViewRun(opts *ViewOptions) error {
+	environment, err := shared.GetEnvironment(opts.Client, opts.IdOrName)
+	if err != nil {
+		return err
+	}
+
+	machineResources, err := opts.Client.Machines.Get(machines.MachinesQuery{EnvironmentIDs: []string{environment.GetID()}})
+	if err != nil {
+		return err
+	}
+	environmentMachines, err := machineResources.GetAllPages(opts.Client.Machines.GetClient())
+	if err != nil {
+		return err
+	}
+
+	if opts.OutputFormat == constants.OutputFormatJson {
+		environmentJson := &EnvironmentAsJson{Environment: environment, Machines: []*MachineAsJson{}}
+		for _, machine := range environmentMachines {
+			environmentJson.Machines = append(environmentJson.Machines, &MachineAsJson{
+				Id:           machine.GetID(),
+				Name:         machine.Name,
+				HealthStatus: machine.HealthStatus,
+				Roles:        machine.Roles,
+				IsDisabled:   machine.IsDisabled,
+			})
+		}
+		data, err := json.MarshalIndent(output.WrapJson(environmentJson), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	fmt.Fprintf(opts.Out, "%s %s\n", output.Bold(environment.Name), output.Dimf("(%s)", environment.GetID()))
+	if environment.Description == "" {
+		fmt.Fprintln(opts.Out, output.Dim(constants.NoDescription))
+	} else {
+		fmt.Fprintln(opts.Out, output.Dim(environment.Description))
+	}
+	fmt.Fprintf(opts.Out, "Use guided failure: %s\n", strconv.FormatBool(environment.UseGuidedFailure))
+
+	fmt.Fprintf(opts.Out, output.Cyan("\nDeployment targets:\n"))
+	if len(environmentMachines) == 0 {
+		fmt.Fprintln(opts.Out, output.Dim("None"))
+	} else {
+		t := output.NewTable(opts.Out)
+		t.AddRow(output.Bold("NAME"), output.Bold("HEALTH"), output.Bold("ROLES"))
+		for _, machine := range environmentMachines {
+			t.AddRow(machine.Name, machine.HealthStatus, output.FormatAsList(machine.Roles))
+		}
+		if err := t.Print(); err != nil {
+			return err
+		}
+	}
+
+	url := fmt.Sprintf("%s/app#/%s/infrastructure/environments/%s", opts.Host, opts.Space.GetID(), environment.GetID())
+
+	// footer
+	fmt.Fprintf(opts.Out, "\nView this environment on Octopus Deploy: %s\n", output.Blue(url))
+
+	if opts.Web.Value {
+		browser.OpenURL(url)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package view_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/environment/view"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func newMachine(id string, name string, roles ...string) *machines.DeploymentTarget {
+	machine := machines.NewDeploymentTarget(name, machines.NewListeningTentacleEndpoint(&url.URL{Scheme: "https", Host: name}, "thumbprint"), []string{"Environments-2"}, roles)
+	machine.ID = id
+	machine.HealthStatus = "Healthy"
+	return machine
+}
+
+func TestEnvironmentView(t *testing.T) {
+	dev := fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev")
+	staging := fixtures.NewEnvironment("Spaces-1", "Environments-2", "Staging")
+	staging.Description = "Pre-production checks"
+	staging.UseGuidedFailure = true
+	machineResources := &resources.Resources[*machines.DeploymentTarget]{
+		Items: []*machines.DeploymentTarget{newMachine("Machines-1", "web01", "web"), newMachine("Machines-2", "db01", "db", "backup")},
+	}
+
+	tests := []struct {
+		name         string
+		outputFormat string
+		verify       func(t *testing.T, out string)
+	}{
+		{"prints the environment and a table of its machines", "", func(t *testing.T, out string) {
+			assert.Contains(t, out, "Staging (Environments-2)\n")
+			assert.Contains(t, out, "Pre-production checks\n")
+			assert.Contains(t, out, "Use guided failure: true\n")
+			assert.Contains(t, out, "web01  Healthy  web\n")
+			assert.Contains(t, out, "db01   Healthy  db, backup\n")
+			assert.Contains(t, out, "View this environment on Octopus Deploy: /app#/Spaces-1/infrastructure/environments/Environments-2\n")
+		}},
+		{"prints the full environment with its machines as json", constants.OutputFormatJson, func(t *testing.T, out string) {
+			var parsed map[string]any
+			assert.Nil(t, json.Unmarshal([]byte(out), &parsed))
+			assert.Equal(t, "Environments-2", parsed["Id"])
+			assert.Equal(t, "Staging", parsed["Name"])
+			assert.Equal(t, true, parsed["UseGuidedFailure"])
+			assert.Equal(t, []any{
+				map[string]any{"Id": "Machines-1", "Name": "web01", "HealthStatus": "Healthy", "Roles": []any{"web"}, "IsDisabled": false},
+				map[string]any{"Id": "Machines-2", "Name": "db01", "HealthStatus": "Healthy", "Roles": []any{"db", "backup"}, "IsDisabled": false},
+			}, parsed["Machines"])
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			out := &bytes.Buffer{}
+			opts := view.NewViewOptions(view.NewViewFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out, OutputFormat: test.outputFormat}, "staging")
+
+			errReceiver := testutil.GoBegin(func() error {
+				defer api.Close()
+				octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "")
+				opts.Client = octopus
+				return view.ViewRun(opts)
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{dev, staging})
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/machines?environmentIds=Environments-2").RespondWith(machineResources)
+
+			err := <-errReceiver
+			assert.Nil(t, err)
+			test.verify(t, out.String())
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/util/flag"
@@ -42,7 +43,8 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 			$ %[1]s package list --limit 50 --filter SomePackage
 			$ %[1]s package ls -n 30 -q SomePackage
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return listRun(cmd, f, listFlags)
 		},
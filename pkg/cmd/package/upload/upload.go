@@ -14,6 +14,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
@@ -209,7 +210,7 @@ func uploadRun(cmd *cobra.Command, f factory.Factory, flags *UploadFlags) error
 		return errors.New("at least one package must be specified")
 	}
 	if outputFormat == constants.OutputFormatJson {
-		bytes, _ := json.Marshal(jsonResult)
+		bytes, _ := json.Marshal(output.WrapJson(jsonResult))
 		_, _ = cmd.OutOrStdout().Write(bytes)
 	}
 	if didErrorsOccur {
@@ -22,7 +22,8 @@ func NewCmdBranch(f factory.Factory) *cobra.Command {
 			$ %[1]s project branch create -p "Deploy Web App" --new-branch-name add-name-variable --base-branch refs/heads/main -
 		`, constants.ExecutableName),
 		Annotations: map[string]string{
-			annotations.IsCore: "true",
+			annotations.IsCore:           "true",
+			annotations.MinServerVersion: constants.ServerVersionConfigAsCode,
 		},
 	}
 
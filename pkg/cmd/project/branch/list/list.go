@@ -7,6 +7,7 @@ import (
 	sharedBranches "github.com/OctopusDeploy/cli/pkg/cmd/project/branch/shared"
 	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
@@ -68,7 +69,8 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 			$ %[1]s project branch list "Deploy Website"
 			$ %[1]s project variable ls
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
 			opts := NewListOptions(listFlags, cmd.NewDependencies(f, c), c)
 
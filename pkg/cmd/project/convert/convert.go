@@ -7,6 +7,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
@@ -111,6 +112,7 @@ func NewCmdConvert(f factory.Factory) *cobra.Command {
 			$ %[1]s project convert
 			$ %[1]s project convert --project "Deploy web site" --git-url https://github.com/orgname/reponame"
 		`, constants.ExecutableName),
+		Annotations: map[string]string{annotations.MinServerVersion: constants.ServerVersionConfigAsCode},
 		RunE: func(c *cobra.Command, args []string) error {
 			opts := NewConvertOptions(convertProjectFlags, cmd.NewDependencies(f, c))
 			return convertRun(opts)
@@ -63,10 +63,13 @@ func (co *CreateOptions) Commit() error {
 		return err
 	}
 
-	_, err = fmt.Fprintf(co.Out, "\nSuccessfully created project '%s' (%s), with lifecycle '%s' in project group '%s'.\n", createdProject.Name, createdProject.Slug, co.Lifecycle.Value, co.Group.Value)
+	err = output.Successf(co.Out, createdProject.GetID(), "\nSuccessfully created project '%s' (%s), with lifecycle '%s' in project group '%s'.\n", createdProject.Name, createdProject.Slug, co.Lifecycle.Value, co.Group.Value)
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 
 	link := output.Bluef("%s/app#/%s/projects/%s", co.Host, co.Space.GetID(), createdProject.GetID())
 	fmt.Fprintf(co.Out, "View this project on Octopus Deploy: %s\n", link)
@@ -75,7 +78,7 @@ func (co *CreateOptions) Commit() error {
 }
 
 func (co *CreateOptions) GenerateAutomationCmd() {
-	if !co.NoPrompt {
+	if !co.NoPrompt && !output.IsQuiet {
 		autoCmd := flag.GenerateAutomationCmd(co.CmdPath, co.Name, co.Description, co.Group, co.Lifecycle)
 		fmt.Fprintf(co.Out, "%s\n", autoCmd)
 	}
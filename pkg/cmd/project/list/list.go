@@ -3,14 +3,31 @@ package list
 import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/spf13/cobra"
 )
 
+const FlagProjectGroup = "project-group"
+
+type ListFlags struct {
+	ProjectGroup *flag.Flag[string]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		ProjectGroup: flag.New[string](FlagProjectGroup, false),
+	}
+}
+
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List projects",
@@ -18,23 +35,30 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 		Example: heredoc.Docf(`
 			$ %[1]s project list
 			$ %[1]s project ls
+			$ %[1]s project list --project-group "Default Project Group"
+			$ %[1]s project list --output-format json
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return listRun(cmd, f)
+			return listRun(cmd, f, listFlags)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.StringVarP(&listFlags.ProjectGroup.Value, listFlags.ProjectGroup.Name, "g", "", "only list projects in this project group, given by name or ID")
 	return cmd
 }
 
 type ProjectAsJson struct {
-	Id          string `json:"Id"`
-	Name        string `json:"Name"`
-	Description string `json:"Description"`
+	Id           string            `json:"Id"`
+	Name         string            `json:"Name"`
+	Description  string            `json:"Description"`
+	ProjectGroup *output.IdAndName `json:"ProjectGroup"`
+	Lifecycle    *output.IdAndName `json:"Lifecycle"`
 }
 
-func listRun(cmd *cobra.Command, f factory.Factory) error {
+func listRun(cmd *cobra.Command, f factory.Factory, flags *ListFlags) error {
 	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
 	if err != nil {
 		return err
@@ -44,19 +68,49 @@ func listRun(cmd *cobra.Command, f factory.Factory) error {
 	if err != nil {
 		return err
 	}
+	allProjectGroups, err := client.ProjectGroups.GetAll()
+	if err != nil {
+		return err
+	}
+	allLifecycles, err := client.Lifecycles.GetAll()
+	if err != nil {
+		return err
+	}
+
+	projectGroupNames := make(map[string]string, len(allProjectGroups))
+	for _, group := range allProjectGroups {
+		projectGroupNames[group.GetID()] = group.Name
+	}
+	lifecycleNames := make(map[string]string, len(allLifecycles))
+	for _, lifecycle := range allLifecycles {
+		lifecycleNames[lifecycle.GetID()] = lifecycle.Name
+	}
+
+	if flags.ProjectGroup.Value != "" {
+		// the project groups are already loaded for their names, so the resolver needn't fetch them again
+		resolver := helper.NewProjectGroupResolver(client)
+		resolver.GetAllProjectGroups = func() ([]*projectgroups.ProjectGroup, error) { return allProjectGroups, nil }
+		projectGroupIds, err := resolver.Resolve([]string{flags.ProjectGroup.Value})
+		if err != nil {
+			return err
+		}
+		allProjects = filterProjects(allProjects, projectGroupIds[0])
+	}
 
 	return output.PrintArray(allProjects, cmd, output.Mappers[*projects.Project]{
 		Json: func(p *projects.Project) any {
 			return ProjectAsJson{
-				Id:          p.GetID(),
-				Name:        p.GetName(),
-				Description: p.Description,
+				Id:           p.GetID(),
+				Name:         p.GetName(),
+				Description:  p.Description,
+				ProjectGroup: &output.IdAndName{Id: p.ProjectGroupID, Name: projectGroupNames[p.ProjectGroupID]},
+				Lifecycle:    &output.IdAndName{Id: p.LifecycleID, Name: lifecycleNames[p.LifecycleID]},
 			}
 		},
 		Table: output.TableDefinition[*projects.Project]{
-			Header: []string{"NAME", "DESCRIPTION"},
+			Header: []string{"NAME", "PROJECT GROUP", "LIFECYCLE", "ID", "DESCRIPTION"},
 			Row: func(p *projects.Project) []string {
-				return []string{output.Bold(p.Name), p.Description}
+				return []string{output.Bold(p.Name), nameOrId(projectGroupNames, p.ProjectGroupID), nameOrId(lifecycleNames, p.LifecycleID), p.GetID(), p.Description}
 			},
 		},
 		Basic: func(p *projects.Project) string {
@@ -64,3 +118,21 @@ func listRun(cmd *cobra.Command, f factory.Factory) error {
 		},
 	})
 }
+
+func filterProjects(items []*projects.Project, projectGroupId string) []*projects.Project {
+	matches := make([]*projects.Project, 0, len(items))
+	for _, p := range items {
+		if p.ProjectGroupID == projectGroupId {
+			matches = append(matches, p)
+		}
+	}
+	return matches
+}
+
+// nameOrId falls back to the ID for anything we couldn't look up, rather than showing nothing
+func nameOrId(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return id
+}
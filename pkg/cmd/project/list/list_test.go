@@ -0,0 +1,113 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func newProjectGroup(id string, name string) *projectgroups.ProjectGroup {
+	group := projectgroups.NewProjectGroup(name)
+	group.ID = id
+	return group
+}
+
+func newLifecycle(id string, name string) *lifecycles.Lifecycle {
+	lifecycle := lifecycles.NewLifecycle(name)
+	lifecycle.ID = id
+	return lifecycle
+}
+
+func TestProjectList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	allProjects := []*projects.Project{
+		fixtures.NewProject("Spaces-1", "Projects-1", "Web", "Lifecycles-1", "ProjectGroups-1", ""),
+		fixtures.NewProject("Spaces-1", "Projects-2", "Billing", "Lifecycles-2", "ProjectGroups-2", ""),
+		fixtures.NewProject("Spaces-1", "Projects-3", "Docs", "Lifecycles-1", "ProjectGroups-1", ""),
+	}
+	allProjectGroups := []*projectgroups.ProjectGroup{newProjectGroup("ProjectGroups-1", "Default Project Group"), newProjectGroup("ProjectGroups-2", "Finance"), newProjectGroup("ProjectGroups-3", "Ops"), newProjectGroup("ProjectGroups-4", "OPS")}
+	allLifecycles := []*lifecycles.Lifecycle{newLifecycle("Lifecycles-1", "Default Lifecycle"), newLifecycle("Lifecycles-2", "Strict")}
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"prints a table with project group and lifecycle names", []string{"--no-color"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "NAME     PROJECT GROUP          LIFECYCLE          ID          DESCRIPTION\n"+
+				"Web      Default Project Group  Default Lifecycle  Projects-1  \n"+
+				"Billing  Finance                Strict             Projects-2  \n"+
+				"Docs     Default Project Group  Default Lifecycle  Projects-3  \n", out.String())
+		}},
+		{"filters by project group name", []string{"--project-group", "default project group", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "Web\nDocs\n", out.String())
+		}},
+		{"filters by project group ID", []string{"-g", "ProjectGroups-2", "-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			type entity struct {
+				Id   string
+				Name string
+			}
+			parsed, err := testutil.ParseJsonStrict[[]struct {
+				Id           string
+				Name         string
+				Description  string
+				ProjectGroup entity
+				Lifecycle    entity
+			}](out)
+			assert.Nil(t, err)
+			assert.Len(t, parsed, 1)
+			assert.Equal(t, "Projects-2", parsed[0].Id)
+			assert.Equal(t, entity{Id: "ProjectGroups-2", Name: "Finance"}, parsed[0].ProjectGroup)
+			assert.Equal(t, entity{Id: "Lifecycles-2", Name: "Strict"}, parsed[0].Lifecycle)
+		}},
+		{"matches a project group ID ignoring case", []string{"-g", "projectgroups-2", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "Billing\n", out.String())
+		}},
+		{"rejects an unknown project group", []string{"--project-group", "Marketing"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.ErrorContains(t, err, "cannot find a project group with name or ID of 'Marketing'")
+		}},
+		{"rejects a name shared by more than one project group", []string{"--project-group", "ops"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.ErrorContains(t, err, "more than one project group is named 'ops' (ProjectGroups-3, ProjectGroups-4); give the ID of the one you want instead")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"project", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projects/all").RespondWith(allProjects)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/projectgroups/all").RespondWith(allProjectGroups)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/lifecycles/all").RespondWith(allLifecycles)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd/tenant/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	sharedVariable "github.com/OctopusDeploy/cli/pkg/question/shared/variables"
@@ -199,18 +200,21 @@ func CreateRun(opts *CreateOptions) error {
 		newVariable.Prompt.DisplaySettings = variables.NewDisplaySettings(promptControlType, selectOptions)
 	}
 
+	var variableSet *variables.VariableSet
 	if opts.GitRef.Value != "" {
-		_, err = opts.Client.ProjectVariables.AddSingleByGitRef(opts.Space.GetID(), project.GetID(), opts.GitRef.Value, newVariable)
+		variableSet, err = opts.Client.ProjectVariables.AddSingleByGitRef(opts.Space.GetID(), project.GetID(), opts.GitRef.Value, newVariable)
 	} else {
-		_, err = opts.Client.Variables.AddSingle(project.GetID(), newVariable)
+		var set variables.VariableSet
+		set, err = opts.Client.Variables.AddSingle(project.GetID(), newVariable)
+		variableSet = &set
 	}
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintf(opts.Out, "Successfully created variable '%s' in project '%s'\n", opts.Name.Value, project.GetName())
+	err = output.Successf(opts.Out, createdVariableID(variableSet, opts.Name.Value), "Successfully created variable '%s' in project '%s'\n", opts.Name.Value, project.GetName())
 
-	if !opts.NoPrompt {
+	if !opts.NoPrompt && !output.IsQuiet {
 		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.Project, opts.Name, opts.Value, opts.Description, opts.Type, opts.EnvironmentsScopes, opts.ChannelScopes, opts.StepScopes, opts.TargetScopes, opts.TagScopes, opts.RoleScopes, opts.ProcessScopes, opts.IsPrompted, opts.PromptType, opts.PromptLabel, opts.PromptDescription, opts.PromptSelectOptions, opts.PromptRequired, opts.GitRef)
 		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
 	}
@@ -218,6 +222,19 @@ func CreateRun(opts *CreateOptions) error {
 	return nil
 }
 
+// createdVariableID finds the id the server gave the variable we just added; it is appended to the end of the set
+func createdVariableID(variableSet *variables.VariableSet, name string) string {
+	if variableSet == nil {
+		return ""
+	}
+	for i := len(variableSet.Variables) - 1; i >= 0; i-- {
+		if variableSet.Variables[i].Name == name {
+			return variableSet.Variables[i].GetID()
+		}
+	}
+	return ""
+}
+
 func PromptMissing(opts *CreateOptions) error {
 	var project *projects.Project
 	var err error
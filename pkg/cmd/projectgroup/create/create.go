@@ -45,17 +45,20 @@ func (co *CreateOptions) Commit() error {
 	if err != nil {
 		return err
 	}
-	_, err = fmt.Fprintf(co.Out, "\nSuccessfully created project group %s.\n", createdGroupProject.Name)
+	err = output.Successf(co.Out, createdGroupProject.GetID(), "\nSuccessfully created project group %s.\n", createdGroupProject.Name)
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 	link := output.Bluef("%s/app#/%s/projectGroups/%s", co.Host, co.Space.GetID(), createdGroupProject.GetID())
 	fmt.Fprintf(co.Out, "View this project group on Octopus Deploy: %s\n", link)
 	return nil
 }
 
 func (co *CreateOptions) GenerateAutomationCmd() {
-	if !co.NoPrompt {
+	if !co.NoPrompt && !output.IsQuiet {
 		autoCmd := flag.GenerateAutomationCmd(co.CmdPath, co.Name, co.Description)
 		fmt.Fprintf(co.Out, "%s\n", autoCmd)
 	}
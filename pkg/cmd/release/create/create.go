@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"io"
-	"os"
 	"regexp"
 	"sort"
 	"strings"
@@ -163,7 +162,7 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	flags.StringVarP(&createFlags.GitCommit.Value, createFlags.GitCommit.Name, "", "", "Git Commit Hash; Specify this in addition to Git Reference if you want to reference a commit other than the latest for that branch/tag.")
 	flags.StringVarP(&createFlags.PackageVersion.Value, createFlags.PackageVersion.Name, "", "", "Default version to use for all Packages")
 	flags.StringVar(&createFlags.ReleaseNotes.Value, createFlags.ReleaseNotes.Name, "", "Release notes to attach")
-	flags.StringVarP(&createFlags.ReleaseNotesFile.Value, createFlags.ReleaseNotesFile.Name, "", "", "Release notes to attach (from file)")
+	flags.StringVarP(&createFlags.ReleaseNotesFile.Value, createFlags.ReleaseNotesFile.Name, "", "", "Release notes to attach (from file, or - to read them from stdin)")
 	flags.StringVarP(&createFlags.Version.Value, createFlags.Version.Name, "v", "", "Override the Release Version")
 	flags.BoolVarP(&createFlags.IgnoreExisting.Value, createFlags.IgnoreExisting.Name, "x", false, "If a release with the same version exists, do nothing instead of failing.")
 	flags.BoolVarP(&createFlags.IgnoreChannelRules.Value, createFlags.IgnoreChannelRules.Name, "", false, "Allow creation of a release where channel rules would otherwise prevent it.")
@@ -216,7 +215,7 @@ func createRun(cmd *cobra.Command, f factory.Factory, flags *CreateFlags) error
 	}
 
 	if flags.ReleaseNotesFile.Value != "" {
-		fileContents, err := os.ReadFile(flags.ReleaseNotesFile.Value)
+		fileContents, err := util.ReadFileOrStdin(flags.ReleaseNotesFile.Value, cmd.InOrStdin())
 		if err != nil {
 			return err
 		}
@@ -289,7 +288,7 @@ func createRun(cmd *cobra.Command, f factory.Factory, flags *CreateFlags) error
 				if channel != nil {
 					v.Channel = channel.Name
 				}
-				data, err := json.Marshal(v)
+				data, err := json.Marshal(output.WrapJson(v))
 				if err != nil { // shouldn't happen but fallback in case
 					cmd.PrintErrln(err)
 				} else {
@@ -297,7 +296,9 @@ func createRun(cmd *cobra.Command, f factory.Factory, flags *CreateFlags) error
 					cmd.Println()
 				}
 			default: // table
-				if channel != nil {
+				if output.IsQuiet {
+					cmd.Println(options.Response.ReleaseID)
+				} else if channel != nil {
 					cmd.Printf("Successfully created release version %s using channel %s\n", releaseVersion, channel.Name)
 				} else {
 					cmd.Printf("Successfully created release version %s\n", releaseVersion)
@@ -321,7 +322,7 @@ func createRun(cmd *cobra.Command, f factory.Factory, flags *CreateFlags) error
 		}
 
 		// output web URL all the time, so long as output format is not JSON or basic
-		if err == nil && !constants.IsProgrammaticOutputFormat(outputFormat) {
+		if err == nil && !constants.IsProgrammaticOutputFormat(outputFormat) && !output.IsQuiet {
 			link := output.Bluef("%s/app#/%s/releases/%s", f.GetCurrentHost(), f.GetCurrentSpace().ID, options.Response.ReleaseID)
 			cmd.Printf("\nView this release on Octopus Deploy: %s\n", link)
 		}
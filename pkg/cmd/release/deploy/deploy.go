@@ -20,6 +20,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/cli/pkg/surveyext"
+	"github.com/OctopusDeploy/cli/pkg/taskwait"
 	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
@@ -101,6 +102,8 @@ type DeployFlags struct {
 	ForcePackageDownload *flag.Flag[bool]
 	DeploymentTargets    *flag.Flag[[]string]
 	ExcludeTargets       *flag.Flag[[]string]
+	Wait                 *flag.Flag[bool]
+	WaitTimeout          *flag.Flag[time.Duration]
 }
 
 func NewDeployFlags() *DeployFlags {
@@ -119,6 +122,8 @@ func NewDeployFlags() *DeployFlags {
 		ForcePackageDownload: flag.New[bool](FlagForcePackageDownload, false),
 		DeploymentTargets:    flag.New[[]string](FlagDeploymentTarget, false),
 		ExcludeTargets:       flag.New[[]string](FlagExcludeDeploymentTarget, false),
+		Wait:                 flag.New[bool](taskwait.FlagWait, false),
+		WaitTimeout:          flag.New[time.Duration](taskwait.FlagWaitTimeout, false),
 	}
 }
 
@@ -134,6 +139,7 @@ func NewCmdDeploy(f factory.Factory) *cobra.Command {
 			$ %[1]s release deploy --project MyProject --version 1.0 --tenant-tag Regions/East --tenant-tag Regions/South
 			$ %[1]s release deploy -p MyProject --version 1.0 -e Dev --skip InstallStep --variable VarName:VarValue
 			$ %[1]s release deploy -p MyProject --version 1.0 -e Dev --force-package-download --guided-failure true -f basic
+			$ %[1]s release deploy -p MyProject --version 1.0 -e Dev --wait --wait-timeout 30m
 		`, constants.ExecutableName),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && deployFlags.Project.Value == "" {
@@ -159,6 +165,7 @@ func NewCmdDeploy(f factory.Factory) *cobra.Command {
 	flags.BoolVarP(&deployFlags.ForcePackageDownload.Value, deployFlags.ForcePackageDownload.Name, "", false, "Force re-download of packages")
 	flags.StringSliceVarP(&deployFlags.DeploymentTargets.Value, deployFlags.DeploymentTargets.Name, "", nil, "Deploy to this target (can be specified multiple times)")
 	flags.StringSliceVarP(&deployFlags.ExcludeTargets.Value, deployFlags.ExcludeTargets.Name, "", nil, "Deploy to targets except for this (can be specified multiple times)")
+	taskwait.RegisterFlags(cmd, &deployFlags.Wait.Value, &deployFlags.WaitTimeout.Value)
 
 	flags.SortFlags = false
 
@@ -263,6 +270,7 @@ func deployRun(cmd *cobra.Command, f factory.Factory, flags *DeployFlags) error
 			// we're deliberately adding --no-prompt to the generated cmdline so ForcePackageDownload=false will be missing,
 			// but that's fine
 			resolvedFlags.ForcePackageDownload.Value = options.ForcePackageDownload
+			resolvedFlags.Wait.Value = flags.Wait.Value
 
 			autoCmd := flag.GenerateAutomationCmd(constants.ExecutableName+" release deploy",
 				resolvedFlags.Project,
@@ -278,6 +286,7 @@ func deployRun(cmd *cobra.Command, f factory.Factory, flags *DeployFlags) error
 				resolvedFlags.DeploymentTargets,
 				resolvedFlags.ExcludeTargets,
 				resolvedFlags.Variables,
+				resolvedFlags.Wait,
 			)
 			cmd.Printf("\nAutomation Command: %s\n", autoCmd)
 
@@ -312,7 +321,7 @@ func deployRun(cmd *cobra.Command, f factory.Factory, flags *DeployFlags) error
 			}
 
 		case constants.OutputFormatJson:
-			data, err := json.Marshal(options.Response.DeploymentServerTasks)
+			data, err := json.Marshal(output.WrapJson(options.Response.DeploymentServerTasks))
 			if err != nil { // shouldn't happen but fallback in case
 				cmd.PrintErrln(err)
 			} else {
@@ -343,6 +352,11 @@ func deployRun(cmd *cobra.Command, f factory.Factory, flags *DeployFlags) error
 				cmd.Printf("\nView this release on Octopus Deploy: %s\n", link)
 			}
 		}
+
+		if flags.Wait.Value {
+			taskIDs := util.SliceTransform(options.Response.DeploymentServerTasks, func(t *deployments.DeploymentServerTask) string { return t.ServerTaskID })
+			return taskwait.WaitForCommand(cmd, outputFormat, octopus, taskIDs, flags.WaitTimeout.Value)
+		}
 	}
 
 	return nil
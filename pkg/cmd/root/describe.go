@@ -0,0 +1,102 @@
+package root
+
+import (
+	"encoding/json"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type FlagDescription struct {
+	Name        string
+	Shorthand   string
+	Type        string
+	Default     string
+	Description string
+	Required    bool
+}
+
+type CommandDescription struct {
+	Command       string
+	Usage         string
+	RequiresSpace bool
+	Timeout       string // the timeout the command would run with; empty if there is no limit
+	Flags         []FlagDescription
+}
+
+// DescribeCommand builds a machine-readable description of cmd, for people building wrappers around the CLI
+func DescribeCommand(cmd *cobra.Command) *CommandDescription {
+	description := &CommandDescription{
+		Command:       cmd.CommandPath(),
+		Usage:         cmd.UseLine(),
+		RequiresSpace: requiresSpace(cmd),
+		Flags:         []FlagDescription{},
+	}
+	if timeout := EffectiveTimeout(cmd); timeout > 0 {
+		description.Timeout = timeout.String()
+	}
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Name == constants.FlagHelp || f.Name == constants.FlagDescribe {
+			return
+		}
+		_, required := f.Annotations[cobra.BashCompOneRequiredFlag]
+		description.Flags = append(description.Flags, FlagDescription{
+			Name:        f.Name,
+			Shorthand:   f.Shorthand,
+			Type:        f.Value.Type(),
+			Default:     f.DefValue,
+			Description: f.Usage,
+			Required:    required,
+		})
+	})
+	return description
+}
+
+// requiresSpace is true unless the command, or one of its parents, is marked as working outside of any space
+func requiresSpace(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[annotations.IsSpaceless]; ok {
+			return false
+		}
+	}
+	return cmd.HasParent() // the root command on its own doesn't do anything
+}
+
+// describeInsteadOfRunning swaps out the command's implementation so that it prints its description and exits.
+// This has to happen after flags are parsed (so we know --describe was given) but before the command runs.
+// cobra checks required flags after that, so the description is taken first, and then they stop being required.
+func describeInsteadOfRunning(cmd *cobra.Command) {
+	description := DescribeCommand(cmd)
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		delete(f.Annotations, cobra.BashCompOneRequiredFlag)
+	})
+	cmd.PreRun = nil
+	cmd.PreRunE = nil
+	cmd.Run = nil
+	cmd.RunE = func(c *cobra.Command, _ []string) error {
+		data, err := json.MarshalIndent(description, "", "  ")
+		if err != nil {
+			return err
+		}
+		c.Println(string(data))
+		return nil
+	}
+}
+
+// skipArgsWhenDescribing makes cmd and all its subcommands accept any arguments when --describe is given, as cobra
+// checks them before PersistentPreRunE gets the chance to call describeInsteadOfRunning
+func skipArgsWhenDescribing(cmd *cobra.Command) {
+	if validateArgs := cmd.Args; validateArgs != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if describe, _ := c.Flags().GetBool(constants.FlagDescribe); describe {
+				return nil
+			}
+			return validateArgs(c, args)
+		}
+	}
+	for _, subcommand := range cmd.Commands() {
+		skipArgsWhenDescribing(subcommand)
+	}
+}
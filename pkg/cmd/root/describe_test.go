@@ -0,0 +1,89 @@
+package root_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Run("describes account ssh create without calling the API", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		defer api.Close()
+		// no space on the factory; if the command actually ran it would fail to get a client
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, nil, askProvider), nil, askProvider)
+		stdout := &bytes.Buffer{}
+		rootCmd.SetOut(stdout)
+		rootCmd.SetArgs([]string{"account", "ssh", "create", "--describe"})
+
+		err := rootCmd.Execute()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, api.GetPendingMessageCount())
+
+		description, err := testutil.ParseJsonStrict[cmdRoot.CommandDescription](stdout)
+		assert.Nil(t, err)
+		assert.Equal(t, "octopus account ssh create", description.Command)
+		assert.True(t, description.RequiresSpace)
+
+		flags := map[string]cmdRoot.FlagDescription{}
+		for _, f := range description.Flags {
+			flags[f.Name] = f
+		}
+		assert.Equal(t, cmdRoot.FlagDescription{
+			Name:        "private-key",
+			Shorthand:   "K",
+			Type:        "string",
+			Default:     "",
+			Description: "Path to the private key file portion of the key pair, or - to read it from stdin.",
+		}, flags["private-key"])
+		assert.Equal(t, "stringArray", flags["environment"].Type)
+		assert.NotContains(t, flags, "describe")
+		assert.NotContains(t, flags, "space") // inherited flags belong to the root command
+	})
+
+	t.Run("space commands don't require a space", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		defer api.Close()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, nil, askProvider), nil, askProvider)
+		stdout := &bytes.Buffer{}
+		rootCmd.SetOut(stdout)
+		rootCmd.SetArgs([]string{"space", "list", "--describe"})
+
+		assert.Nil(t, rootCmd.Execute())
+
+		var description cmdRoot.CommandDescription
+		assert.Nil(t, json.Unmarshal(stdout.Bytes(), &description))
+		assert.False(t, description.RequiresSpace)
+	})
+
+	t.Run("describes commands which take arguments or have required flags without them", func(t *testing.T) {
+		for _, args := range [][]string{{"account", "view"}, {"account", "ssh", "update"}, {"account", "find"}} {
+			api := testutil.NewMockHttpServer()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, nil, askProvider), nil, askProvider)
+			stdout := &bytes.Buffer{}
+			rootCmd.SetOut(stdout)
+			rootCmd.SetArgs(append(args, "--describe"))
+
+			assert.Nil(t, rootCmd.Execute())
+			api.Close()
+
+			description, err := testutil.ParseJsonStrict[cmdRoot.CommandDescription](stdout)
+			assert.Nil(t, err)
+			assert.Equal(t, "octopus "+strings.Join(args, " "), description.Command)
+			for _, f := range description.Flags {
+				if f.Name == "fingerprint" {
+					assert.True(t, f.Required) // still described as required, even though --describe didn't need it
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/spf13/cobra"
+)
+
+// ErrInterrupted is what Execute returns when the user pressed Ctrl-C, whether at a prompt or while the command
+// was busy. There is nothing more to tell them, so it isn't worth printing
+var ErrInterrupted = errors.New("interrupted")
+
+// Execute runs cmd, and if it panics, stops the spinner before reporting the panic as an error.
+// Stopping the spinner also puts the terminal cursor back, which would otherwise stay hidden after we exit.
+// Ctrl-C stops the spinner the same way and cancels the command's context, which stops any request in flight;
+// pressing it again kills us straight away. Running out of time is reported as a timeout however deep inside
+// other errors it was found.
+func Execute(cmd *cobra.Command, s factory.Spinner) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.Stop()
+			err = fmt.Errorf("the command failed unexpectedly: %v\nplease report this at https://github.com/OctopusDeploy/cli/issues", r)
+		}
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	interrupted := make(chan struct{})
+	go func() {
+		select {
+		case <-interrupts:
+			signal.Stop(interrupts)
+			s.Stop()
+			close(interrupted)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	executed, err := cmd.ExecuteContextC(ctx)
+	select {
+	case <-interrupted:
+		return ErrInterrupted
+	default:
+	}
+	if question.IsInterrupt(err) {
+		return ErrInterrupted
+	}
+	return timeoutError(executed, err)
+}
+
+func timeoutError(cmd *cobra.Command, err error) error {
+	var timeoutErr *apiclient.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) && cmd != nil {
+		if timeout := EffectiveTimeout(cmd); timeout > 0 {
+			return &apiclient.TimeoutError{Timeout: timeout, Err: err}
+		}
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSpinner struct {
+	running bool
+}
+
+func (s *recordingSpinner) Start() { s.running = true }
+func (s *recordingSpinner) Stop()  { s.running = false }
+
+func TestExecute(t *testing.T) {
+	t.Run("stops the spinner when the command panics", func(t *testing.T) {
+		spinner := &recordingSpinner{}
+		cmd := &cobra.Command{
+			Use: "crash",
+			RunE: func(c *cobra.Command, args []string) error {
+				spinner.Start()
+				panic("boom")
+			},
+		}
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmdRoot.Execute(cmd, spinner)
+
+		assert.False(t, spinner.running)
+		assert.EqualError(t, err, "the command failed unexpectedly: boom\nplease report this at https://github.com/OctopusDeploy/cli/issues")
+	})
+
+	t.Run("passes through errors from the command", func(t *testing.T) {
+		spinner := &recordingSpinner{}
+		cmd := &cobra.Command{
+			Use: "fail",
+			RunE: func(c *cobra.Command, args []string) error {
+				return assert.AnError
+			},
+		}
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		assert.Equal(t, assert.AnError, cmdRoot.Execute(cmd, spinner))
+	})
+	t.Run("reports running out of time as a timeout", func(t *testing.T) {
+		spinner := &recordingSpinner{}
+		cmd := &cobra.Command{
+			Use:         "slow",
+			Annotations: map[string]string{annotations.DefaultTimeout: "5m"},
+			RunE: func(c *cobra.Command, args []string) error {
+				return fmt.Errorf("waiting for tasks: %w", context.DeadlineExceeded)
+			},
+		}
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmdRoot.Execute(cmd, spinner)
+		assert.EqualError(t, err, "operation timed out after 5m0s; use --timeout to allow longer")
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("stops the spinner and cancels the command on Ctrl-C", func(t *testing.T) {
+		spinner := &recordingSpinner{}
+		cmd := &cobra.Command{
+			Use: "busy",
+			RunE: func(c *cobra.Command, args []string) error {
+				spinner.Start()
+				self, _ := os.FindProcess(os.Getpid())
+				if err := self.Signal(os.Interrupt); err != nil {
+					t.Skip("can't send ourselves Ctrl-C here")
+				}
+				<-c.Context().Done()
+				return c.Context().Err()
+			},
+		}
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmdRoot.Execute(cmd, spinner)
+		assert.False(t, spinner.running)
+		assert.Equal(t, cmdRoot.ErrInterrupted, err)
+	})
+
+	t.Run("treats Ctrl-C at a prompt as an interruption", func(t *testing.T) {
+		cmd := &cobra.Command{
+			Use: "ask",
+			RunE: func(c *cobra.Command, args []string) error {
+				return terminal.InterruptErr
+			},
+		}
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		assert.Equal(t, cmdRoot.ErrInterrupted, cmdRoot.Execute(cmd, &recordingSpinner{}))
+	})
+}
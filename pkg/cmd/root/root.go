@@ -1,8 +1,12 @@
 package root
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	accountCmd "github.com/OctopusDeploy/cli/pkg/cmd/account"
+	completionCmd "github.com/OctopusDeploy/cli/pkg/cmd/completion"
 	configCmd "github.com/OctopusDeploy/cli/pkg/cmd/config"
 	environmentCmd "github.com/OctopusDeploy/cli/pkg/cmd/environment"
 	packageCmd "github.com/OctopusDeploy/cli/pkg/cmd/package"
@@ -13,13 +17,16 @@ import (
 	spaceCmd "github.com/OctopusDeploy/cli/pkg/cmd/space"
 	deploymentTargetCmd "github.com/OctopusDeploy/cli/pkg/cmd/target"
 	taskCmd "github.com/OctopusDeploy/cli/pkg/cmd/task"
+	teamCmd "github.com/OctopusDeploy/cli/pkg/cmd/team"
 	tenantCmd "github.com/OctopusDeploy/cli/pkg/cmd/tenant"
 	userCmd "github.com/OctopusDeploy/cli/pkg/cmd/user"
 	"github.com/OctopusDeploy/cli/pkg/cmd/version"
+	"github.com/OctopusDeploy/cli/pkg/cmd/whoami"
 	workerCmd "github.com/OctopusDeploy/cli/pkg/cmd/worker"
 	workerPoolCmd "github.com/OctopusDeploy/cli/pkg/cmd/workerpool"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -38,6 +45,7 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 	// ----- Child Commands -----
 
 	cmd.AddCommand(version.NewCmdVersion(f))
+	cmd.AddCommand(whoami.NewCmdWhoAmI(f))
 
 	// infrastructure
 	cmd.AddCommand(accountCmd.NewCmdAccount(f))
@@ -55,7 +63,9 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 
 	// configuration
 	cmd.AddCommand(configCmd.NewCmdConfig(f))
+	cmd.AddCommand(completionCmd.NewCmdCompletion())
 	cmd.AddCommand(spaceCmd.NewCmdSpace(f))
+	cmd.AddCommand(teamCmd.NewCmdTeam(f))
 	cmd.AddCommand(userCmd.NewCmdUser(f))
 	cmd.AddCommand(releaseCmd.NewCmdRelease(f))
 	cmd.AddCommand(runbookCmd.NewCmdRunbook(f))
@@ -71,11 +81,33 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 	cmdPFlags.BoolP(constants.FlagHelp, "h", false, "Show help for a command")
 	cmd.SetHelpFunc(rootHelpFunc)
 	cmdPFlags.StringP(constants.FlagSpace, "s", "", "Specify the space for operations")
+	// --profile is picked out of the arguments in main before the client factory is created; it is
+	// registered here so cobra accepts it and it shows up in help
+	cmdPFlags.String(constants.FlagProfile, "", "Use the named Octopus instance profile from config.yaml")
+	// like --profile, these are picked out by main; they beat OCTOPUS_URL/OCTOPUS_API_KEY, the profile and the config file
+	cmdPFlags.String(constants.FlagServer, "", "The `url` of the Octopus Server. Overrides OCTOPUS_URL, the profile and the config file")
+	cmdPFlags.String(constants.FlagApiKey, "", "The API `key` to authenticate with. Overrides OCTOPUS_API_KEY, OCTOPUS_ACCESS_TOKEN, the profile and the config file. Use @path to read it from a file")
 
 	// remember if you read FlagOutputFormat you also need to check FlagOutputFormatLegacy
-	cmdPFlags.StringP(constants.FlagOutputFormat, "f", constants.OutputFormatTable, `Specify the output format for a command ("json", "table", or "basic")`)
+	cmdPFlags.StringP(constants.FlagOutputFormat, "f", constants.OutputFormatTable, `Specify the output format for a command ("json", "ndjson", "csv", "table", "basic", "env", or "yaml")`)
 
 	cmdPFlags.BoolP(constants.FlagNoPrompt, "", false, "Disable prompting in interactive mode")
+	// main also looks for --prompt early, as it decides whether we're running in CI before cobra runs
+	cmdPFlags.Bool(constants.FlagPrompt, false, "Prompt for missing input even when running in CI, e.g. to debug a pipeline locally")
+	cmdPFlags.String(constants.FlagCACert, "", "Trust the Octopus Server's TLS certificate if it was issued by a CA in this PEM `file`")
+	cmdPFlags.Bool(constants.FlagSkipTlsVerify, false, "Don't verify the Octopus Server's TLS certificate. This is insecure; prefer installing your CA certificate")
+	cmdPFlags.Bool(constants.FlagNoCache, false, "Always look up the space on the Octopus Server, rather than using the cached result of a previous lookup")
+	cmdPFlags.Bool(constants.FlagDescribe, false, "Print a JSON description of the command's flags and exit without running it")
+	cmdPFlags.BoolP(constants.FlagNoBanner, "", false, "Suppress informational messages, leaving only errors and the command's result")
+	// -q is already taken by some subcommands, so --quiet has no shorthand; main also looks for it early to turn off the spinner
+	cmdPFlags.Bool(constants.FlagQuiet, false, "Don't show the spinner or success messages; commands that create something print only its ID")
+	// like --profile, main also looks for --no-color before cobra runs, so that nothing printed early is coloured
+	cmdPFlags.Bool(constants.FlagNoColor, false, "Don't use colors or other styling in output. Also set by the NO_COLOR environment variable, or when output isn't a terminal")
+	cmdPFlags.Bool(constants.FlagIncludeSpace, false, `With --output-format json, wrap the output in an object giving the space the command ran against as "ActiveSpace", and the output itself as "Result"`)
+	cmdPFlags.String(constants.FlagEditor, "", "The `command` to run when a prompt opens an editor, such as for a description. Defaults to $VISUAL, then $EDITOR, then the Editor config setting")
+	// main also looks for --debug early, as the HTTP client is set up before cobra runs
+	cmdPFlags.Bool(constants.FlagDebug, false, "Log each request to the Octopus Server, with its status and timing, and how the space was chosen, to stderr. Credentials are masked. Also set by OCTOPUS_DEBUG")
+	cmdPFlags.Duration(constants.FlagTimeout, 0, "Give up if the command hasn't finished talking to the Octopus Server after this long, e.g. 90s or 30m. Defaults to a limit suited to the command")
 
 	// Legacy flags brought across from the .NET CLI.
 	// Consumers of these flags will have to explicitly check for them as well as the new
@@ -90,10 +122,12 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 
 	_ = viper.BindPFlag(constants.ConfigNoPrompt, cmdPFlags.Lookup(constants.FlagNoPrompt))
 	_ = viper.BindPFlag(constants.ConfigSpace, cmdPFlags.Lookup(constants.FlagSpace))
+	_ = viper.BindPFlag(constants.ConfigEditor, cmdPFlags.Lookup(constants.FlagEditor))
 	// if we attempt to check the flags before Execute is called, cobra hasn't parsed anything yet,
-	// so we'll get bad values. PersistentPreRun is a convenient callback for setting up our
+	// so we'll get bad values. PersistentPreRunE is a convenient callback for setting up our
 	// environment after parsing but before execution.
-	cmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
+	var cancelTimeout context.CancelFunc
+	cmd.PersistentPreRunE = func(c *cobra.Command, _ []string) error {
 		// map flag alias values
 		for k, v := range flagAliases {
 			for _, aliasName := range v {
@@ -105,17 +139,86 @@ func NewCmdRoot(f factory.Factory, clientFactory apiclient.ClientFactory, askPro
 			}
 		}
 
-		if noPrompt := viper.GetBool(constants.ConfigNoPrompt); noPrompt {
+		// the shell is waiting on tab completion to print its suggestions, so it must never stop to ask a question
+		if c.Name() == cobra.ShellCompRequestCmd || c.Name() == cobra.ShellCompNoDescRequestCmd {
+			askProvider.DisableInteractive()
+		}
+
+		forcePrompt, _ := cmdPFlags.GetBool(constants.FlagPrompt)
+		if forcePrompt && cmdPFlags.Changed(constants.FlagNoPrompt) {
+			return fmt.Errorf("--%s and --%s can't be used together", constants.FlagPrompt, constants.FlagNoPrompt)
+		}
+
+		// --prompt beats the CI environment variable, which is also bound to ConfigNoPrompt
+		if noPrompt := viper.GetBool(constants.ConfigNoPrompt); noPrompt && !forcePrompt {
 			askProvider.DisableInteractive()
 			if v, _ := cmdPFlags.GetString(constants.FlagOutputFormat); v == "" {
 				cmdPFlags.Set(constants.FlagOutputFormat, constants.OutputFormatBasic)
 			}
 		}
 
-		if spaceNameOrId := viper.GetString(constants.ConfigSpace); spaceNameOrId != "" {
-			clientFactory.SetSpaceNameOrId(spaceNameOrId)
+		if noColor, _ := cmdPFlags.GetBool(constants.FlagNoColor); noColor {
+			output.IsColorEnabled = false
+		}
+
+		if quiet, _ := cmdPFlags.GetBool(constants.FlagQuiet); quiet {
+			output.IsQuiet = true
+		}
+
+		if includeSpace, _ := cmdPFlags.GetBool(constants.FlagIncludeSpace); includeSpace && clientFactory != nil {
+			// asked for at print time, as the space is only worked out when the command first needs a client
+			output.ActiveSpace = func() *output.IdAndName {
+				space := clientFactory.GetActiveSpace()
+				if space == nil {
+					return nil
+				}
+				return &output.IdAndName{Id: space.GetID(), Name: space.Name}
+			}
+		}
+
+		if noCache, _ := cmdPFlags.GetBool(constants.FlagNoCache); noCache && clientFactory != nil {
+			clientFactory.DisableSpaceCache()
+		}
+
+		// Execute cancels the command's context when the user presses Ctrl-C; do this before the timeout wraps it
+		if clientFactory != nil {
+			clientFactory.CancelRequestsOn(c.Context().Done())
+		}
+		if timeout := EffectiveTimeout(c); timeout > 0 {
+			cancelTimeout = withTimeoutContext(c, timeout)
+			if clientFactory != nil {
+				clientFactory.SetCommandTimeout(timeout)
+			}
+		}
+
+		// the client factory has already picked up OCTOPUS_SPACE, the profile or the config file, so only
+		// an explicit --space should replace it; doing so also throws away any clients made for the old space
+		if cmdPFlags.Changed(constants.FlagSpace) && clientFactory != nil {
+			if spaceNameOrId, _ := cmdPFlags.GetString(constants.FlagSpace); spaceNameOrId != "" {
+				clientFactory.SetSpaceNameOrId(spaceNameOrId)
+			}
+		}
+
+		if describe, _ := cmdPFlags.GetBool(constants.FlagDescribe); describe {
+			describeInsteadOfRunning(c)
+			return nil
+		}
+
+		// better to say up front that the server is too old than for the command to fail part way through
+		if minimum := MinServerVersion(c); minimum != "" && clientFactory != nil {
+			return apiclient.RequireServerVersion(clientFactory, apiclient.NewRequester(c), minimum)
+		}
+		return nil
+	}
+
+	// stop the timeout's timer as soon as the command is done. Cobra skips this when the command fails, but then
+	// Execute cancels the context the timeout was made from, which stops it too
+	cmd.PersistentPostRun = func(*cobra.Command, []string) {
+		if cancelTimeout != nil {
+			cancelTimeout()
 		}
 	}
 
+	skipArgsWhenDescribing(cmd)
 	return cmd
 }
@@ -0,0 +1,195 @@
+package root_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoColorFlag(t *testing.T) {
+	// pretend we're on a terminal which would otherwise get colour
+	defer func(enabled bool) { output.IsColorEnabled = enabled }(output.IsColorEnabled)
+	output.IsColorEnabled = true
+
+	api, _ := testutil.NewMockServerAndAsker()
+	askProvider := question.NewAskProvider(nil)
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, fixtures.NewSpace("Spaces-1", "Default Space"), askProvider), nil, askProvider)
+	stdout := &bytes.Buffer{}
+	rootCmd.SetOut(stdout)
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		rootCmd.SetArgs([]string{"environment", "list", "--no-color", "-f", "table"})
+		return rootCmd.ExecuteC()
+	})
+
+	rootResource := testutil.NewRootResource()
+	api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+	api.ExpectRequest(t, "GET", "/api/Spaces-1/environments?take=100").RespondWith(&resources.Resources[*environments.Environment]{
+		Items: []*environments.Environment{fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev")},
+	})
+
+	_, err := testutil.ReceivePair(cmdReceiver)
+	assert.Nil(t, err)
+	assert.False(t, output.IsColorEnabled)
+	assert.Equal(t, "NAME  GUIDED FAILURE\nDev   false\n", stdout.String())
+}
+
+func TestSpaceFlag(t *testing.T) {
+	newClientFactory := func(t *testing.T, askProvider question.AskProvider) *apiclient.Client {
+		clientFactory, err := apiclient.NewClientFactory(nil, "http://server", "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX", "Env Space", askProvider)
+		assert.Nil(t, err)
+		return clientFactory.(*apiclient.Client)
+	}
+
+	t.Run("replaces the space from the environment", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		defer api.Close()
+		askProvider := question.NewAskProvider(nil)
+		clientFactory := newClientFactory(t, askProvider)
+		clientFactory.ActiveSpace = fixtures.NewSpace("Spaces-1", "Env Space")
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), clientFactory, askProvider)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetArgs([]string{"completion", "bash", "--space", "Flag Space"})
+
+		assert.Nil(t, rootCmd.Execute())
+		assert.Equal(t, "Flag Space", clientFactory.SpaceNameOrID)
+		assert.Nil(t, clientFactory.GetActiveSpace())
+	})
+
+	t.Run("leaves the space alone when not given", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		defer api.Close()
+		askProvider := question.NewAskProvider(nil)
+		clientFactory := newClientFactory(t, askProvider)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), clientFactory, askProvider)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetArgs([]string{"completion", "bash"})
+
+		assert.Nil(t, rootCmd.Execute())
+		assert.Equal(t, "Env Space", clientFactory.SpaceNameOrID)
+	})
+}
+
+func TestEditorFlag(t *testing.T) {
+	t.Setenv(constants.EnvVisual, "vi")
+	_ = viper.BindEnv(constants.ConfigEditor, constants.EnvVisual, constants.EnvEditor)
+
+	api := testutil.NewMockHttpServer()
+	defer api.Close()
+	askProvider := question.NewAskProvider(nil)
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"completion", "bash", "--editor", "code --wait"})
+
+	assert.Nil(t, rootCmd.Execute())
+	assert.Equal(t, "code --wait", viper.GetString(constants.ConfigEditor))
+}
+
+func TestNoCacheFlagWithoutClientFactory(t *testing.T) {
+	api := testutil.NewMockHttpServer()
+	defer api.Close()
+	askProvider := question.NewAskProvider(nil)
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"completion", "bash", "--no-cache"})
+
+	assert.Nil(t, rootCmd.Execute())
+}
+
+func TestMinServerVersion(t *testing.T) {
+	oldRoot := testutil.NewRootResource()
+	oldRoot.Version = "2020.6.4000"
+	oldRoot.Links["Self"] = "/api"
+
+	api := testutil.NewMockHttpServer()
+	askProvider := question.NewAskProvider(nil)
+	clientFactory, err := apiclient.NewClientFactory(testutil.NewMockHttpClientWithTransport(api), "http://server", "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX", "", askProvider)
+	assert.Nil(t, err)
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), clientFactory, askProvider)
+	ran := false
+	rootCmd.AddCommand(&cobra.Command{
+		Use:         "shiny",
+		Annotations: map[string]string{annotations.MinServerVersion: "2022.1"},
+		Run:         func(*cobra.Command, []string) { ran = true },
+	})
+
+	cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+		defer api.Close()
+		rootCmd.SetArgs([]string{"shiny"})
+		return rootCmd.ExecuteC()
+	})
+
+	api.ExpectRequest(t, "GET", "/api").RespondWith(oldRoot)
+	api.ExpectRequest(t, "GET", "/api").RespondWith(oldRoot)
+
+	_, err = testutil.ReceivePair(cmdReceiver)
+	assert.EqualError(t, err, "this command requires Octopus 2022.1 or later, but the Octopus Server is version 2020.6.4000")
+	assert.False(t, ran)
+}
+
+func TestTimeoutIsReleased(t *testing.T) {
+	api := testutil.NewMockHttpServer()
+	defer api.Close()
+	askProvider := question.NewAskProvider(nil)
+	rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+	var ctx context.Context
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "slow",
+		Run: func(c *cobra.Command, _ []string) { ctx = c.Context() },
+	})
+	rootCmd.SetArgs([]string{"slow", "--timeout", "1h"})
+
+	assert.Nil(t, rootCmd.ExecuteContext(context.Background()))
+	_, hasDeadline := ctx.Deadline()
+	assert.True(t, hasDeadline)
+	assert.Equal(t, context.Canceled, ctx.Err())
+}
+
+func TestPromptFlag(t *testing.T) {
+	t.Setenv(constants.EnvCI, "true")
+	_ = viper.BindEnv(constants.ConfigNoPrompt, constants.EnvCI)
+
+	run := func(args ...string) (question.AskProvider, error) {
+		api := testutil.NewMockHttpServer()
+		defer api.Close()
+		askProvider := question.NewAskProvider(testutil.NewAskMocker().AsAsker())
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(args)
+		return askProvider, rootCmd.Execute()
+	}
+
+	t.Run("CI turns off prompting", func(t *testing.T) {
+		askProvider, err := run("completion", "bash")
+		assert.Nil(t, err)
+		assert.False(t, askProvider.IsInteractive())
+	})
+
+	t.Run("--prompt keeps prompting on in CI", func(t *testing.T) {
+		askProvider, err := run("completion", "bash", "--prompt")
+		assert.Nil(t, err)
+		assert.True(t, askProvider.IsInteractive())
+	})
+
+	t.Run("--prompt and --no-prompt together are an error", func(t *testing.T) {
+		_, err := run("completion", "bash", "--prompt", "--no-prompt")
+		assert.EqualError(t, err, "--prompt and --no-prompt can't be used together")
+	})
+}
@@ -0,0 +1,17 @@
+package root
+
+import (
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/spf13/cobra"
+)
+
+// MinServerVersion is the oldest Octopus Server that cmd works with, as declared by the command or its nearest
+// parent. Empty means it works with any version.
+func MinServerVersion(cmd *cobra.Command) string {
+	for c := cmd; c != nil; c = c.Parent() {
+		if value, ok := c.Annotations[annotations.MinServerVersion]; ok {
+			return value
+		}
+	}
+	return ""
+}
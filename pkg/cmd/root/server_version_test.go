@@ -0,0 +1,34 @@
+package root_test
+
+import (
+	"strings"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinServerVersionOfCommands(t *testing.T) {
+	tests := []struct {
+		args    []string
+		minimum string
+	}{
+		{[]string{"project", "convert"}, "2022.1"},
+		{[]string{"project", "branch", "list"}, "2022.1"},
+		{[]string{"project", "list"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			defer api.Close()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+
+			c, _, err := rootCmd.Find(test.args)
+			assert.Nil(t, err)
+			assert.Equal(t, test.minimum, cmdRoot.MinServerVersion(c))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package root
+
+import (
+	"context"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/spf13/cobra"
+)
+
+// EffectiveTimeout is how long cmd may spend talking to the Octopus Server: the global --timeout if it was given,
+// otherwise the DefaultTimeout declared by the command or its nearest parent. Zero means there is no limit.
+func EffectiveTimeout(cmd *cobra.Command) time.Duration {
+	// a command may have its own --timeout which means something else (e.g. task wait), so only take the global one
+	if f := cmd.Flags().Lookup(constants.FlagTimeout); f != nil && f.Changed && f == cmd.Root().PersistentFlags().Lookup(constants.FlagTimeout) {
+		if timeout, err := cmd.Flags().GetDuration(constants.FlagTimeout); err == nil && timeout > 0 {
+			return timeout
+		}
+		return 0
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if value, ok := c.Annotations[annotations.DefaultTimeout]; ok {
+			timeout, _ := time.ParseDuration(value)
+			return timeout
+		}
+	}
+	return 0
+}
+
+// withTimeoutContext gives cmd a context which is done once timeout has passed, so that work which isn't an HTTP
+// request, such as waiting between polls of a server task, stops at the same time. Call the returned function once
+// cmd is done to release the timer; cancelling the context that cmd was executed with releases it too
+func withTimeoutContext(cmd *cobra.Command, timeout time.Duration) context.CancelFunc {
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	cmd.SetContext(ctx)
+	return cancel
+}
@@ -0,0 +1,41 @@
+package root_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		timeout string
+	}{
+		{"list commands use their default", []string{"environment", "list"}, "1m0s"},
+		{"--timeout overrides the command's default", []string{"environment", "list", "--timeout", "5m"}, "5m0s"},
+		{"commands without a default have no limit", []string{"environment", "create"}, ""},
+		{"--timeout applies to commands without a default", []string{"environment", "create", "--timeout", "90s"}, "1m30s"},
+		{"a command's own --timeout flag isn't the global one", []string{"task", "wait", "--timeout", "30"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			defer api.Close()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, nil, askProvider), nil, askProvider)
+			stdout := &bytes.Buffer{}
+			rootCmd.SetOut(stdout)
+			rootCmd.SetArgs(append(test.args, "--describe"))
+
+			assert.Nil(t, rootCmd.Execute())
+			description, err := testutil.ParseJsonStrict[cmdRoot.CommandDescription](stdout)
+			assert.Nil(t, err)
+			assert.Equal(t, test.timeout, description.Timeout)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
@@ -48,7 +49,8 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 			$ %[1]s runbook list --project SomeProject --limit 50 --filter SomeKeyword
 			$ %[1]s runbook ls -p SomeProject -n 30 -q SomeKeyword
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && listFlags.Project.Value == "" {
 				listFlags.Project.Value = args[0]
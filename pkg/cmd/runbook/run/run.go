@@ -20,6 +20,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/cli/pkg/surveyext"
+	"github.com/OctopusDeploy/cli/pkg/taskwait"
 	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
@@ -92,6 +93,8 @@ type RunFlags struct {
 	ForcePackageDownload *flag.Flag[bool]
 	RunTargets           *flag.Flag[[]string]
 	ExcludeTargets       *flag.Flag[[]string]
+	Wait                 *flag.Flag[bool]
+	WaitTimeout          *flag.Flag[time.Duration]
 }
 
 func NewRunFlags() *RunFlags {
@@ -110,6 +113,8 @@ func NewRunFlags() *RunFlags {
 		ForcePackageDownload: flag.New[bool](FlagForcePackageDownload, false),
 		RunTargets:           flag.New[[]string](FlagRunTarget, false),
 		ExcludeTargets:       flag.New[[]string](FlagExcludeRunTarget, false),
+		Wait:                 flag.New[bool](taskwait.FlagWait, false),
+		WaitTimeout:          flag.New[time.Duration](taskwait.FlagWaitTimeout, false),
 	}
 }
 
@@ -147,6 +152,7 @@ func NewCmdRun(f factory.Factory) *cobra.Command {
 	flags.BoolVarP(&runFlags.ForcePackageDownload.Value, runFlags.ForcePackageDownload.Name, "", false, "Force re-download of packages")
 	flags.StringSliceVarP(&runFlags.RunTargets.Value, runFlags.RunTargets.Name, "", nil, "Run on this target (can be specified multiple times)")
 	flags.StringSliceVarP(&runFlags.ExcludeTargets.Value, runFlags.ExcludeTargets.Name, "", nil, "Run on targets except for this (can be specified multiple times)")
+	taskwait.RegisterFlags(cmd, &runFlags.Wait.Value, &runFlags.WaitTimeout.Value)
 
 	flags.SortFlags = false
 
@@ -250,6 +256,7 @@ func runbookRun(cmd *cobra.Command, f factory.Factory, flags *RunFlags) error {
 			// we're deliberately adding --no-prompt to the generated cmdline so ForcePackageDownload=false will be missing,
 			// but that's fine
 			resolvedFlags.ForcePackageDownload.Value = options.ForcePackageDownload
+			resolvedFlags.Wait.Value = flags.Wait.Value
 
 			autoCmd := flag.GenerateAutomationCmd(constants.ExecutableName+" runbook run",
 				resolvedFlags.Project,
@@ -266,6 +273,7 @@ func runbookRun(cmd *cobra.Command, f factory.Factory, flags *RunFlags) error {
 				resolvedFlags.RunTargets,
 				resolvedFlags.ExcludeTargets,
 				resolvedFlags.Variables,
+				resolvedFlags.Wait,
 			)
 			cmd.Printf("\nAutomation Command: %s\n", autoCmd)
 
@@ -291,7 +299,7 @@ func runbookRun(cmd *cobra.Command, f factory.Factory, flags *RunFlags) error {
 			}
 
 		case constants.OutputFormatJson:
-			data, err := json.Marshal(options.Response.RunbookRunServerTasks)
+			data, err := json.Marshal(output.WrapJson(options.Response.RunbookRunServerTasks))
 			if err != nil { // shouldn't happen but fallback in case
 				cmd.PrintErrln(err)
 			} else {
@@ -301,6 +309,11 @@ func runbookRun(cmd *cobra.Command, f factory.Factory, flags *RunFlags) error {
 		default: // table
 			cmd.Printf("Successfully started %d runbook run(s)\n", len(options.Response.RunbookRunServerTasks))
 		}
+
+		if flags.Wait.Value {
+			taskIDs := util.SliceTransform(options.Response.RunbookRunServerTasks, func(t *runbooks.RunbookRunServerTask) string { return t.ServerTaskID })
+			return taskwait.WaitForCommand(cmd, outputFormat, octopus, taskIDs, flags.WaitTimeout.Value)
+		}
 	}
 
 	return nil
@@ -29,6 +29,9 @@ const (
 	FlagDescription = "description"
 	FlagTeam        = "team"
 	FlagUser        = "user"
+
+	FlagAliasSpaceManagersTeams = "space-managers-teams"
+	FlagAliasSpaceManagersUsers = "space-managers-users"
 )
 
 type CreateFlags struct {
@@ -74,10 +77,13 @@ func NewCreateFlags() *CreateFlags {
 func NewCmdCreate(f factory.Factory) *cobra.Command {
 	createFlags := NewCreateFlags()
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create a space",
-		Long:    "Create a space in Octopus Deploy",
-		Example: heredoc.Docf("$ %s space create", constants.ExecutableName),
+		Use:   "create",
+		Short: "Create a space",
+		Long:  "Create a space in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s space create
+			$ %[1]s space create --name "Platform" --description "Shared infrastructure" --space-managers-teams "Platform Team"
+		`, constants.ExecutableName),
 		Aliases: []string{"new"},
 		RunE: func(c *cobra.Command, args []string) error {
 			opts := NewCreateOptions(f, createFlags, c)
@@ -92,6 +98,15 @@ func NewCmdCreate(f factory.Factory) *cobra.Command {
 	flags.StringSliceVarP(&createFlags.Teams.Value, createFlags.Teams.Name, "t", nil, "The teams to manage the space (can be specified multiple times)")
 	flags.StringSliceVarP(&createFlags.Users.Value, createFlags.Users.Name, "u", nil, "The users to manage the space (can be specified multiple times)")
 
+	flagAliases := make(map[string][]string, 2)
+	util.AddFlagAliasesStringSlice(flags, FlagTeam, flagAliases, FlagAliasSpaceManagersTeams)
+	util.AddFlagAliasesStringSlice(flags, FlagUser, flagAliases, FlagAliasSpaceManagersUsers)
+
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		util.ApplyFlagAliases(cmd.Flags(), flagAliases)
+		return nil
+	}
+
 	return cmd
 }
 
@@ -101,6 +116,25 @@ func createRun(opts *CreateOptions) error {
 			return err
 		}
 	}
+
+	if opts.Name.Value == "" {
+		return fmt.Errorf("a name is required to create a space; use --%s", FlagName)
+	}
+	if len(opts.Teams.Value) == 0 && len(opts.Users.Value) == 0 {
+		return fmt.Errorf("a space needs at least one space manager; use --%s or --%s", FlagTeam, FlagUser)
+	}
+
+	// the server's own error for a duplicate name is a generic bad request, so check up front
+	existingSpaces, err := opts.GetAllSpacesCallback()
+	if err != nil {
+		return err
+	}
+	for _, existingSpace := range existingSpaces {
+		if strings.EqualFold(existingSpace.Name, opts.Name.Value) {
+			return fmt.Errorf("a space named '%s' already exists (%s)", existingSpace.Name, existingSpace.GetID())
+		}
+	}
+
 	space := spaces.NewSpace(opts.Name.Value)
 
 	allTeams, err := opts.Client.Teams.GetAll()
@@ -138,9 +172,9 @@ func createRun(opts *CreateOptions) error {
 		return err
 	}
 
-	fmt.Printf("%s The space, \"%s\" %s was created successfully.\n", output.Green("✔"), createdSpace.Name, output.Dimf("(%s)", createdSpace.ID))
+	_ = output.Successf(opts.Out, createdSpace.GetID(), "%s The space, \"%s\" %s was created successfully.\n", output.Green("✔"), createdSpace.Name, output.Dimf("(%s)", createdSpace.ID))
 
-	if !opts.NoPrompt {
+	if !opts.NoPrompt && !output.IsQuiet {
 		autoCmd := flag.GenerateAutomationCmd(opts.CmdPath, opts.Name, opts.Description, opts.Teams, opts.Users)
 		fmt.Fprintf(opts.Out, "\nAutomation Command: %s\n", autoCmd)
 	}
@@ -186,7 +220,7 @@ func selectTeams(ask question.Asker, getAllTeamsCallback shared.GetAllTeamsCallb
 	}, false)
 }
 
-func selectUsers(ask question.Asker, getAllUsersCallback shared.GetAllUsersCallback, message string) ([]*users.User, error) {
+func selectUsers(ask question.Asker, getAllUsersCallback shared.GetAllUsersCallback, message string, required bool) ([]*users.User, error) {
 	existingUsers, err := getAllUsersCallback()
 	if err != nil {
 		return nil, err
@@ -194,7 +228,7 @@ func selectUsers(ask question.Asker, getAllUsersCallback shared.GetAllUsersCallb
 
 	return question.MultiSelectMap(ask, message, existingUsers, func(existingUser *users.User) string {
 		return fmt.Sprintf("%s %s", existingUser.DisplayName, output.Dimf("(%s)", existingUser.Username))
-	}, false)
+	}, required)
 }
 
 func PromptMissing(opts *CreateOptions) error {
@@ -236,7 +270,8 @@ func PromptMissing(opts *CreateOptions) error {
 	}
 
 	if len(opts.Users.Value) == 0 {
-		selectedUsers, err := selectUsers(opts.Ask, opts.GetAllUsersCallback, "Select one or more users to manage this space:")
+		// a space must have at least one manager, so if no teams were picked there has to be a user
+		selectedUsers, err := selectUsers(opts.Ask, opts.GetAllUsersCallback, "Select one or more users to manage this space:", len(opts.Teams.Value) == 0)
 		if err != nil {
 			return err
 		}
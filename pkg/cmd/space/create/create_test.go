@@ -3,12 +3,16 @@ package create_test
 import (
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
 	"github.com/OctopusDeploy/cli/pkg/cmd/space/create"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
 	"github.com/OctopusDeploy/cli/test/testutil"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -79,6 +83,50 @@ func TestPromptMissing_NoOptionsSupplied(t *testing.T) {
 	assert.Equal(t, []string{vulcan.Username}, flags.Users.Value)
 }
 
+func TestSpaceCreate_Validation(t *testing.T) {
+	rootResource := testutil.NewRootResource()
+	existingSpace := fixtures.NewSpace("Spaces-1", "Explored space")
+
+	t.Run("rejects a name that is already taken", func(t *testing.T) {
+		api, _ := testutil.NewMockServerAndAsker()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, existingSpace, askProvider), nil, askProvider)
+
+		cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+			defer api.Close()
+			rootCmd.SetArgs([]string{"space", "create", "--no-prompt", "--name", "explored SPACE", "--space-managers-teams", "Crew"})
+			return rootCmd.ExecuteC()
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{existingSpace})
+
+		_, err := testutil.ReceivePair(cmdReceiver)
+		assert.EqualError(t, err, "a space named 'Explored space' already exists (Spaces-1)")
+	})
+
+	t.Run("requires at least one space manager", func(t *testing.T) {
+		api, _ := testutil.NewMockServerAndAsker()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, existingSpace, askProvider), nil, askProvider)
+
+		cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+			defer api.Close()
+			rootCmd.SetArgs([]string{"space", "create", "--no-prompt", "--name", "The Final Frontier"})
+			return rootCmd.ExecuteC()
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+
+		_, err := testutil.ReceivePair(cmdReceiver)
+		assert.EqualError(t, err, "a space needs at least one space manager; use --team or --user")
+	})
+}
+
 func formatUser(user *users.User) string {
 	return fmt.Sprintf("%s (%s)", user.DisplayName, output.Dimf(user.Username))
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
@@ -12,11 +13,12 @@ import (
 
 func NewCmdList(f factory.Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List spaces",
-		Long:    "List spaces in Octopus Deploy",
-		Example: heredoc.Docf("$ %s space list", constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Use:         "list",
+		Short:       "List spaces",
+		Long:        "List spaces in Octopus Deploy",
+		Example:     heredoc.Docf("$ %s space list", constants.ExecutableName),
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return listRun(f, cmd)
 		},
@@ -57,14 +59,14 @@ func listRun(f factory.Factory, cmd *cobra.Command) error {
 			}
 		},
 		Table: output.TableDefinition[*spaces.Space]{
-			Header: []string{"NAME", "DESCRIPTION", "TASK QUEUE"},
+			Header: []string{"NAME", "ID", "DESCRIPTION", "TASK QUEUE"},
 			Row: func(item *spaces.Space) []string {
 				taskQueue := output.Green("Running")
 				if item.TaskQueueStopped {
 					taskQueue = output.Yellow("Stopped")
 				}
 
-				return []string{output.Bold(item.Name), item.Description, taskQueue}
+				return []string{output.Bold(item.Name), item.GetID(), item.Description, taskQueue}
 			},
 		},
 		Basic: func(item *spaces.Space) string {
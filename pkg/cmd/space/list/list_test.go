@@ -0,0 +1,75 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func TestSpaceList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	space2 := fixtures.NewSpace("Spaces-2", "Stopped Space")
+	space2.Description = "Nothing runs here"
+	space2.TaskQueueStopped = true
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"prints a table with the id and task queue state", []string{"--no-color"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Contains(t, out.String(), "Spaces-1")
+			assert.Contains(t, out.String(), "Running")
+			assert.Contains(t, out.String(), "Spaces-2")
+			assert.Contains(t, out.String(), "Nothing runs here")
+			assert.Contains(t, out.String(), "Stopped")
+		}},
+		{"prints json", []string{"-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			type spaceAsJson struct {
+				Id          string
+				Name        string
+				Description string
+				TaskQueue   string
+			}
+			parsed, err := testutil.ParseJsonStrict[[]spaceAsJson](out)
+			assert.Nil(t, err)
+			assert.Equal(t, []spaceAsJson{
+				{Id: "Spaces-1", Name: "Default Space", TaskQueue: "Running"},
+				{Id: "Spaces-2", Name: "Stopped Space", Description: "Nothing runs here", TaskQueue: "Stopped"},
+			}, parsed)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"space", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{space1, space2})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+}
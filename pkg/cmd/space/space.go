@@ -23,6 +23,7 @@ func NewCmdSpace(f factory.Factory) *cobra.Command {
 		`, constants.ExecutableName),
 		Annotations: map[string]string{
 			annotations.IsConfiguration: "true",
+			annotations.IsSpaceless:     "true",
 		},
 	}
 
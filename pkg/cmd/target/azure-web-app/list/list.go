@@ -5,12 +5,14 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/cmd/target/list"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := list.NewListFlags()
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List Azure Web App deployment targets",
@@ -19,13 +21,15 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 			$ %[1]s deployment-target azure-web-app list
 			$ %[1]s deployment-target azure-web-app ls
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
 			dependencies := cmd.NewDependencies(f, c)
-			options := list.NewListOptions(dependencies, c, machines.MachinesQuery{DeploymentTargetTypes: []string{"AzureWebApp"}})
+			options := list.NewListOptions(dependencies, c, listFlags, machines.MachinesQuery{DeploymentTargetTypes: []string{"AzureWebApp"}})
 			return list.ListRun(options)
 		},
 	}
 
+	list.RegisterFlags(cmd, listFlags)
 	return cmd
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/executionscommon"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/machinescommon"
+	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/question"
 	"github.com/OctopusDeploy/cli/pkg/question/selectors"
 	"github.com/OctopusDeploy/cli/pkg/util"
@@ -464,8 +465,8 @@ func (opts *CreateOptions) Commit() error {
 		return err
 	}
 
-	fmt.Fprintf(opts.Out, "Successfully created Kubernetes deployment target '%s'.\n", deploymentTarget.Name)
-	if !opts.NoPrompt {
+	_ = output.Successf(opts.Out, createdTarget.GetID(), "Successfully created Kubernetes deployment target '%s'.\n", deploymentTarget.Name)
+	if !opts.NoPrompt && !output.IsQuiet {
 		autoCmd := flag.GenerateAutomationCmd(
 			opts.CmdPath,
 			opts.Name,
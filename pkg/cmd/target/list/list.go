@@ -1,21 +1,62 @@
 package list
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/account/helper"
 	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/machinescommon"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
+const (
+	FlagEnvironment = "environment"
+	FlagRole        = "role"
+	FlagHealth      = "health"
+)
+
+// HealthStatuses are the values the server accepts for a target's health, in the order the portal shows them
+var HealthStatuses = []string{"Healthy", "HasWarnings", "Unhealthy", "Unavailable", "Unknown"}
+
+type ListFlags struct {
+	Environment *flag.Flag[[]string]
+	Role        *flag.Flag[[]string]
+	Health      *flag.Flag[[]string]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		Environment: flag.New[[]string](FlagEnvironment, false),
+		Role:        flag.New[[]string](FlagRole, false),
+		Health:      flag.New[[]string](FlagHealth, false),
+	}
+}
+
+// RegisterFlags adds the filtering flags to a deployment target list command
+func RegisterFlags(cmd *cobra.Command, flags *ListFlags) {
+	cmd.Flags().StringSliceVarP(&flags.Environment.Value, flags.Environment.Name, "e", nil, "Only list deployment targets in this environment, by name or ID (can be specified multiple times)")
+	cmd.Flags().StringSliceVarP(&flags.Role.Value, flags.Role.Name, "r", nil, "Only list deployment targets with this role (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&flags.Health.Value, flags.Health.Name, nil, fmt.Sprintf("Only list deployment targets with this health status, one of %s (can be specified multiple times)", strings.Join(HealthStatuses, ", ")))
+}
+
 type ListOptions struct {
 	*cobra.Command
 	*cmd.Dependencies
 	*shared.GetTargetsOptions
+	*ListFlags
+
+	// Query is sent to the server to fetch the targets; ListRun adds the filters from ListFlags to it
+	Query machines.MachinesQuery
 }
 
 type Entity struct {
@@ -23,15 +64,23 @@ type Entity struct {
 	Name string `json:"Name"`
 }
 
-func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command, query machines.MachinesQuery) *ListOptions {
-	return &ListOptions{
-		Command:           command,
-		Dependencies:      dependencies,
-		GetTargetsOptions: shared.NewGetTargetsOptions(dependencies, query),
+func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command, flags *ListFlags, query machines.MachinesQuery) *ListOptions {
+	opts := &ListOptions{
+		Command:      command,
+		Dependencies: dependencies,
+		ListFlags:    flags,
+		Query:        query,
+	}
+	opts.GetTargetsOptions = &shared.GetTargetsOptions{
+		GetTargetsCallback: func() ([]*machines.DeploymentTarget, error) {
+			return shared.GetAllTargets(*dependencies.Client, opts.Query)
+		},
 	}
+	return opts
 }
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List deployment targets",
@@ -39,17 +88,30 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 		Example: heredoc.Docf(`
 			$ %[1]s deployment-target list
 			$ %[1]s deployment-target ls
+			$ %[1]s deployment-target list --environment Production --role web
+			$ %[1]s deployment-target list --health Unhealthy --health Unavailable
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
-			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c, machines.MachinesQuery{}))
+			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c, listFlags, machines.MachinesQuery{}))
 		},
 	}
 
+	RegisterFlags(cmd, listFlags)
 	return cmd
 }
 
 func ListRun(opts *ListOptions) error {
+	allEnvironments, err := opts.Client.Environments.GetAll()
+	if err != nil {
+		return err
+	}
+
+	if err := applyFilters(opts, allEnvironments); err != nil {
+		return err
+	}
+
 	allTargets, err := opts.GetTargetsCallback()
 	if err != nil {
 		return err
@@ -61,13 +123,14 @@ func ListRun(opts *ListOptions) error {
 		Type         string   `json:"Type"`
 		Roles        []string `json:"Roles"`
 		Environments []Entity `json:"Environments"`
+		HealthStatus string   `json:"HealthStatus"`
 		Tenants      []Entity `json:"Tenants"`
 		TenantTags   []string `json:"TenantTags"`
 	}
 
-	environmentMap, err := GetEnvironmentMap(opts)
-	if err != nil {
-		return err
+	environmentMap := make(map[string]string, len(allEnvironments))
+	for _, e := range allEnvironments {
+		environmentMap[e.GetID()] = e.GetName()
 	}
 
 	tenantMap, err := GetTenantMap(opts)
@@ -85,16 +148,17 @@ func ListRun(opts *ListOptions) error {
 				Type:         machinescommon.CommunicationStyleToDeploymentTargetTypeMap[item.Endpoint.GetCommunicationStyle()],
 				Roles:        item.Roles,
 				Environments: environments,
+				HealthStatus: item.HealthStatus,
 				Tenants:      tenants,
 				TenantTags:   item.TenantTags,
 			}
 		},
 		Table: output.TableDefinition[*machines.DeploymentTarget]{
-			Header: []string{"NAME", "TYPE", "ROLES", "ENVIRONMENTS", "TENANTS", "TAGS"},
+			Header: []string{"NAME", "TYPE", "ROLES", "ENVIRONMENTS", "HEALTH", "TENANTS", "TAGS"},
 			Row: func(item *machines.DeploymentTarget) []string {
 				environmentNames := resolveValues(item.EnvironmentIDs, environmentMap)
 				tenantNames := resolveValues(item.TenantIDs, tenantMap)
-				return []string{output.Bold(item.Name), machinescommon.CommunicationStyleToDescriptionMap[item.Endpoint.GetCommunicationStyle()], output.FormatAsList(item.Roles), output.FormatAsList(environmentNames), output.FormatAsList(tenantNames), output.FormatAsList(item.TenantTags)}
+				return []string{output.Bold(item.Name), machinescommon.CommunicationStyleToDescriptionMap[item.Endpoint.GetCommunicationStyle()], output.FormatAsList(item.Roles), output.FormatAsList(environmentNames), shared.FormatHealthStatus(item), output.FormatAsList(tenantNames), output.FormatAsList(item.TenantTags)}
 			},
 		},
 		Basic: func(item *machines.DeploymentTarget) string {
@@ -103,6 +167,46 @@ func ListRun(opts *ListOptions) error {
 	})
 }
 
+// applyFilters adds the environments, roles and health statuses given in the flags to the query, so the server
+// does the filtering. Environments can be given by name or ID
+func applyFilters(opts *ListOptions, allEnvironments []*environments.Environment) error {
+	if opts.ListFlags == nil {
+		return nil
+	}
+
+	if len(opts.Environment.Value) > 0 {
+		resolver := helper.NewEnvironmentResolver(opts.Client, false)
+		resolver.GetAllEnvironments = func() ([]*environments.Environment, error) { return allEnvironments, nil }
+		environmentIds, err := resolver.Resolve(opts.Environment.Value)
+		if err != nil {
+			return err
+		}
+		opts.Query.EnvironmentIDs = environmentIds
+	}
+
+	if len(opts.Role.Value) > 0 {
+		opts.Query.Roles = opts.Role.Value
+	}
+
+	for _, health := range opts.Health.Value {
+		status, ok := findHealthStatus(health)
+		if !ok {
+			return fmt.Errorf("'%s' is not a valid value for --%s; use one of %s", health, opts.Health.Name, strings.Join(HealthStatuses, ", "))
+		}
+		opts.Query.HealthStatuses = append(opts.Query.HealthStatuses, status)
+	}
+	return nil
+}
+
+func findHealthStatus(value string) (string, bool) {
+	for _, status := range HealthStatuses {
+		if strings.EqualFold(status, value) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
 func resolveValues(keys []string, lookup map[string]string) []string {
 	var values []string
 	for _, key := range keys {
@@ -120,18 +224,6 @@ func resolveEntities(keys []string, lookup map[string]string) []Entity {
 	return entities
 }
 
-func GetEnvironmentMap(opts *ListOptions) (map[string]string, error) {
-	environmentMap := make(map[string]string)
-	allEnvs, err := opts.Client.Environments.GetAll()
-	if err != nil {
-		return nil, err
-	}
-	for _, e := range allEnvs {
-		environmentMap[e.GetID()] = e.GetName()
-	}
-	return environmentMap, nil
-}
-
 func GetTenantMap(opts *ListOptions) (map[string]string, error) {
 	tenantMap := make(map[string]string)
 	allEnvs, err := opts.Client.Tenants.GetAll()
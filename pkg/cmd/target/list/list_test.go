@@ -0,0 +1,149 @@
+package list_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func newTarget(id string, name string, health string, environmentIds []string, roles ...string) *machines.DeploymentTarget {
+	target := machines.NewDeploymentTarget(name, machines.NewListeningTentacleEndpoint(&url.URL{Scheme: "https", Host: name}, "thumbprint"), environmentIds, roles)
+	target.ID = id
+	target.HealthStatus = health
+	return target
+}
+
+func TestTargetList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	allEnvironments := []*environments.Environment{
+		fixtures.NewEnvironment("Spaces-1", "Environments-1", "Dev"),
+		fixtures.NewEnvironment("Spaces-1", "Environments-2", "Production"),
+	}
+
+	web01 := newTarget("Machines-1", "web01", "Healthy", []string{"Environments-1", "Environments-2"}, "web")
+	db01 := newTarget("Machines-2", "db01", "Unhealthy", []string{"Environments-2"}, "db")
+	web02 := newTarget("Machines-3", "web02", "Healthy", []string{"Environments-1"}, "Web", "cache")
+	respondWith := func(targets ...*machines.DeploymentTarget) *resources.Resources[*machines.DeploymentTarget] {
+		return &resources.Resources[*machines.DeploymentTarget]{Items: targets}
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		query   string
+		targets *resources.Resources[*machines.DeploymentTarget]
+		verify  func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"prints a table with environment names and health", []string{"--no-color"}, "take=2147483647", respondWith(web01, db01, web02), func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "NAME   TYPE                ROLES       ENVIRONMENTS     HEALTH     TENANTS  TAGS\n"+
+				"web01  Listening Tentacle  web         Dev, Production  Healthy             \n"+
+				"db01   Listening Tentacle  db          Production       Unhealthy           \n"+
+				"web02  Listening Tentacle  Web, cache  Dev              Healthy             \n", out.String())
+		}},
+		{"filters by environment name", []string{"--environment", "production", "-f", "basic"}, "environmentIds=Environments-2&take=2147483647", respondWith(web01, db01), func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "web01\ndb01\n", out.String())
+		}},
+		{"filters by environment ID and role", []string{"-e", "Environments-1", "--role", "web", "-f", "basic"}, "environmentIds=Environments-1&roles=web&take=2147483647", respondWith(web01, web02), func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "web01\nweb02\n", out.String())
+		}},
+		{"matches any of several roles", []string{"--role", "db", "--role", "cache", "-f", "basic"}, "roles=db%2Ccache&take=2147483647", respondWith(db01, web02), func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "db01\nweb02\n", out.String())
+		}},
+		{"filters by health, ignoring case", []string{"--health", "unhealthy", "--health", "HasWarnings", "-f", "basic"}, "healthStatuses=Unhealthy%2CHasWarnings&take=2147483647", respondWith(db01), func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "db01\n", out.String())
+		}},
+		{"includes health and environments in json", []string{"--role", "db", "-f", "json"}, "roles=db&take=2147483647", respondWith(db01), func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			type entity struct {
+				Id   string
+				Name string
+			}
+			parsed, err := testutil.ParseJsonStrict[[]struct {
+				Id           string
+				Name         string
+				Type         string
+				Roles        []string
+				Environments []entity
+				HealthStatus string
+				Tenants      []entity
+				TenantTags   []string
+			}](out)
+			assert.Nil(t, err)
+			assert.Len(t, parsed, 1)
+			assert.Equal(t, "Machines-2", parsed[0].Id)
+			assert.Equal(t, "Unhealthy", parsed[0].HealthStatus)
+			assert.Equal(t, []entity{{Id: "Environments-2", Name: "Production"}}, parsed[0].Environments)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"deployment-target", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/machines?"+test.query).RespondWith(test.targets)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/tenants/all").RespondWith([]*tenants.Tenant{})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+
+	for _, test := range []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{"rejects an unknown environment", []string{"--environment", "Staging"}, "1 error occurred:\n\t* cannot find an environment with name or ID of 'Staging'\n\n"},
+		{"rejects an unknown health status", []string{"--health", "Sick"}, "'Sick' is not a valid value for --health; use one of Healthy, HasWarnings, Unhealthy, Unavailable, Unknown"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"deployment-target", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith(allEnvironments)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package shared
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/machinescommon"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services/api"
 )
 
 type ContributeEndpointCallback func(opts *ViewOptions, endpoint machines.IEndpoint) ([]*output.DataRow, error)
@@ -41,11 +46,32 @@ func ViewRun(opts *ViewOptions, contributeEndpoint ContributeEndpointCallback, d
 		return err
 	}
 
+	if opts.OutputFormat == constants.OutputFormatJson {
+		data, err := json.MarshalIndent(output.WrapJson(target), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.Out, string(data))
+		return err
+	}
+
+	connectionStatus, err := getConnectionStatus(opts, target)
+	if err != nil {
+		return err
+	}
+
 	data := []*output.DataRow{}
 
 	data = append(data, output.NewDataRow("Name", fmt.Sprintf("%s %s", output.Bold(target.Name), output.Dimf("(%s)", target.GetID()))))
-	data = append(data, output.NewDataRow("Health status", getHealthStatus(target)))
+	data = append(data, output.NewDataRow("Type", description))
+	data = append(data, output.NewDataRow("Health status", FormatHealthStatus(target)))
 	data = append(data, output.NewDataRow("Current status", target.StatusSummary))
+	if connectionStatus != nil && !connectionStatus.LastChecked.IsZero() {
+		data = append(data, output.NewDataRow("Last health check", connectionStatus.LastChecked.Format(time.RFC1123Z)))
+		if message := lastErrorMessage(connectionStatus); message != "" {
+			data = append(data, output.NewDataRow("Health check error", output.Red(message)))
+		}
+	}
 
 	if contributeEndpoint != nil {
 		newRows, err := contributeEndpoint(opts, target.Endpoint)
@@ -97,6 +123,30 @@ func ViewRun(opts *ViewOptions, contributeEndpoint ContributeEndpointCallback, d
 	return nil
 }
 
+// getConnectionStatus loads the result of the target's last health check, or returns nil if the server
+// doesn't link to one
+func getConnectionStatus(opts *ViewOptions, target *machines.DeploymentTarget) (*machines.MachineConnectionStatus, error) {
+	link := target.Links["Connection"]
+	if link == "" {
+		return nil, nil
+	}
+	resp, err := api.ApiGet(opts.Client.Sling(), machines.NewMachineConnectionStatus(), link)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*machines.MachineConnectionStatus), nil
+}
+
+func lastErrorMessage(connectionStatus *machines.MachineConnectionStatus) string {
+	for i := len(connectionStatus.Logs) - 1; i >= 0; i-- {
+		logElement := connectionStatus.Logs[i]
+		if logElement.Category == "Error" || logElement.Category == "Fatal" {
+			return logElement.MessageText
+		}
+	}
+	return ""
+}
+
 func ContributeProxy(opts *ViewOptions, proxyID string) ([]*output.DataRow, error) {
 	if proxyID != "" {
 		proxy, err := opts.Client.Proxies.GetById(proxyID)
@@ -118,7 +168,7 @@ func ContributeAccount(opts *ViewOptions, accountID string) ([]*output.DataRow,
 	return data, nil
 }
 
-func getHealthStatus(target *machines.DeploymentTarget) string {
+func FormatHealthStatus(target *machines.DeploymentTarget) string {
 	switch target.HealthStatus {
 	case "Healthy":
 		return output.Green(target.HealthStatus)
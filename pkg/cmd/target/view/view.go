@@ -29,6 +29,7 @@ func NewCmdView(f factory.Factory) *cobra.Command {
 		Example: heredoc.Docf(`
 			$ %[1]s deployment-target view Machines-100
 			$ %[1]s deployment-target view 'web-server'
+			$ %[1]s deployment-target view Machines-100 --output-format json
 		`, constants.ExecutableName),
 		RunE: func(c *cobra.Command, args []string) error {
 			return ViewRun(shared.NewViewOptions(flags, cmd.NewDependencies(f, c), args))
@@ -0,0 +1,101 @@
+package view_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/cmd"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/shared"
+	"github.com/OctopusDeploy/cli/pkg/cmd/target/view"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	octopusApiClient "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverUrl, _ = url.Parse("http://server")
+
+const placeholderApiKey = "API-XXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+var rootResource = testutil.NewRootResource()
+
+func TestTargetView(t *testing.T) {
+	production := fixtures.NewEnvironment("Spaces-1", "Environments-2", "Production")
+	endpoint := machines.NewListeningTentacleEndpoint(&url.URL{Scheme: "https", Host: "web01:10933"}, "thumbprint")
+	endpoint.TentacleVersionDetails = machines.NewTentacleVersionDetails("6.3.417", false, false, false)
+	target := machines.NewDeploymentTarget("web01", endpoint, []string{"Environments-2"}, []string{"web"})
+	target.ID = "Machines-1"
+	target.HealthStatus = "Unhealthy"
+	target.StatusSummary = "The machine is offline"
+	target.Links = map[string]string{"Connection": "/api/Spaces-1/machines/Machines-1/connection"}
+
+	lastChecked := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	connectionStatus := machines.NewMachineConnectionStatus()
+	connectionStatus.LastChecked = lastChecked
+	connectionStatus.Logs = []*machines.ActivityLogElement{
+		{Category: "Info", MessageText: "Checking health"},
+		{Category: "Error", MessageText: "No connection could be made because the target machine actively refused it"},
+		{Category: "Info", MessageText: "Done"},
+	}
+
+	t.Run("prints the type, health and last health check", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		out := &bytes.Buffer{}
+		opts := shared.NewViewOptions(shared.NewViewFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out}, []string{"Machines-1"})
+
+		errReceiver := testutil.GoBegin(func() error {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Spaces-1")
+			opts.Client = octopus
+			return view.ViewRun(opts)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/machines/Machines-1").RespondWith(target)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/machines/Machines-1").RespondWith(target)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/machines/Machines-1/connection").RespondWith(connectionStatus)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/environments/all").RespondWith([]*environments.Environment{production})
+
+		err := <-errReceiver
+		assert.Nil(t, err)
+		assert.Contains(t, out.String(), "Type                Listening Tentacle\n")
+		assert.Contains(t, out.String(), "Health status       Unhealthy\n")
+		assert.Contains(t, out.String(), "Last health check   "+lastChecked.Format(time.RFC1123Z)+"\n")
+		assert.Contains(t, out.String(), "Health check error  No connection could be made because the target machine actively refused it\n")
+		assert.Contains(t, out.String(), "Environments        Production\n")
+	})
+
+	t.Run("prints the full machine as json", func(t *testing.T) {
+		api := testutil.NewMockHttpServer()
+		out := &bytes.Buffer{}
+		opts := shared.NewViewOptions(shared.NewViewFlags(), &cmd.Dependencies{Space: fixtures.NewSpace("Spaces-1", "testspace"), Out: out, OutputFormat: constants.OutputFormatJson}, []string{"Machines-1"})
+
+		errReceiver := testutil.GoBegin(func() error {
+			defer api.Close()
+			octopus, _ := octopusApiClient.NewClient(testutil.NewMockHttpClientWithTransport(api), serverUrl, placeholderApiKey, "Spaces-1")
+			opts.Client = octopus
+			return view.ViewRun(opts)
+		})
+
+		api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/machines/Machines-1").RespondWith(target)
+		api.ExpectRequest(t, "GET", "/api/Spaces-1/machines/Machines-1").RespondWith(target)
+
+		err := <-errReceiver
+		assert.Nil(t, err)
+		parsed, err := testutil.ParseJsonStrict[map[string]any](out)
+		assert.Nil(t, err)
+		assert.Equal(t, "Machines-1", parsed["Id"])
+		assert.Equal(t, "web01", parsed["Name"])
+		assert.Equal(t, "Unhealthy", parsed["HealthStatus"])
+		assert.Equal(t, []any{"web"}, parsed["Roles"])
+		assert.Equal(t, "TentaclePassive", parsed["Endpoint"].(map[string]any)["CommunicationStyle"])
+	})
+}
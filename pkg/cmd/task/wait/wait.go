@@ -1,7 +1,7 @@
 package wait
 
 import (
-	"fmt"
+	"context"
 	"io"
 	"time"
 
@@ -9,9 +9,9 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/constants"
 	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/taskwait"
 	"github.com/OctopusDeploy/cli/pkg/util"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/spf13/cobra"
 )
 
@@ -34,7 +34,7 @@ func NewWaitOps(dependencies *cmd.Dependencies, taskIDs []string) *WaitOptions {
 	}
 }
 
-type ServerTasksCallback func([]string) ([]*tasks.Task, error)
+type ServerTasksCallback = taskwait.ServerTasksCallback
 
 func NewCmdWait(f factory.Factory) *cobra.Command {
 	var timeout int
@@ -52,7 +52,7 @@ func NewCmdWait(f factory.Factory) *cobra.Command {
 			dependencies := cmd.NewDependencies(f, c)
 			opts := NewWaitOps(dependencies, taskIDs)
 
-			return WaitRun(opts.Out, taskIDs, opts.GetServerTasksCallback, timeout)
+			return WaitRun(c.Context(), opts.Out, taskIDs, opts.GetServerTasksCallback, timeout)
 		},
 	}
 
@@ -62,89 +62,11 @@ func NewCmdWait(f factory.Factory) *cobra.Command {
 	return cmd
 }
 
-func WaitRun(out io.Writer, taskIDs []string, getServerTasksCallback ServerTasksCallback, timeout int) error {
-	if len(taskIDs) == 0 {
-		return fmt.Errorf("no server task IDs provided, at least one is required")
-	}
-	tasks, err := getServerTasksCallback(taskIDs)
-	if err != nil {
-		return err
-	}
-
-	if len(tasks) == 0 {
-		return fmt.Errorf("no server tasks found")
-	}
-
-	pendingTaskIDs := make([]string, 0)
-	for _, t := range tasks {
-		if t.IsCompleted == nil || !*t.IsCompleted {
-			pendingTaskIDs = append(pendingTaskIDs, t.ID)
-		}
-		fmt.Fprintf(out, "%s: %s\n", t.Description, t.State)
-	}
-
-	if len(pendingTaskIDs) == 0 {
-		return nil
-	}
-
-	gotError := make(chan error, 1)
-	done := make(chan bool, 1)
-	go func() {
-		for len(pendingTaskIDs) != 0 {
-			time.Sleep(5 * time.Second)
-			tasks, err = getServerTasksCallback(pendingTaskIDs)
-			if err != nil {
-				gotError <- err
-				return
-			}
-			for _, t := range tasks {
-				if t.IsCompleted != nil && *t.IsCompleted {
-					fmt.Fprintf(out, "%s: %s\n", t.Description, t.State)
-					pendingTaskIDs = removeTaskID(pendingTaskIDs, t.ID)
-				}
-			}
-		}
-		done <- true
-	}()
-
-	select {
-	case <-done:
-		return nil
-	case err := <-gotError:
-		return err
-	case <-time.After(time.Duration(timeout) * time.Second):
-		return fmt.Errorf("timeout while waiting for pending tasks")
-	}
-
+func WaitRun(ctx context.Context, out io.Writer, taskIDs []string, getServerTasksCallback ServerTasksCallback, timeout int) error {
+	_, err := taskwait.Wait(ctx, out, taskIDs, getServerTasksCallback, time.Duration(timeout)*time.Second)
+	return err
 }
 
 func GetServerTasksCallback(octopus *client.Client) ServerTasksCallback {
-	return func(taskIDs []string) ([]*tasks.Task, error) {
-		query := tasks.TasksQuery{
-			IDs: taskIDs,
-		}
-
-		resourceTasks, err := octopus.Tasks.Get(query)
-		if err != nil {
-			return nil, err
-		}
-
-		tasks, err := resourceTasks.GetAllPages(octopus.Sling())
-		if err != nil {
-			return nil, err
-		}
-
-		return tasks, nil
-	}
-}
-
-func removeTaskID(taskIDs []string, taskID string) []string {
-	for i, p := range taskIDs {
-		if p == taskID {
-			taskIDs[i] = taskIDs[len(taskIDs)-1]
-			taskIDs = taskIDs[:len(taskIDs)-1]
-			break
-		}
-	}
-	return taskIDs
+	return taskwait.NewServerTasksCallback(octopus)
 }
@@ -2,6 +2,7 @@ package wait_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/url"
 	"testing"
@@ -63,7 +64,7 @@ func TestWait(t *testing.T) {
 		}
 		return nil, fmt.Errorf("getServerTaskCallback was called more then the expected amount of times")
 	}
-	err := taskWaitCreate.WaitRun(&out, defaultTaskIDs, getServerTaskCallback, taskWaitCreate.DefaultTimeout)
+	err := taskWaitCreate.WaitRun(context.Background(), &out, defaultTaskIDs, getServerTaskCallback, taskWaitCreate.DefaultTimeout)
 	assert.NoError(t, err)
 	assert.Equal(t, 2, timesCalled)
 	expectedOutput := heredoc.Doc(`
@@ -0,0 +1,94 @@
+package list
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/spf13/cobra"
+)
+
+// scopeSystem is shown for teams which aren't scoped to a space, and so apply across the whole instance
+const scopeSystem = "System"
+
+type TeamAsJson struct {
+	Id          string `json:"Id"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+	SpaceId     string `json:"SpaceId"`
+	SpaceName   string `json:"SpaceName"`
+}
+
+func NewCmdList(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List teams",
+		Long:  "List the teams in Octopus Deploy, in every space as well as system teams",
+		Example: heredoc.Docf(`
+			$ %[1]s team list
+			$ %[1]s team ls --output-format json
+		`, constants.ExecutableName),
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listRun(f, cmd)
+		},
+	}
+
+	return cmd
+}
+
+func listRun(f factory.Factory, cmd *cobra.Command) error {
+	// teams can be scoped to a space, or to none, so they are listed with the system client
+	client, err := f.GetSystemClient(apiclient.NewRequester(cmd))
+	if err != nil {
+		return err
+	}
+
+	allTeams, err := client.Teams.GetAll()
+	if err != nil {
+		return err
+	}
+	allSpaces, err := client.Spaces.GetAll()
+	if err != nil {
+		return err
+	}
+	spaceNames := make(map[string]string, len(allSpaces))
+	for _, space := range allSpaces {
+		spaceNames[space.GetID()] = space.Name
+	}
+
+	return output.PrintArray(allTeams, cmd, output.Mappers[*teams.Team]{
+		Json: func(item *teams.Team) any {
+			return TeamAsJson{
+				Id:          item.GetID(),
+				Name:        item.Name,
+				Description: item.Description,
+				SpaceId:     item.SpaceID,
+				SpaceName:   spaceNames[item.SpaceID],
+			}
+		},
+		Table: output.TableDefinition[*teams.Team]{
+			Header: []string{"NAME", "ID", "SCOPE", "DESCRIPTION"},
+			Row: func(item *teams.Team) []string {
+				return []string{output.Bold(item.Name), item.GetID(), formatScope(item, spaceNames), item.Description}
+			},
+		},
+		Basic: func(item *teams.Team) string {
+			return item.Name
+		},
+	})
+}
+
+func formatScope(team *teams.Team, spaceNames map[string]string) string {
+	if team.SpaceID == "" {
+		return scopeSystem
+	}
+	if name, ok := spaceNames[team.SpaceID]; ok {
+		return name
+	}
+	return team.SpaceID
+}
@@ -0,0 +1,92 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func newTeam(id string, name string, spaceId string, description string) *teams.Team {
+	team := teams.NewTeam(name)
+	team.ID = id
+	team.SpaceID = spaceId
+	team.Description = description
+	return team
+}
+
+func TestTeamList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	allTeams := []*teams.Team{
+		newTeam("teams-administrators", "Octopus Administrators", "", "Can do everything"),
+		newTeam("Teams-1", "Developers", "Spaces-1", "Build and deploy"),
+		newTeam("Teams-2", "Auditors", "Spaces-9", ""),
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"prints a table with the scope of each team", []string{"--no-color"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "NAME                    ID                    SCOPE          DESCRIPTION\n"+
+				"Octopus Administrators  teams-administrators  System         Can do everything\n"+
+				"Developers              Teams-1               Default Space  Build and deploy\n"+
+				"Auditors                Teams-2               Spaces-9       \n", out.String())
+		}},
+		{"prints names", []string{"-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "Octopus Administrators\nDevelopers\nAuditors\n", out.String())
+		}},
+		{"prints json", []string{"-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			type teamAsJson struct {
+				Id          string
+				Name        string
+				Description string
+				SpaceId     string
+				SpaceName   string
+			}
+			parsed, err := testutil.ParseJsonStrict[[]teamAsJson](out)
+			assert.Nil(t, err)
+			assert.Equal(t, []teamAsJson{
+				{Id: "teams-administrators", Name: "Octopus Administrators", Description: "Can do everything"},
+				{Id: "Teams-1", Name: "Developers", Description: "Build and deploy", SpaceId: "Spaces-1", SpaceName: "Default Space"},
+				{Id: "Teams-2", Name: "Auditors", SpaceId: "Spaces-9"},
+			}, parsed)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"team", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/teams/all").RespondWith(allTeams)
+			api.ExpectRequest(t, "GET", "/api/spaces/all").RespondWith([]*spaces.Space{space1})
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+}
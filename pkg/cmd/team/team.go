@@ -0,0 +1,29 @@
+package team
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdList "github.com/OctopusDeploy/cli/pkg/cmd/team/list"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTeam(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "team <command>",
+		Short: "Manage teams",
+		Long:  "Manage teams in Octopus Deploy",
+		Example: heredoc.Docf(`
+			$ %[1]s team list
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsConfiguration: "true",
+			annotations.IsSpaceless:     "true",
+		},
+	}
+
+	cmd.AddCommand(cmdList.NewCmdList(f))
+
+	return cmd
+}
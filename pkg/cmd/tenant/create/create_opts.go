@@ -39,10 +39,13 @@ func (co *CreateOptions) Commit() error {
 		return err
 	}
 
-	_, err = fmt.Fprintf(co.Out, "\nSuccessfully created tenant %s (%s).\n", createdTenant.Name, createdTenant.ID)
+	err = output.Successf(co.Out, createdTenant.GetID(), "\nSuccessfully created tenant %s (%s).\n", createdTenant.Name, createdTenant.ID)
 	if err != nil {
 		return err
 	}
+	if output.IsQuiet {
+		return nil
+	}
 
 	link := output.Bluef("%s/app#/%s/tenants/%s/overview", co.Host, co.Space.GetID(), createdTenant.GetID())
 	fmt.Fprintf(co.Out, "View this tenant on Octopus Deploy: %s\n", link)
@@ -51,7 +54,7 @@ func (co *CreateOptions) Commit() error {
 }
 
 func (co *CreateOptions) GenerateAutomationCmd() {
-	if !co.NoPrompt {
+	if !co.NoPrompt && !output.IsQuiet {
 		autoCmd := flag.GenerateAutomationCmd(co.CmdPath, co.Name, co.Description, co.Tag)
 		fmt.Fprintf(co.Out, "%s\n", autoCmd)
 	}
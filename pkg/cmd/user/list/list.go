@@ -1,42 +1,74 @@
 package list
 
 import (
+	"strings"
+
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
 	"github.com/spf13/cobra"
 )
 
+const (
+	FlagFilter    = "filter"
+	FlagShowEmail = "show-email"
+)
+
+type ListFlags struct {
+	Filter    *flag.Flag[string]
+	ShowEmail *flag.Flag[bool]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		Filter:    flag.New[string](FlagFilter, false),
+		ShowEmail: flag.New[bool](FlagShowEmail, false),
+	}
+}
+
 type UserAsJson struct {
-	Id          string `json:"Id"`
-	Name        string `json:"Name"`
-	UserName    string `json:"UserName"`
-	Description string `json:"Description"`
+	Id           string `json:"Id"`
+	Name         string `json:"Name"`
+	UserName     string `json:"UserName"`
+	Description  string `json:"Description"`
+	EmailAddress string `json:"EmailAddress,omitempty"`
+	IsActive     bool   `json:"IsActive"`
+	IsService    bool   `json:"IsService"`
 }
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List users",
-		Long:  "List users in Octopus Deploy",
+		Long:  "List users in Octopus Deploy. Email addresses are left out unless --show-email is given.",
 		Example: heredoc.Docf(`
 			$ %[1]s user list
 			$ %[1]s user ls
+			$ %[1]s user list --filter jane --show-email
+			$ %[1]s user list --output-format json
 		`, constants.ExecutableName),
-		Aliases: []string{"ls"},
+		Aliases:     []string{"ls"},
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return listRun(cmd, f)
+			return listRun(cmd, f, listFlags)
 		},
 	}
 
+	flags := cmd.Flags()
+	flags.StringVar(&listFlags.Filter.Value, listFlags.Filter.Name, "", "only list users whose username or display name contains this text, ignoring case")
+	flags.BoolVar(&listFlags.ShowEmail.Value, listFlags.ShowEmail.Name, false, "include email addresses, which are hidden by default")
 	return cmd
 }
 
-func listRun(cmd *cobra.Command, f factory.Factory) error {
-	client, err := f.GetSpacedClient(apiclient.NewRequester(cmd))
+func listRun(cmd *cobra.Command, f factory.Factory, flags *ListFlags) error {
+	// users belong to the instance rather than a space
+	client, err := f.GetSystemClient(apiclient.NewRequester(cmd))
 	if err != nil {
 		return err
 	}
@@ -45,21 +77,44 @@ func listRun(cmd *cobra.Command, f factory.Factory) error {
 	if err != nil {
 		return err
 	}
+	allUsers = filterUsers(allUsers, strings.ToLower(flags.Filter.Value))
+
+	showEmail := flags.ShowEmail.Value
+	header := []string{"USERNAME", "NAME", "ID", "TYPE", "STATUS"}
+	if showEmail {
+		header = append(header, "EMAIL")
+	}
 
 	return output.PrintArray(allUsers, cmd, output.Mappers[*users.User]{
 		Json: func(t *users.User) any {
-			return UserAsJson{
-				Id:       t.GetID(),
-				Name:     t.DisplayName,
-				UserName: t.Username,
-				// TODO other fields like isService, etc
+			user := UserAsJson{
+				Id:        t.GetID(),
+				Name:      t.DisplayName,
+				UserName:  t.Username,
+				IsActive:  t.IsActive,
+				IsService: t.IsService,
 			}
+			if showEmail {
+				user.EmailAddress = t.EmailAddress
+			}
+			return user
 		},
 		Table: output.TableDefinition[*users.User]{
-			// TODO other fields like isService, etc
-			Header: []string{"USERNAME", "NAME", "ID"},
+			Header: header,
 			Row: func(t *users.User) []string {
-				return []string{output.Bold(t.Username), t.DisplayName, t.GetID()}
+				userType := "User"
+				if t.IsService {
+					userType = "Service"
+				}
+				status := output.Green("Active")
+				if !t.IsActive {
+					status = output.Yellow("Inactive")
+				}
+				row := []string{output.Bold(t.Username), t.DisplayName, t.GetID(), userType, status}
+				if showEmail {
+					row = append(row, t.EmailAddress)
+				}
+				return row
 			},
 		},
 		Basic: func(t *users.User) string {
@@ -67,3 +122,17 @@ func listRun(cmd *cobra.Command, f factory.Factory) error {
 		},
 	})
 }
+
+// filterUsers keeps the users whose username or display name contains filter, which must already be lower case
+func filterUsers(items []*users.User, filter string) []*users.User {
+	if filter == "" {
+		return items
+	}
+	matches := make([]*users.User, 0, len(items))
+	for _, user := range items {
+		if strings.Contains(strings.ToLower(user.Username), filter) || strings.Contains(strings.ToLower(user.DisplayName), filter) {
+			matches = append(matches, user)
+		}
+	}
+	return matches
+}
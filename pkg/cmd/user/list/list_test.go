@@ -0,0 +1,93 @@
+package list_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func newUser(id string, username string, displayName string, email string) *users.User {
+	user := users.NewUser(username, displayName)
+	user.ID = id
+	user.EmailAddress = email
+	return user
+}
+
+func TestUserList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	jane := newUser("Users-1", "jane", "Jane Smith", "jane@example.com")
+	deployBot := newUser("Users-2", "deploy-bot", "Deploy Bot", "")
+	deployBot.IsService = true
+	former := newUser("Users-3", "old.admin", "Former Admin", "old@example.com")
+	former.IsActive = false
+	allUsers := []*users.User{jane, deployBot, former}
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"prints a table without email addresses", []string{"--no-color"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "USERNAME    NAME          ID       TYPE     STATUS\n"+
+				"jane        Jane Smith    Users-1  User     Active\n"+
+				"deploy-bot  Deploy Bot    Users-2  Service  Active\n"+
+				"old.admin   Former Admin  Users-3  User     Inactive\n", out.String())
+		}},
+		{"shows email addresses with --show-email", []string{"--no-color", "--show-email", "--filter", "JANE"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "USERNAME  NAME        ID       TYPE  STATUS  EMAIL\n"+
+				"jane      Jane Smith  Users-1  User  Active  jane@example.com\n", out.String())
+		}},
+		{"filters on display name", []string{"--filter", "admin", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "old.admin\n", out.String())
+		}},
+		{"prints json without email addresses", []string{"-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			parsed, err := testutil.ParseJsonStrict[[]map[string]any](out)
+			assert.Nil(t, err)
+			assert.Len(t, parsed, 3)
+			assert.Equal(t, map[string]any{"Id": "Users-2", "Name": "Deploy Bot", "UserName": "deploy-bot", "Description": "", "IsActive": true, "IsService": true}, parsed[1])
+			assert.NotContains(t, parsed[0], "EmailAddress")
+		}},
+		{"prints json with email addresses", []string{"-f", "json", "--show-email"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			parsed, err := testutil.ParseJsonStrict[[]map[string]any](out)
+			assert.Nil(t, err)
+			assert.Equal(t, "jane@example.com", parsed[0]["EmailAddress"])
+			assert.Equal(t, false, parsed[2]["IsActive"])
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"user", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/users/all").RespondWith(allUsers)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+}
@@ -20,7 +20,8 @@ func NewCmdUser(f factory.Factory) *cobra.Command {
 			$ %[1]s user ls
 		`, constants.ExecutableName),
 		Annotations: map[string]string{
-			annotations.IsCore: "true",
+			annotations.IsCore:      "true",
+			annotations.IsSpaceless: "true",
 		},
 	}
 
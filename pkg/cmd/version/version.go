@@ -2,7 +2,9 @@ package version
 
 import (
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/spf13/cobra"
 )
@@ -12,8 +14,17 @@ func NewCmdVersion(f factory.Factory) *cobra.Command {
 		Use:     "version",
 		Hidden:  true,
 		Example: heredoc.Docf("$ %s version", constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsSpaceless:    "true",
+			annotations.DefaultTimeout: constants.TimeoutVersion,
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.Println(f.BuildVersion())
+			// bug reports need the server version too. Without credentials, or if the server can't be reached,
+			// the CLI version alone will have to do
+			if serverVersion, err := f.GetServerVersion(apiclient.NewRequester(cmd)); err == nil && serverVersion != "" {
+				cmd.Printf("Octopus Server %s (%s)\n", serverVersion, f.GetCurrentHost())
+			}
 			return nil
 		},
 	}
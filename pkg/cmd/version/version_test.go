@@ -0,0 +1,49 @@
+package version_test
+
+import (
+	"bytes"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	rootResource := testutil.NewRootResource()
+	rootResource.Version = "2023.1.1234"
+	rootResource.Links["Self"] = "/api"
+
+	run := func(t *testing.T, respond func(api *testutil.MockHttpServer)) string {
+		api := testutil.NewMockHttpServer()
+		askProvider := question.NewAskProvider(nil)
+		rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactory(api), nil, askProvider)
+		stdout := &bytes.Buffer{}
+		rootCmd.SetOut(stdout)
+		rootCmd.SetArgs([]string{"version"})
+
+		cmdReceiver := testutil.GoBegin(func() error {
+			defer api.Close()
+			return rootCmd.Execute()
+		})
+		respond(api)
+		assert.Nil(t, <-cmdReceiver)
+		return stdout.String()
+	}
+
+	t.Run("prints the server version as well when it can", func(t *testing.T) {
+		out := run(t, func(api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+		})
+		assert.Equal(t, "0.0.0-test\nOctopus Server 2023.1.1234 (http://server)\n", out)
+	})
+
+	t.Run("prints only the CLI version if the server can't be reached", func(t *testing.T) {
+		out := run(t, func(api *testutil.MockHttpServer) {
+			api.ExpectRequest(t, "GET", "/api").RespondWithStatus(503, "503 Service Unavailable", nil)
+		})
+		assert.Equal(t, "0.0.0-test\n", out)
+	})
+}
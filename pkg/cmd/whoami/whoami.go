@@ -0,0 +1,112 @@
+package whoami
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/apiclient"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
+	"github.com/OctopusDeploy/cli/pkg/factory"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+type WhoAmIAsJson struct {
+	Server        string `json:"Server"`
+	ServerVersion string `json:"ServerVersion"`
+	UserId        string `json:"UserId"`
+	Username      string `json:"Username"`
+	DisplayName   string `json:"DisplayName"`
+	SpaceId       string `json:"SpaceId,omitempty"`
+	SpaceName     string `json:"SpaceName,omitempty"`
+}
+
+func NewCmdWhoAmI(f factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the current user and server",
+		Long:  "Check the connection to Octopus Deploy and show the current user, server version and space",
+		Example: heredoc.Docf(`
+			$ %[1]s whoami
+			$ %[1]s whoami --output-format json
+		`, constants.ExecutableName),
+		Annotations: map[string]string{
+			annotations.IsSpaceless: "true",
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return whoAmIRun(cmd, f)
+		},
+	}
+
+	return cmd
+}
+
+func whoAmIRun(cmd *cobra.Command, f factory.Factory) error {
+	requester := apiclient.NewRequester(cmd)
+	systemClient, err := f.GetSystemClient(requester)
+	if err != nil {
+		return err
+	}
+
+	me, err := systemClient.Users.GetMe()
+	if err != nil {
+		return err
+	}
+
+	serverVersion, err := f.GetServerVersion(requester)
+	if err != nil {
+		return err
+	}
+
+	result := &WhoAmIAsJson{
+		Server:        f.GetCurrentHost(),
+		ServerVersion: serverVersion,
+		UserId:        me.GetID(),
+		Username:      me.Username,
+		DisplayName:   me.DisplayName,
+	}
+
+	// only look up the space if one was given; whoami never prompts for one
+	if f.HasSpace() {
+		if _, err := f.GetSpacedClient(requester); err != nil {
+			return err
+		}
+		if space := f.GetCurrentSpace(); space != nil {
+			result.SpaceId = space.GetID()
+			result.SpaceName = space.Name
+		}
+	}
+
+	return printWhoAmI(cmd.OutOrStdout(), output.GetOutputFormat(cmd), result)
+}
+
+func printWhoAmI(out io.Writer, outputFormat string, result *WhoAmIAsJson) error {
+	switch outputFormat {
+	case constants.OutputFormatJson:
+		data, err := json.MarshalIndent(output.WrapJson(result), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case constants.OutputFormatBasic:
+		_, err := fmt.Fprintln(out, result.Username)
+		return err
+	}
+
+	name := result.Username
+	if result.DisplayName != "" && result.DisplayName != result.Username {
+		name = fmt.Sprintf("%s (%s)", result.DisplayName, result.Username)
+	}
+	fmt.Fprintf(out, "Logged in as %s %s\n", output.Bold(name), output.Dimf("(%s)", result.UserId))
+	fmt.Fprintf(out, "Server: %s %s\n", result.Server, output.Dimf("(version %s)", result.ServerVersion))
+	if result.SpaceName == "" {
+		fmt.Fprintln(out, "Space: "+output.Dim("none selected"))
+	} else {
+		fmt.Fprintf(out, "Space: %s %s\n", result.SpaceName, output.Dimf("(%s)", result.SpaceId))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package whoami_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhoAmI(t *testing.T) {
+	rootResource := testutil.NewRootResource()
+	rootResource.Version = "2023.1.1234"
+	rootResource.Links["Self"] = "/api"
+	rootResource.Links["Users"] = "/api/users{/id}{?skip,take,ids,filter}"
+
+	me := users.NewUser("jsmith", "Jane Smith")
+	me.ID = "Users-1"
+
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+
+	tests := []struct {
+		name   string
+		space  *spaces.Space
+		args   []string
+		expect string
+	}{
+		{"prints the user, server and space", space1, []string{"whoami"}, heredoc.Doc(`
+			Logged in as Jane Smith (jsmith) (Users-1)
+			Server: http://server (version 2023.1.1234)
+			Space: Default Space (Spaces-1)
+		`)},
+		{"does not prompt when no space is selected", nil, []string{"whoami"}, heredoc.Doc(`
+			Logged in as Jane Smith (jsmith) (Users-1)
+			Server: http://server (version 2023.1.1234)
+			Space: none selected
+		`)},
+		{"basic output prints the username", nil, []string{"whoami", "-f", "basic"}, "jsmith\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api := testutil.NewMockHttpServer()
+			askProvider := question.NewAskProvider(testutil.NewAskMocker().AsAsker())
+			fac := testutil.NewMockFactoryWithSpaceAndPrompt(api, test.space, askProvider)
+			rootCmd := cmdRoot.NewCmdRoot(fac, nil, askProvider)
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			rootCmd.SetOut(stdout)
+			rootCmd.SetErr(stderr)
+
+			cmdReceiver := testutil.GoBegin(func() error {
+				defer api.Close()
+				rootCmd.SetArgs(test.args)
+				return rootCmd.Execute()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/users/me").RespondWith(me)
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			if test.space != nil {
+				api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+				api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			}
+
+			err := <-cmdReceiver
+			assert.Nil(t, err)
+			assert.Equal(t, test.expect, stdout.String())
+			assert.Equal(t, "", stderr.String())
+		})
+	}
+}
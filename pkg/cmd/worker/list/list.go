@@ -1,44 +1,72 @@
 package list
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/cmd/model"
 	"github.com/OctopusDeploy/cli/pkg/cmd/worker/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/machinescommon"
 	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
+type ListFlags struct {
+	WorkerPool *flag.Flag[[]string]
+}
+
+func NewListFlags() *ListFlags {
+	return &ListFlags{
+		WorkerPool: flag.New[[]string](shared.FlagWorkerPool, false),
+	}
+}
+
+// RegisterFlags adds the filtering flags to a worker list command
+func RegisterFlags(cmd *cobra.Command, flags *ListFlags) {
+	cmd.Flags().StringSliceVar(&flags.WorkerPool.Value, flags.WorkerPool.Name, nil, "Only list workers in this worker pool, by name or ID (can be specified multiple times)")
+}
+
 type ListOptions struct {
 	*cobra.Command
 	*cmd.Dependencies
 	*shared.GetWorkersOptions
+	*ListFlags
 }
 
-func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command, filter func(*machines.Worker) bool) *ListOptions {
+func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command, flags *ListFlags, filter func(*machines.Worker) bool) *ListOptions {
 	return &ListOptions{
 		Command:           command,
 		Dependencies:      dependencies,
 		GetWorkersOptions: shared.NewGetWorkersOptions(dependencies, filter),
+		ListFlags:         flags,
 	}
 }
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := NewListFlags()
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List workers",
 		Long:    "List workers in Octopus Deploy",
 		Aliases: []string{"ls"},
-		Example: heredoc.Docf("$ %s worker list", constants.ExecutableName),
+		Example: heredoc.Docf(`
+			$ %[1]s worker list
+			$ %[1]s worker list --worker-pool 'Linux Workers'
+		`, constants.ExecutableName),
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
-			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c, nil))
+			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c, listFlags, nil))
 		},
 	}
 
+	RegisterFlags(cmd, listFlags)
 	return cmd
 }
 
@@ -49,10 +77,11 @@ func ListRun(opts *ListOptions) error {
 	}
 
 	type TargetAsJson struct {
-		Id          string         `json:"Id"`
-		Name        string         `json:"Name"`
-		Type        string         `json:"Type"`
-		WorkerPools []model.Entity `json:"WorkerPools"`
+		Id           string         `json:"Id"`
+		Name         string         `json:"Name"`
+		Type         string         `json:"Type"`
+		WorkerPools  []model.Entity `json:"WorkerPools"`
+		HealthStatus string         `json:"HealthStatus"`
 	}
 
 	workerPoolMap, err := GetWorkerPoolMap(opts)
@@ -60,21 +89,27 @@ func ListRun(opts *ListOptions) error {
 		return err
 	}
 
+	allTargets, err = filterWorkers(allTargets, opts.ListFlags, workerPoolMap)
+	if err != nil {
+		return err
+	}
+
 	return output.PrintArray(allTargets, opts.Command, output.Mappers[*machines.Worker]{
 		Json: func(item *machines.Worker) any {
 
 			return TargetAsJson{
-				Id:          item.GetID(),
-				Name:        item.Name,
-				Type:        machinescommon.CommunicationStyleToDeploymentTargetTypeMap[item.Endpoint.GetCommunicationStyle()],
-				WorkerPools: resolveEntities(item.WorkerPoolIDs, workerPoolMap),
+				Id:           item.GetID(),
+				Name:         item.Name,
+				Type:         machinescommon.CommunicationStyleToDeploymentTargetTypeMap[item.Endpoint.GetCommunicationStyle()],
+				WorkerPools:  resolveEntities(item.WorkerPoolIDs, workerPoolMap),
+				HealthStatus: item.HealthStatus,
 			}
 		},
 		Table: output.TableDefinition[*machines.Worker]{
-			Header: []string{"NAME", "TYPE", "WORKER POOLS"},
+			Header: []string{"NAME", "TYPE", "WORKER POOLS", "HEALTH"},
 			Row: func(item *machines.Worker) []string {
 				poolNames := resolveValues(item.WorkerPoolIDs, workerPoolMap)
-				return []string{output.Bold(item.Name), machinescommon.CommunicationStyleToDescriptionMap[item.Endpoint.GetCommunicationStyle()], output.FormatAsList(poolNames)}
+				return []string{output.Bold(item.Name), machinescommon.CommunicationStyleToDescriptionMap[item.Endpoint.GetCommunicationStyle()], output.FormatAsList(poolNames), shared.FormatHealthStatus(item)}
 			},
 		},
 		Basic: func(item *machines.Worker) string {
@@ -83,6 +118,45 @@ func ListRun(opts *ListOptions) error {
 	})
 }
 
+// filterWorkers keeps the workers in any of the worker pools given in flags, by name or ID
+func filterWorkers(workers []*machines.Worker, flags *ListFlags, workerPoolMap map[string]string) ([]*machines.Worker, error) {
+	if flags == nil || len(flags.WorkerPool.Value) == 0 {
+		return workers, nil
+	}
+
+	workerPoolIds := make(map[string]bool)
+	for _, nameOrId := range flags.WorkerPool.Value {
+		id, err := findWorkerPoolId(nameOrId, workerPoolMap)
+		if err != nil {
+			return nil, err
+		}
+		workerPoolIds[id] = true
+	}
+
+	var filtered []*machines.Worker
+	for _, worker := range workers {
+		for _, id := range worker.WorkerPoolIDs {
+			if workerPoolIds[id] {
+				filtered = append(filtered, worker)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+func findWorkerPoolId(nameOrId string, workerPoolMap map[string]string) (string, error) {
+	if _, ok := workerPoolMap[nameOrId]; ok {
+		return nameOrId, nil
+	}
+	for id, name := range workerPoolMap {
+		if strings.EqualFold(name, nameOrId) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find worker pool '%s'", nameOrId)
+}
+
 func resolveValues(keys []string, lookup map[string]string) []string {
 	var values []string
 	for _, key := range keys {
@@ -0,0 +1,98 @@
+package list_test
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	cmdRoot "github.com/OctopusDeploy/cli/pkg/cmd/root"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/test/fixtures"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+var rootResource = testutil.NewRootResource()
+
+func newWorker(id string, name string, health string, workerPoolIds ...string) *machines.Worker {
+	worker := machines.NewWorker(name, machines.NewListeningTentacleEndpoint(&url.URL{Scheme: "https", Host: name}, "thumbprint"))
+	worker.ID = id
+	worker.HealthStatus = health
+	worker.WorkerPoolIDs = workerPoolIds
+	return worker
+}
+
+func newWorkerPool(id string, name string) *workerpools.WorkerPoolListResult {
+	return &workerpools.WorkerPoolListResult{ID: id, Name: name, WorkerPoolType: workerpools.WorkerPoolTypeStatic, CanAddWorkers: true}
+}
+
+func TestWorkerList(t *testing.T) {
+	space1 := fixtures.NewSpace("Spaces-1", "Default Space")
+	allWorkers := []*machines.Worker{
+		newWorker("Workers-1", "linux01", "Healthy", "WorkerPools-1"),
+		newWorker("Workers-2", "win01", "Unhealthy", "WorkerPools-2"),
+		newWorker("Workers-3", "linux02", "HasWarnings", "WorkerPools-1", "WorkerPools-2"),
+	}
+	allWorkerPools := []*workerpools.WorkerPoolListResult{newWorkerPool("WorkerPools-1", "Linux Workers"), newWorkerPool("WorkerPools-2", "Windows Workers")}
+
+	tests := []struct {
+		name   string
+		args   []string
+		verify func(t *testing.T, out *bytes.Buffer, err error)
+	}{
+		{"prints a table with worker pool names and health", []string{"--no-color"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "NAME     TYPE                WORKER POOLS                    HEALTH\n"+
+				"linux01  Listening Tentacle  Linux Workers                   Healthy\n"+
+				"win01    Listening Tentacle  Windows Workers                 Unhealthy\n"+
+				"linux02  Listening Tentacle  Linux Workers, Windows Workers  HasWarnings\n", out.String())
+		}},
+		{"filters by worker pool name", []string{"--worker-pool", "windows workers", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "win01\nlinux02\n", out.String())
+		}},
+		{"filters by worker pool ID", []string{"--worker-pool", "WorkerPools-1", "-f", "basic"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			assert.Equal(t, "linux01\nlinux02\n", out.String())
+		}},
+		{"includes health in json", []string{"--worker-pool", "Windows Workers", "-f", "json"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.Nil(t, err)
+			parsed, err := testutil.ParseJsonStrict[[]map[string]any](out)
+			assert.Nil(t, err)
+			assert.Len(t, parsed, 2)
+			assert.Equal(t, "Workers-2", parsed[0]["Id"])
+			assert.Equal(t, "Unhealthy", parsed[0]["HealthStatus"])
+			assert.Equal(t, []any{map[string]any{"Id": "WorkerPools-2", "Name": "Windows Workers"}}, parsed[0]["WorkerPools"])
+		}},
+		{"rejects an unknown worker pool", []string{"--worker-pool", "Mac Workers"}, func(t *testing.T, out *bytes.Buffer, err error) {
+			assert.EqualError(t, err, "cannot find worker pool 'Mac Workers'")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			api, _ := testutil.NewMockServerAndAsker()
+			askProvider := question.NewAskProvider(nil)
+			rootCmd := cmdRoot.NewCmdRoot(testutil.NewMockFactoryWithSpaceAndPrompt(api, space1, askProvider), nil, askProvider)
+			rootCmd.SetOut(stdout)
+
+			cmdReceiver := testutil.GoBegin2(func() (*cobra.Command, error) {
+				defer api.Close()
+				rootCmd.SetArgs(append([]string{"worker", "list", "--no-prompt"}, test.args...))
+				return rootCmd.ExecuteC()
+			})
+
+			api.ExpectRequest(t, "GET", "/api").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1").RespondWith(rootResource)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/workers/all").RespondWith(allWorkers)
+			api.ExpectRequest(t, "GET", "/api/Spaces-1/workerpools/all").RespondWith(allWorkerPools)
+
+			_, err := testutil.ReceivePair(cmdReceiver)
+			test.verify(t, stdout, err)
+		})
+	}
+}
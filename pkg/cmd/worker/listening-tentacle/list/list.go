@@ -5,12 +5,14 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/cmd/worker/list"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := list.NewListFlags()
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List Listening Tentacle workers",
@@ -19,14 +21,16 @@ func NewCmdList(f factory.Factory) *cobra.Command {
 		Example: heredoc.Docf(`
 			$ %s worker listening-tentacle list
 		`, constants.ExecutableName),
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
 			dependencies := cmd.NewDependencies(f, c)
-			options := list.NewListOptions(dependencies, c, func(worker *machines.Worker) bool {
+			options := list.NewListOptions(dependencies, c, listFlags, func(worker *machines.Worker) bool {
 				return worker.Endpoint.GetCommunicationStyle() == "TentaclePassive"
 			})
 			return list.ListRun(options)
 		},
 	}
 
+	list.RegisterFlags(cmd, listFlags)
 	return cmd
 }
@@ -43,7 +43,7 @@ func ViewRun(opts *ViewOptions, contributeEndpoint ContributeEndpointCallback, d
 	data := []*output.DataRow{}
 
 	data = append(data, output.NewDataRow("Name", fmt.Sprintf("%s %s", output.Bold(worker.Name), output.Dimf("(%s)", worker.GetID()))))
-	data = append(data, output.NewDataRow("Health status", getHealthStatus(worker)))
+	data = append(data, output.NewDataRow("Health status", FormatHealthStatus(worker)))
 	data = append(data, output.NewDataRow("Current status", worker.StatusSummary))
 
 	workerPoolMap, err := GetWorkerPoolMap(opts)
@@ -90,7 +90,7 @@ func ContributeAccount(opts *ViewOptions, accountID string) ([]*output.DataRow,
 	return data, nil
 }
 
-func getHealthStatus(worker *machines.Worker) string {
+func FormatHealthStatus(worker *machines.Worker) string {
 	switch worker.HealthStatus {
 	case "Healthy":
 		return output.Green(worker.HealthStatus)
@@ -5,26 +5,30 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/cmd/worker/list"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/spf13/cobra"
 )
 
 func NewCmdList(f factory.Factory) *cobra.Command {
+	listFlags := list.NewListFlags()
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List SSH workers",
-		Long:    "List SSH workers in Octopus Deploy",
-		Aliases: []string{"ls"},
-		Example: heredoc.Docf("$ %s worker ssh list", constants.ExecutableName),
+		Use:         "list",
+		Short:       "List SSH workers",
+		Long:        "List SSH workers in Octopus Deploy",
+		Aliases:     []string{"ls"},
+		Example:     heredoc.Docf("$ %s worker ssh list", constants.ExecutableName),
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
 			dependencies := cmd.NewDependencies(f, c)
-			options := list.NewListOptions(dependencies, c, func(worker *machines.Worker) bool {
+			options := list.NewListOptions(dependencies, c, listFlags, func(worker *machines.Worker) bool {
 				return worker.Endpoint.GetCommunicationStyle() == "Ssh"
 			})
 			return list.ListRun(options)
 		},
 	}
 
+	list.RegisterFlags(cmd, listFlags)
 	return cmd
 }
@@ -24,7 +24,7 @@ func NewCmdWorker(f factory.Factory) *cobra.Command {
 			$ %[1]s worker ls
 		`, constants.ExecutableName),
 		Annotations: map[string]string{
-			annotations.IsCore: "true",
+			annotations.IsInfrastructure: "true",
 		},
 	}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/OctopusDeploy/cli/pkg/cmd"
 	"github.com/OctopusDeploy/cli/pkg/cmd/workerpool/shared"
 	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/constants/annotations"
 	"github.com/OctopusDeploy/cli/pkg/factory"
 	"github.com/OctopusDeploy/cli/pkg/output"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
@@ -27,11 +28,12 @@ func NewListOptions(dependencies *cmd.Dependencies, command *cobra.Command) *Lis
 
 func NewCmdList(f factory.Factory) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list",
-		Short:   "List worker pools",
-		Long:    "List worker pools in Octopus Deploy",
-		Aliases: []string{"ls"},
-		Example: heredoc.Docf("$ %s worker-pool list", constants.ExecutableName),
+		Use:         "list",
+		Short:       "List worker pools",
+		Long:        "List worker pools in Octopus Deploy",
+		Aliases:     []string{"ls"},
+		Example:     heredoc.Docf("$ %s worker-pool list", constants.ExecutableName),
+		Annotations: map[string]string{annotations.DefaultTimeout: constants.TimeoutList},
 		RunE: func(c *cobra.Command, args []string) error {
 			return ListRun(NewListOptions(cmd.NewDependencies(f, c), c))
 		},
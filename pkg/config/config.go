@@ -14,6 +14,8 @@ import (
 const configName = "cli_config"
 const defaultConfigFileType = "json"
 const appData = "AppData"
+const spaceCacheFileName = "space_cache.json"
+const draftsDirName = "drafts"
 
 func SetupConfigFile(v *viper.Viper, configPath string) {
 	v.SetConfigName(configName)
@@ -29,6 +31,16 @@ func setDefaults(v *viper.Viper) {
 	//	v.SetDefault(constants.ConfigProxyUrl, "")
 	v.SetDefault(constants.ConfigShowOctopus, true)
 	v.SetDefault(constants.ConfigOutputFormat, "table")
+	v.SetDefault(constants.ConfigProfile, "")
+	v.SetDefault(constants.ConfigHttpTimeout, "")
+	v.SetDefault(constants.ConfigRetryCount, "")
+	v.SetDefault(constants.ConfigMaxAccountEnvironments, "")
+	v.SetDefault(constants.ConfigSkipTlsVerify, false)
+	v.SetDefault(constants.ConfigCACert, "")
+	v.SetDefault(constants.ConfigSkipApiKeyCheck, false)
+	v.SetDefault(constants.ConfigApiKeyCommand, "")
+	v.SetDefault(constants.ConfigAccessToken, "")
+	v.SetDefault(constants.ConfigDebug, false)
 
 	if runtime.GOOS == "windows" {
 		v.SetDefault(constants.ConfigEditor, "notepad")
@@ -47,6 +59,36 @@ func bindEnvironment(v *viper.Viper) error {
 	if err := v.BindEnv(constants.ConfigSpace, constants.EnvOctopusSpace); err != nil {
 		return err
 	}
+	if err := v.BindEnv(constants.ConfigProfile, constants.EnvOctopusProfile); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigHttpTimeout, constants.EnvHttpTimeout); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigRetryCount, constants.EnvRetryCount); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigMaxAccountEnvironments, constants.EnvMaxAccountEnvironments); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigSkipTlsVerify, constants.EnvSkipTlsVerify); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigCACert, constants.EnvCACert); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigSkipApiKeyCheck, constants.EnvSkipApiKeyCheck); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigApiKeyCommand, constants.EnvOctopusApiKeyCommand); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigAccessToken, constants.EnvOctopusAccessToken); err != nil {
+		return err
+	}
+	if err := v.BindEnv(constants.ConfigDebug, constants.EnvOctopusDebug); err != nil {
+		return err
+	}
 	// Envs will take precedence in the specified order
 	if err := v.BindEnv(constants.ConfigEditor, constants.EnvVisual, constants.EnvEditor); err != nil {
 		return err
@@ -95,6 +137,25 @@ func EnsureConfigPath() (string, error) {
 	return configPath, nil
 }
 
+// GetSpaceCachePath returns where the cache of space lookups is kept; it lives alongside the config file
+func GetSpaceCachePath() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configPath, spaceCacheFileName), nil
+}
+
+// GetDraftPath returns where the draft of an interrupted interactive command called name is kept; drafts live
+// in their own folder alongside the config file
+func GetDraftPath(name string) (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configPath, draftsDirName, name+".json"), nil
+}
+
 // getConfigPath works out the directory where the config file should be saved and returns it.
 // does not modify the global viper
 func getConfigPath() (string, error) {
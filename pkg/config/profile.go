@@ -0,0 +1,160 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const profilesConfigName = "config"
+const profilesConfigFileType = "yaml"
+const profilesKey = "profiles"
+
+// SecretFilePermissions are the permissions config files are written with, as they can hold API keys
+const SecretFilePermissions os.FileMode = 0600
+
+const (
+	ProfileKeyUrl    = "url"
+	ProfileKeyApiKey = "apikey"
+	ProfileKeySpace  = "space"
+)
+
+// Profile holds the connection details for one named Octopus instance. Profiles live in config.yaml
+// alongside the regular config file, e.g.
+//
+//	profiles:
+//	  production:
+//	    url: https://octopus.example.com
+//	    apikey: API-XXXXXXXX
+//	    space: Default
+type Profile struct {
+	Url    string `mapstructure:"url" json:"Url"`
+	ApiKey string `mapstructure:"apikey" json:"ApiKey"`
+	Space  string `mapstructure:"space" json:"Space"`
+}
+
+// LoadProfile reads the named instance profile from config.yaml in the CLI's config directory
+func LoadProfile(name string) (*Profile, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadProfileFromPath(configPath, name)
+}
+
+// LoadProfileFromPath reads the named instance profile from config.yaml in configPath.
+// Profile names are not case sensitive.
+func LoadProfileFromPath(configPath string, name string) (*Profile, error) {
+	profilesFile := filepath.Join(configPath, profilesConfigName+"."+profilesConfigFileType)
+	if _, err := os.Stat(profilesFile); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("cannot use profile '%s' because %s does not exist", name, profilesFile)
+	}
+
+	profiles, err := readProfiles(profilesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// viper lower-cases all keys as it reads them
+	profile, ok := profiles[strings.ToLower(name)]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("cannot find profile '%s' in %s", name, profilesFile)
+	}
+	return profile, nil
+}
+
+// LoadProfiles reads every instance profile from config.yaml in the CLI's config directory
+func LoadProfiles() (map[string]*Profile, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadProfilesFromPath(configPath)
+}
+
+// LoadProfilesFromPath reads every instance profile from config.yaml in configPath, keyed by their
+// lower-cased names. There are no profiles if config.yaml doesn't exist
+func LoadProfilesFromPath(configPath string) (map[string]*Profile, error) {
+	profilesFile := filepath.Join(configPath, profilesConfigName+"."+profilesConfigFileType)
+	if _, err := os.Stat(profilesFile); errors.Is(err, os.ErrNotExist) {
+		return map[string]*Profile{}, nil
+	}
+	return readProfiles(profilesFile)
+}
+
+func readProfiles(profilesFile string) (map[string]*Profile, error) {
+	v := viper.New()
+	v.SetConfigFile(profilesFile)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", profilesFile, err)
+	}
+
+	profiles := map[string]*Profile{}
+	if err := v.UnmarshalKey(profilesKey, &profiles); err != nil {
+		return nil, fmt.Errorf("error reading profiles from %s: %w", profilesFile, err)
+	}
+	return profiles, nil
+}
+
+// ProfileKey turns the name of a profile setting as people type it, e.g. "Host" or "api-key", into the
+// key it is saved under in config.yaml
+func ProfileKey(key string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "url", "host":
+		return ProfileKeyUrl, nil
+	case "apikey", "api-key":
+		return ProfileKeyApiKey, nil
+	case "space":
+		return ProfileKeySpace, nil
+	}
+	return "", fmt.Errorf("the key '%s' is not valid for a profile; use host, api-key or space", key)
+}
+
+// SetProfileValue sets one value of the named profile in config.yaml in the CLI's config directory
+func SetProfileValue(name string, key string, value string) error {
+	configPath, err := EnsureConfigPath()
+	if err != nil {
+		return err
+	}
+	return SetProfileValueInPath(configPath, name, key, value)
+}
+
+// SetProfileValueInPath sets one value of the named profile in config.yaml in configPath, creating the
+// file and the profile if they don't exist yet. key is any name ProfileKey understands.
+// The file is left readable only by its owner, as it holds API keys
+func SetProfileValueInPath(configPath string, name string, key string, value string) error {
+	profileKey, err := ProfileKey(key)
+	if err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || strings.Contains(name, ".") {
+		return fmt.Errorf("'%s' is not a valid profile name; it must not be empty or contain '.'", name)
+	}
+
+	profilesFile := filepath.Join(configPath, profilesConfigName+"."+profilesConfigFileType)
+	v := viper.New()
+	v.SetConfigFile(profilesFile)
+	v.SetConfigPermissions(SecretFilePermissions)
+	if _, err := os.Stat(profilesFile); err == nil {
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("error reading %s: %w", profilesFile, err)
+		}
+	}
+
+	v.Set(strings.Join([]string{profilesKey, strings.ToLower(name), profileKey}, "."), value)
+	if err := v.WriteConfig(); err != nil {
+		return err
+	}
+	return RestrictPermissions(profilesFile)
+}
+
+// RestrictPermissions makes a config file readable and writable only by its owner. Files written before
+// we did this may still be readable by others
+func RestrictPermissions(path string) error {
+	return os.Chmod(path, SecretFilePermissions)
+}
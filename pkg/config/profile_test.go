@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProfileFromPath(t *testing.T) {
+	configPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(configPath, "config.yaml"), []byte(heredoc.Doc(`
+		profiles:
+		  Production:
+		    url: https://prod.example.com
+		    apikey: API-PROD
+		    space: Default
+		  local:
+		    url: http://localhost:8065
+		    apikey: API-LOCAL
+	`)), 0600)
+	assert.Nil(t, err)
+
+	t.Run("loads a named profile regardless of case", func(t *testing.T) {
+		profile, err := config.LoadProfileFromPath(configPath, "production")
+		assert.Nil(t, err)
+		assert.Equal(t, &config.Profile{Url: "https://prod.example.com", ApiKey: "API-PROD", Space: "Default"}, profile)
+	})
+
+	t.Run("errors for an unknown profile", func(t *testing.T) {
+		_, err := config.LoadProfileFromPath(configPath, "staging")
+		assert.EqualError(t, err, "cannot find profile 'staging' in "+filepath.Join(configPath, "config.yaml"))
+	})
+
+	t.Run("errors when there is no profiles file", func(t *testing.T) {
+		emptyPath := t.TempDir()
+		_, err := config.LoadProfileFromPath(emptyPath, "local")
+		assert.EqualError(t, err, "cannot use profile 'local' because "+filepath.Join(emptyPath, "config.yaml")+" does not exist")
+	})
+}
+
+func TestSetProfileValueInPath(t *testing.T) {
+	t.Run("creates config.yaml readable only by its owner", func(t *testing.T) {
+		configPath := t.TempDir()
+		assert.Nil(t, config.SetProfileValueInPath(configPath, "Production", "host", "https://prod.example.com"))
+		assert.Nil(t, config.SetProfileValueInPath(configPath, "production", "api-key", "API-PROD"))
+
+		profile, err := config.LoadProfileFromPath(configPath, "production")
+		assert.Nil(t, err)
+		assert.Equal(t, &config.Profile{Url: "https://prod.example.com", ApiKey: "API-PROD"}, profile)
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(filepath.Join(configPath, "config.yaml"))
+			assert.Nil(t, err)
+			assert.Equal(t, config.SecretFilePermissions, info.Mode().Perm())
+		}
+	})
+
+	t.Run("keeps the other profiles and tightens permissions on an existing file", func(t *testing.T) {
+		configPath := t.TempDir()
+		profilesFile := filepath.Join(configPath, "config.yaml")
+		err := os.WriteFile(profilesFile, []byte(heredoc.Doc(`
+			profiles:
+			  local:
+			    url: http://localhost:8065
+			    apikey: API-LOCAL
+		`)), 0644)
+		assert.Nil(t, err)
+
+		assert.Nil(t, config.SetProfileValueInPath(configPath, "local", "Space", "Default"))
+		assert.Nil(t, config.SetProfileValueInPath(configPath, "staging", "url", "https://staging.example.com"))
+
+		profiles, err := config.LoadProfilesFromPath(configPath)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]*config.Profile{
+			"local":   {Url: "http://localhost:8065", ApiKey: "API-LOCAL", Space: "Default"},
+			"staging": {Url: "https://staging.example.com"},
+		}, profiles)
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(profilesFile)
+			assert.Nil(t, err)
+			assert.Equal(t, config.SecretFilePermissions, info.Mode().Perm())
+		}
+	})
+
+	t.Run("rejects keys a profile can't hold", func(t *testing.T) {
+		err := config.SetProfileValueInPath(t.TempDir(), "local", "editor", "vim")
+		assert.EqualError(t, err, "the key 'editor' is not valid for a profile; use host, api-key or space")
+	})
+}
@@ -5,4 +5,7 @@ const (
 	IsConfiguration  = "IsConfiguration"
 	IsLibrary        = "IsLibrary"
 	IsInfrastructure = "IsInfrastructure"
+	IsSpaceless      = "IsSpaceless"      // the command (and its children) work outside of any space
+	DefaultTimeout   = "DefaultTimeout"   // how long the command (and its children) may run for, unless --timeout says otherwise
+	MinServerVersion = "MinServerVersion" // the oldest Octopus Server the command (and its children) work with, e.g. "2022.1"
 )
@@ -4,6 +4,14 @@ const (
 	ExecutableName = "octopus"
 )
 
+// process exit codes
+const (
+	ExitCodeError         = 1   // the command failed
+	ExitCodeConfiguration = 3   // the CLI isn't configured well enough to run at all
+	ExitCodeAuth          = 4   // the Octopus Server didn't accept our credentials
+	ExitCodeInterrupted   = 130 // the user pressed Ctrl-C; the shells' own convention
+)
+
 // flags for command line switches
 const (
 	FlagHelp               = "help"
@@ -11,6 +19,36 @@ const (
 	FlagOutputFormat       = "output-format"
 	FlagOutputFormatLegacy = "outputFormat"
 	FlagNoPrompt           = "no-prompt"
+	FlagPrompt             = "prompt"
+	FlagNoBanner           = "no-banner"
+	FlagQuiet              = "quiet"
+	FlagEditor             = "editor"
+	FlagDryRun             = "dry-run"
+	FlagIfNotExists        = "if-not-exists"
+	FlagServer             = "server" // not "host" or "url", as some commands already use those for the machine they're creating
+	FlagApiKey             = "api-key"
+	FlagDebug              = "debug"
+	FlagNoColor            = "no-color"
+	FlagProfile            = "profile"
+	FlagCACert             = "cacert"
+	FlagSkipTlsVerify      = "insecure-skip-tls-verify"
+	FlagNoCache            = "no-cache"
+	FlagDescribe           = "describe"
+	FlagTimeout            = "timeout"
+	FlagIncludeSpace       = "include-space"
+)
+
+// values for the annotations.DefaultTimeout annotation. Commands which start server tasks (release deploy, runbook
+// run) deliberately have none: with --wait they can run for as long as --wait-timeout allows, which a fixed default
+// would cut short, and without it they return as soon as the tasks are queued
+const (
+	TimeoutList    = "60s" // commands which just fetch things
+	TimeoutVersion = "10s" // version asks the server for its version, but mustn't hang if it can't be reached
+)
+
+// values for the annotations.MinServerVersion annotation
+const (
+	ServerVersionConfigAsCode = "2022.1" // version-controlled projects, which project convert and project branch work on
 )
 
 // flags for storing things in the go context
@@ -21,9 +59,13 @@ const (
 
 // values for output formats
 const (
-	OutputFormatJson  = "json"
-	OutputFormatBasic = "basic"
-	OutputFormatTable = "table" // TODO I'd like to rename this to just "standard" or "default"; discuss with team
+	OutputFormatJson   = "json"
+	OutputFormatNdjson = "ndjson" // newline-delimited JSON; one compact object per line, suitable for log pipelines
+	OutputFormatCsv    = "csv"    // RFC 4180 CSV with a header line, for spreadsheets
+	OutputFormatBasic  = "basic"
+	OutputFormatEnv    = "env"   // shell-quoted KEY=VALUE lines for a single resource, suitable for eval
+	OutputFormatYaml   = "yaml"  // only for commands which write documents to be read back, such as account export
+	OutputFormatTable  = "table" // TODO I'd like to rename this to just "standard" or "default"; discuss with team
 )
 
 // keys for key/value store config file
@@ -33,18 +75,50 @@ const (
 	ConfigSpace    = "Space"
 	ConfigNoPrompt = "NoPrompt"
 	// ConfigProxyUrl     = "ProxyUrl"
-	ConfigEditor       = "Editor"
-	ConfigShowOctopus  = "ShowOctopus"
-	ConfigOutputFormat = "OutputFormat"
+	ConfigEditor                 = "Editor"
+	ConfigShowOctopus            = "ShowOctopus"
+	ConfigOutputFormat           = "OutputFormat"
+	ConfigProfile                = "Profile"
+	ConfigHttpTimeout            = "HttpTimeout"
+	ConfigRetryCount             = "RetryCount"
+	ConfigCACert                 = "CACert"
+	ConfigSkipTlsVerify          = "SkipTlsVerify"
+	ConfigMaxAccountEnvironments = "MaxAccountEnvironments"
+	ConfigSkipApiKeyCheck        = "SkipApiKeyCheck"
+	ConfigApiKeyCommand          = "ApiKeyCommand"
+	ConfigAccessToken            = "AccessToken"
+	ConfigDebug                  = "Debug"
 )
 
 const (
-	EnvOctopusUrl    = "OCTOPUS_URL"
-	EnvOctopusApiKey = "OCTOPUS_API_KEY"
-	EnvOctopusSpace  = "OCTOPUS_SPACE"
-	EnvEditor        = "EDITOR"
-	EnvVisual        = "VISUAL"
-	EnvCI            = "CI"
+	EnvOctopusUrl             = "OCTOPUS_URL"
+	EnvOctopusApiKey          = "OCTOPUS_API_KEY"
+	EnvOctopusSpace           = "OCTOPUS_SPACE"
+	EnvOctopusProfile         = "OCTOPUS_PROFILE"
+	EnvHttpTimeout            = "OCTOPUS_HTTP_TIMEOUT"
+	EnvRetryCount             = "OCTOPUS_RETRY_COUNT"
+	EnvCACert                 = "OCTOPUS_CACERT"
+	EnvSkipTlsVerify          = "OCTOPUS_SKIP_TLS_VERIFY"
+	EnvMaxAccountEnvironments = "OCTOPUS_MAX_ACCOUNT_ENVIRONMENTS"
+	EnvSkipApiKeyCheck        = "OCTOPUS_SKIP_API_KEY_CHECK"
+	EnvOctopusApiKeyCommand   = "OCTOPUS_API_KEY_COMMAND"
+	EnvOctopusAccessToken     = "OCTOPUS_ACCESS_TOKEN"
+	EnvOctopusDebug           = "OCTOPUS_DEBUG"
+	EnvEditor                 = "EDITOR"
+	EnvVisual                 = "VISUAL"
+	EnvCI                     = "CI"
+
+	// set by CI systems which don't set CI
+	EnvTeamCityVersion = "TEAMCITY_VERSION"
+	EnvBuildId         = "BUILD_ID" // Jenkins, Google Cloud Build
+	EnvJenkinsUrl      = "JENKINS_URL"
+	EnvTfBuild         = "TF_BUILD" // Azure Pipelines
+	EnvGitHubActions   = "GITHUB_ACTIONS"
+	EnvGitLabCI        = "GITLAB_CI"
+	EnvBitbucketBuild  = "BITBUCKET_BUILD_NUMBER"
+	EnvBuildkite       = "BUILDKITE"
+	EnvCircleCI        = "CIRCLECI"
+	EnvCodeBuildId     = "CODEBUILD_BUILD_ID" // AWS CodeBuild
 )
 
 const (
@@ -79,7 +153,7 @@ const (
 // first, lest you print a progress message into the middle of a JSON document by accident.
 func IsProgrammaticOutputFormat(outputFormat string) bool { // TODO consider whether we should move this into the Factory
 	switch outputFormat {
-	case OutputFormatJson, OutputFormatBasic:
+	case OutputFormatJson, OutputFormatNdjson, OutputFormatCsv, OutputFormatBasic, OutputFormatEnv, OutputFormatYaml:
 		return true
 	default:
 		return false
@@ -1,6 +1,11 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+)
 
 // OsEnvironmentError is raised when the CLI cannot launch because a required environment variable is not set
 type OsEnvironmentError struct{ EnvironmentVariable string }
@@ -9,6 +14,17 @@ func (e *OsEnvironmentError) Error() string {
 	return fmt.Sprintf("%s environment variable is missing or blank", e.EnvironmentVariable)
 }
 
+// OsEnvironmentInvalidError is raised when the CLI cannot launch because an environment variable has a value we can't use
+type OsEnvironmentInvalidError struct {
+	EnvironmentVariable string
+	Value               string
+	Reason              string
+}
+
+func (e *OsEnvironmentInvalidError) Error() string {
+	return fmt.Sprintf("%s environment variable has an invalid value '%s'; %s", e.EnvironmentVariable, e.Value, e.Reason)
+}
+
 // PromptDisabledError is a fallback error if code attempts to prompt the user when prompting is disabled.
 // If you see it, it represents a bug because Commands should check IsInteractive before attempting to prompt
 type PromptDisabledError struct{}
@@ -36,3 +52,59 @@ func (e *InvalidResponseError) Error() string { return e.Message }
 func NewInvalidResponseError(message string) *InvalidResponseError {
 	return &InvalidResponseError{Message: message}
 }
+
+// SpaceNotFoundError is raised when the space given by name or ID isn't one the API key can see.
+// Suggestions holds the names of the closest spaces, best first, in case the name was mistyped; it may be empty
+type SpaceNotFoundError struct {
+	SpaceNameOrID string
+	Suggestions   []string
+}
+
+func (e *SpaceNotFoundError) Error() string {
+	switch len(e.Suggestions) {
+	case 0:
+		return fmt.Sprintf("cannot find space '%s'", e.SpaceNameOrID)
+	case 1:
+		return fmt.Sprintf("cannot find space '%s'; did you mean '%s'?", e.SpaceNameOrID, e.Suggestions[0])
+	default:
+		return fmt.Sprintf("cannot find space '%s'; did you mean one of '%s'?", e.SpaceNameOrID, strings.Join(e.Suggestions, "', '"))
+	}
+}
+
+// ServerVersionError is raised when a command needs a newer Octopus Server than the one the CLI is connected to
+type ServerVersionError struct {
+	MinimumVersion string
+	ServerVersion  string
+}
+
+func (e *ServerVersionError) Error() string {
+	return fmt.Sprintf("this command requires Octopus %s or later, but the Octopus Server is version %s", e.MinimumVersion, e.ServerVersion)
+}
+
+// values for ValidationError.Code
+const (
+	ValidationCodeRequired = "required" // nothing was given for a mandatory field
+	ValidationCodeTooLong  = "too_long"
+	ValidationCodeInvalid  = "invalid" // the value was given, but can't be used
+)
+
+// ValidationError is raised when the CLI rejects the value of a field (named after the flag that sets it) before
+// sending anything to the Octopus Server
+type ValidationError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+func NewValidationError(field string, code string, message string) *ValidationError {
+	return &ValidationError{Field: field, Code: code, Message: message}
+}
+
+// NoSpacesError is raised when a space is needed but the Octopus Server has none the API key can see,
+// so there is nothing to select; someone has to create one first
+type NoSpacesError struct{}
+
+func (e *NoSpacesError) Error() string {
+	return fmt.Sprintf("there are no spaces on the Octopus Server that you can access; create one with '%s space create', or ask an administrator to give you access to a space", constants.ExecutableName)
+}
@@ -25,7 +25,9 @@ type Factory interface {
 	GetSystemClient(requester apiclient.Requester) (*client.Client, error)
 	GetSpacedClient(requester apiclient.Requester) (*client.Client, error)
 	GetCurrentSpace() *spaces.Space
+	HasSpace() bool
 	GetCurrentHost() string
+	GetServerVersion(requester apiclient.Requester) (string, error)
 	Spinner() Spinner
 	IsPromptEnabled() bool
 	Ask(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error
@@ -62,10 +64,18 @@ func (f *factory) GetCurrentSpace() *spaces.Space {
 	return f.client.GetActiveSpace()
 }
 
+func (f *factory) HasSpace() bool {
+	return f.client.HasSpace()
+}
+
 func (f *factory) GetCurrentHost() string {
 	return f.client.GetHostUrl()
 }
 
+func (f *factory) GetServerVersion(requester apiclient.Requester) (string, error) {
+	return f.client.GetServerVersion(requester)
+}
+
 func (f *factory) IsPromptEnabled() bool {
 	return f.asker.IsInteractive()
 }
@@ -47,7 +47,9 @@ func DoWebForWorkerPools(workerPool workerpools.IWorkerPool, dependencies *cmd.D
 
 func doWeb(url string, description string, out io.Writer, flags *WebFlags) {
 	link := output.Bluef(url)
-	fmt.Fprintf(out, "View this %s on Octopus Deploy: %s\n", description, link)
+	if !output.IsQuiet {
+		fmt.Fprintf(out, "View this %s on Octopus Deploy: %s\n", description, link)
+	}
 	if flags.Web.Value {
 		browser.OpenURL(url)
 	}
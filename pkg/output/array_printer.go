@@ -0,0 +1,140 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+// ArrayPrinter is PrintArray for output which arrives a page at a time. Call Print with each batch of items,
+// then Flush once they have all been given. The basic, ndjson and csv formats write each batch straight away,
+// so the first rows appear before the last page has been fetched; json and table output need every item
+// before they can print anything (to make a single document, and to size the columns), so they wait for Flush.
+type ArrayPrinter[T any] struct {
+	cmd          *cobra.Command
+	mappers      Mappers[T]
+	outputFormat string
+
+	started    bool
+	jsonItems  []any
+	table      Table
+	csvWriter  *csv.Writer
+	jsonWriter *json.Encoder
+}
+
+// NewArrayPrinter returns an error if the command doesn't support the output format it was asked for, so a
+// command can find out before it starts fetching anything
+func NewArrayPrinter[T any](cmd *cobra.Command, mappers Mappers[T]) (*ArrayPrinter[T], error) {
+	outputFormat := GetOutputFormat(cmd)
+
+	switch outputFormat {
+	case constants.OutputFormatJson, constants.OutputFormatNdjson:
+		if mappers.Json == nil {
+			return nil, errors.New("command does not support output in JSON format")
+		}
+	case constants.OutputFormatCsv:
+		if mappers.Csv.Row == nil {
+			return nil, errors.New("command does not support output in CSV format")
+		}
+	case constants.OutputFormatEnv:
+		return nil, errors.New("output in env format is only supported by commands which output a single resource")
+	case constants.OutputFormatBasic:
+		if mappers.Basic == nil {
+			return nil, errors.New("command does not support output in plain text")
+		}
+	case constants.OutputFormatTable, "": // table is the default of unspecified
+		if mappers.Table.Row == nil {
+			return nil, errors.New("command does not support output in table format")
+		}
+	default:
+		return nil, unsupportedOutputFormatError(outputFormat, cmd)
+	}
+	return &ArrayPrinter[T]{cmd: cmd, mappers: mappers, outputFormat: outputFormat}, nil
+}
+
+// Print writes items, or holds on to them until Flush if the output format needs every item first
+func (p *ArrayPrinter[T]) Print(items []T) error {
+	p.start()
+
+	switch p.outputFormat {
+	case constants.OutputFormatJson:
+		for _, e := range items {
+			p.jsonItems = append(p.jsonItems, p.mappers.Json(e))
+		}
+
+	case constants.OutputFormatNdjson:
+		for _, e := range items {
+			if err := p.jsonWriter.Encode(p.mappers.Json(e)); err != nil {
+				return err
+			}
+		}
+
+	case constants.OutputFormatCsv:
+		for _, item := range items {
+			if err := p.csvWriter.Write(p.mappers.Csv.Row(item)); err != nil {
+				return err
+			}
+		}
+		p.csvWriter.Flush()
+		return p.csvWriter.Error()
+
+	case constants.OutputFormatBasic:
+		for _, e := range items {
+			p.cmd.Println(p.mappers.Basic(e))
+		}
+
+	default:
+		for _, item := range items {
+			p.table.AddRow(p.mappers.Table.Row(item)...)
+		}
+	}
+	return nil
+}
+
+// Flush writes anything Print was holding on to. Nothing more should be printed afterwards
+func (p *ArrayPrinter[T]) Flush() error {
+	p.start()
+
+	switch p.outputFormat {
+	case constants.OutputFormatJson:
+		data, _ := json.MarshalIndent(WrapJson(p.jsonItems), "", "  ")
+		p.cmd.Println(string(data))
+	case constants.OutputFormatCsv:
+		p.csvWriter.Flush()
+		return p.csvWriter.Error()
+	case constants.OutputFormatTable, "":
+		return p.table.Print()
+	}
+	return nil
+}
+
+// start writes the header, if the output format has one, before the first items
+func (p *ArrayPrinter[T]) start() {
+	if p.started {
+		return
+	}
+	p.started = true
+
+	out := p.cmd.OutOrStdout()
+	switch p.outputFormat {
+	case constants.OutputFormatNdjson:
+		p.jsonWriter = json.NewEncoder(out)
+	case constants.OutputFormatCsv:
+		p.csvWriter = csv.NewWriter(out)
+		p.csvWriter.UseCRLF = true // RFC 4180 line endings, as PrintCsv
+		if p.mappers.Csv.Header != nil {
+			_ = p.csvWriter.Write(p.mappers.Csv.Header)
+		}
+	case constants.OutputFormatTable, "":
+		p.table = NewTable(out)
+		if header := p.mappers.Table.Header; header != nil {
+			for k, v := range header {
+				header[k] = Bold(v)
+			}
+			p.table.AddRow(header...)
+		}
+	}
+}
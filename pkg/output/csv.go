@@ -0,0 +1,22 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// PrintCsv writes the header and rows as RFC 4180 CSV, quoting any field which contains a comma, quote or
+// line break. The header is skipped if it is nil.
+func PrintCsv(out io.Writer, header []string, rows [][]string) error {
+	w := csv.NewWriter(out)
+	w.UseCRLF = true // RFC 4180 line endings, which is also what spreadsheet programs expect
+	if header != nil {
+		if err := w.Write(header); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MaskedValue is shown in place of anything sensitive
+const MaskedValue = "***"
+
+// PrintDryRun writes the resource a create command would have sent to the Octopus Server as indented JSON,
+// so it can be checked before anything is created. The new values of sensitive fields (anything serialised
+// like a core.SensitiveValue) are masked.
+func PrintDryRun(out io.Writer, resource any) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	data, err = json.MarshalIndent(MaskSensitiveValues(v), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// MaskSensitiveValues replaces the new value of every sensitive value in v, a document decoded from JSON into
+// maps and slices, with MaskedValue. It changes v in place, and returns it for convenience.
+func MaskSensitiveValues(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if _, ok := v["HasValue"]; ok {
+			if newValue, ok := v["NewValue"]; ok && newValue != nil {
+				v["NewValue"] = MaskedValue
+			}
+		}
+		for k, child := range v {
+			v[k] = MaskSensitiveValues(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = MaskSensitiveValues(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,50 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintDryRun(t *testing.T) {
+	out := &bytes.Buffer{}
+	resource := struct {
+		Name     string
+		Password *core.SensitiveValue
+		Token    *core.SensitiveValue
+		Keys     []*core.SensitiveValue
+	}{
+		Name:     "Web deploy",
+		Password: core.NewSensitiveValue("hunter2"),
+		Token:    core.NewSensitiveValue(""),
+		Keys:     []*core.SensitiveValue{core.NewSensitiveValue("key")},
+	}
+
+	assert.Nil(t, output.PrintDryRun(out, resource))
+	assert.Equal(t, heredoc.Doc(`
+		{
+		  "Keys": [
+		    {
+		      "HasValue": true,
+		      "Hint": null,
+		      "NewValue": "***"
+		    }
+		  ],
+		  "Name": "Web deploy",
+		  "Password": {
+		    "HasValue": true,
+		    "Hint": null,
+		    "NewValue": "***"
+		  },
+		  "Token": {
+		    "HasValue": false,
+		    "Hint": null,
+		    "NewValue": null
+		  }
+		}
+	`), out.String())
+}
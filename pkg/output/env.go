@@ -0,0 +1,25 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kballard/go-shellquote"
+)
+
+// EnvVar is a single KEY=VALUE line printed by the env output format
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// PrintEnv writes each variable as a KEY=VALUE line. Values are shell-quoted, so the output can be
+// passed straight to eval without the contents of a name or description being interpreted by the shell.
+func PrintEnv(out io.Writer, vars []EnvVar) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(out, "%s=%s\n", v.Key, shellquote.Join(v.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ErrorCodeGeneral is the code given to errors which don't have a more specific one
+const ErrorCodeGeneral = "error"
+
+// ErrorJson is how an error is written with --output-format json, so that tools wrapping the CLI can tell
+// which field was rejected and show the message against it.
+type ErrorJson struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
+
+// IsJsonErrorFormat tells you whether errors from rootCmd should be written as JSON. The output format is
+// a persistent flag, so the root command sees the value given to whichever subcommand ran.
+func IsJsonErrorFormat(rootCmd *cobra.Command) bool {
+	outputFormat, _ := rootCmd.PersistentFlags().GetString(constants.FlagOutputFormat)
+	if outputFormat == "" {
+		outputFormat = viper.GetString(constants.ConfigOutputFormat)
+	}
+	return strings.EqualFold(outputFormat, constants.OutputFormatJson)
+}
+
+// PrintError writes err to stderr, as JSON if rootCmd was asked for JSON output, or as plain text otherwise.
+func PrintError(rootCmd *cobra.Command, err error) {
+	if !IsJsonErrorFormat(rootCmd) {
+		rootCmd.PrintErr(err)
+		rootCmd.PrintErrln()
+		return
+	}
+	errorJson := ErrorJson{Code: ErrorCodeGeneral, Message: err.Error()}
+	var validationError *cliErrors.ValidationError
+	if errors.As(err, &validationError) {
+		errorJson.Code = validationError.Code
+		errorJson.Field = validationError.Field
+	}
+	data, marshalErr := json.Marshal(errorJson)
+	if marshalErr != nil { // shouldn't happen, but the error still needs reporting
+		rootCmd.PrintErrln(err)
+		return
+	}
+	_, _ = fmt.Fprintln(rootCmd.ErrOrStderr(), string(data))
+}
@@ -0,0 +1,45 @@
+package output_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	cliErrors "github.com/OctopusDeploy/cli/pkg/errors"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintError(t *testing.T) {
+	newRootCmd := func(outputFormat string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cmd := &cobra.Command{Use: "octopus"}
+		cmd.PersistentFlags().StringP(constants.FlagOutputFormat, "f", constants.OutputFormatTable, "")
+		_ = cmd.PersistentFlags().Set(constants.FlagOutputFormat, outputFormat)
+		cmd.SetOut(stdout)
+		cmd.SetErr(stderr)
+		return cmd, stdout, stderr
+	}
+
+	t.Run("writes plain text normally", func(t *testing.T) {
+		cmd, stdout, stderr := newRootCmd(constants.OutputFormatTable)
+		output.PrintError(cmd, cliErrors.NewValidationError("name", cliErrors.ValidationCodeRequired, "a name is required; use --name"))
+		assert.Equal(t, "", stdout.String())
+		assert.Equal(t, "a name is required; use --name\n", stderr.String())
+	})
+
+	t.Run("writes a validation error as JSON naming the field", func(t *testing.T) {
+		cmd, stdout, stderr := newRootCmd(constants.OutputFormatJson)
+		output.PrintError(cmd, cliErrors.NewValidationError("name", cliErrors.ValidationCodeRequired, "a name is required; use --name"))
+		assert.Equal(t, "", stdout.String())
+		assert.JSONEq(t, `{"code":"required","message":"a name is required; use --name","field":"name"}`, stderr.String())
+	})
+
+	t.Run("writes any other error as JSON with the general code", func(t *testing.T) {
+		cmd, _, stderr := newRootCmd(constants.OutputFormatJson)
+		output.PrintError(cmd, errors.New("cannot find space 'Dfault'"))
+		assert.JSONEq(t, `{"code":"error","message":"cannot find space 'Dfault'"}`, stderr.String())
+	})
+}
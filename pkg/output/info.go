@@ -0,0 +1,36 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+// IsInfoSuppressed tells you whether the user has asked us to keep informational chatter
+// (startup checks, warnings, "Using space:" notices and the like) out of the console. --quiet implies --no-banner.
+func IsInfoSuppressed(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	noBanner, _ := cmd.Flags().GetBool(constants.FlagNoBanner)
+	return noBanner || IsQuiet
+}
+
+// Infof writes an informational message to stderr, so it never ends up in the middle of the primary
+// result on stdout. All such messages should go through here (or Warnf) so that --no-banner
+// can silence them in one place.
+func Infof(cmd *cobra.Command, format string, args ...any) {
+	if IsInfoSuppressed(cmd) {
+		return
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), format, args...)
+}
+
+// Warnf is the same as Infof, but highlights the message as a warning.
+func Warnf(cmd *cobra.Command, format string, args ...any) {
+	if IsInfoSuppressed(cmd) {
+		return
+	}
+	_, _ = fmt.Fprint(cmd.ErrOrStderr(), Yellowf(format, args...))
+}
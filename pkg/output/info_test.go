@@ -0,0 +1,44 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newInfoCmd(args ...string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(c *cobra.Command, _ []string) {
+			output.Infof(c, "Using space: %s\n", "Default")
+			output.Warnf(c, "Server version is older than expected\n")
+			c.Println("result")
+		},
+	}
+	cmd.PersistentFlags().Bool(constants.FlagNoBanner, false, "")
+	cmd.SetArgs(args)
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	return cmd, stdout, stderr
+}
+
+func TestInfof_WritesToStderr(t *testing.T) {
+	cmd, stdout, stderr := newInfoCmd()
+	assert.Nil(t, cmd.Execute())
+
+	assert.Equal(t, "result\n", stdout.String())
+	assert.Equal(t, "Using space: Default\nServer version is older than expected\n", stderr.String())
+}
+
+func TestInfof_SuppressedByNoBanner(t *testing.T) {
+	cmd, stdout, stderr := newInfoCmd("--no-banner")
+	assert.Nil(t, cmd.Execute())
+
+	assert.Equal(t, "result\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
@@ -0,0 +1,20 @@
+package output
+
+// ActiveSpace is set by the root command when --include-space is given. JSON output is then wrapped in a
+// JsonEnvelope which says which space the command ran against, which matters when the space was picked
+// for the user rather than given. It returns nil if the command never needed a space.
+var ActiveSpace func() *IdAndName
+
+type JsonEnvelope struct {
+	ActiveSpace *IdAndName `json:"ActiveSpace"`
+	Result      any        `json:"Result"`
+}
+
+// WrapJson returns value wrapped in a JsonEnvelope if --include-space was given, or value unchanged if not.
+// Anything printing JSON other than through PrintArray or PrintResource should pass its output through this.
+func WrapJson(value any) any {
+	if ActiveSpace == nil {
+		return value
+	}
+	return &JsonEnvelope{ActiveSpace: ActiveSpace(), Result: value}
+}
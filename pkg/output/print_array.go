@@ -42,65 +42,96 @@ type Mappers[T any] struct {
 	// fail if someone asks for it
 	Basic func(item T) string
 
+	// A function which will convert T into KEY=VALUE pairs for the env output format, which is meant to be
+	// eval'd by a shell script. Only include identifying fields, never sensitive values.
+	// If you leave this as nil, then the command will simply not support output as env and will
+	// fail if someone asks for it. PrintArray never supports it, as the keys would collide.
+	Env func(item T) []EnvVar
+
+	// The columns to write for the csv output format. Unlike Table, the values should be plain text with no
+	// colours or other formatting, as they are destined for spreadsheets and scripts.
+	// If you leave Row as nil, then the command will simply not support output as csv and will
+	// fail if someone asks for it
+	Csv TableDefinition[T]
+
 	// NOTE: We might have some kinds of entities where table formatting doesn't make sense, and we want to
 	// render those as basic text instead. This seems unlikely though, defer it until the issue comes up.
-
-	// NOTE: The structure for printing tables would also work for CSV... perhaps we can have --outputFormat=csv for free?
 }
 
-func PrintArray[T any](items []T, cmd *cobra.Command, mappers Mappers[T]) error {
+// GetOutputFormat returns the lowercased output format from the command line, falling back to the config file
+func GetOutputFormat(cmd *cobra.Command) string {
 	outputFormat, _ := cmd.Flags().GetString(constants.FlagOutputFormat)
 	if outputFormat == "" {
 		outputFormat = viper.GetString(constants.ConfigOutputFormat)
 	}
+	return strings.ToLower(outputFormat)
+}
 
-	switch strings.ToLower(outputFormat) {
-	case constants.OutputFormatJson:
-		jsonMapper := mappers.Json
-		if jsonMapper == nil {
-			return errors.New("command does not support output in JSON format")
-		}
-		var outputJson []any
-		for _, e := range items {
-			outputJson = append(outputJson, jsonMapper(e))
+func unsupportedOutputFormatError(outputFormat string, cmd *cobra.Command) error {
+	return usage.NewUsageError(
+		fmt.Sprintf("unsupported output format %s. Valid values are 'json', 'ndjson', 'csv', 'table', 'basic', 'env'. Defaults to table", outputFormat),
+		cmd)
+}
+
+// printNdjson writes each item as a compact JSON object on its own line. Items are encoded as we go
+// rather than collected into one big document, so consumers can start processing before we finish.
+func printNdjson[T any](items []T, cmd *cobra.Command, jsonMapper func(item T) any) error {
+	if jsonMapper == nil {
+		return errors.New("command does not support output in JSON format")
+	}
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	for _, e := range items {
+		if err := encoder.Encode(jsonMapper(e)); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		data, _ := json.MarshalIndent(outputJson, "", "  ")
-		cmd.Println(string(data))
+func PrintArray[T any](items []T, cmd *cobra.Command, mappers Mappers[T]) error {
+	printer, err := NewArrayPrinter(cmd, mappers)
+	if err != nil {
+		return err
+	}
+	if err := printer.Print(items); err != nil {
+		return err
+	}
+	return printer.Flush()
+}
 
-	case constants.OutputFormatBasic:
-		textMapper := mappers.Basic
-		if textMapper == nil {
-			return errors.New("command does not support output in plain text")
-		}
-		for _, e := range items {
-			cmd.Println(textMapper(e))
-		}
+// PrintResource is the single-item counterpart to PrintArray. JSON output is a single object rather than
+// an array, and ndjson output is exactly one line.
+func PrintResource[T any](item T, cmd *cobra.Command, mappers Mappers[T]) error {
+	outputFormat := GetOutputFormat(cmd)
 
-	case constants.OutputFormatTable, "": // table is the default of unspecified
-		tableMapper := mappers.Table
-		if tableMapper.Row == nil {
-			return errors.New("command does not support output in table format")
+	switch outputFormat {
+	case constants.OutputFormatJson:
+		if mappers.Json == nil {
+			return errors.New("command does not support output in JSON format")
 		}
+		data, _ := json.MarshalIndent(WrapJson(mappers.Json(item)), "", "  ")
+		cmd.Println(string(data))
 
-		t := NewTable(cmd.OutOrStdout())
-		if tableMapper.Header != nil {
-			for k, v := range tableMapper.Header {
-				tableMapper.Header[k] = Bold(v)
-			}
-			t.AddRow(tableMapper.Header...)
+	case constants.OutputFormatNdjson:
+		return printNdjson([]T{item}, cmd, mappers.Json)
+
+	case constants.OutputFormatEnv:
+		if mappers.Env == nil {
+			return errors.New("command does not support output in env format")
 		}
+		return PrintEnv(cmd.OutOrStdout(), mappers.Env(item))
 
-		for _, item := range items {
-			t.AddRow(tableMapper.Row(item)...)
+	case constants.OutputFormatBasic:
+		if mappers.Basic == nil {
+			return errors.New("command does not support output in plain text")
 		}
+		cmd.Println(mappers.Basic(item))
 
-		return t.Print()
+	case constants.OutputFormatTable, constants.OutputFormatCsv, "":
+		return PrintArray([]T{item}, cmd, mappers)
 
 	default:
-		return usage.NewUsageError(
-			fmt.Sprintf("unsupported output format %s. Valid values are 'json', 'table', 'basic'. Defaults to table", outputFormat),
-			cmd)
+		return unsupportedOutputFormatError(outputFormat, cmd)
 	}
 	return nil
 }
@@ -0,0 +1,169 @@
+package output_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type widget struct {
+	id   string
+	name string
+}
+
+var widgetMappers = output.Mappers[*widget]{
+	Json: func(item *widget) any {
+		return output.IdAndName{Id: item.id, Name: item.name}
+	},
+	Table: output.TableDefinition[*widget]{
+		Header: []string{"NAME"},
+		Row:    func(item *widget) []string { return []string{item.name} },
+	},
+	Basic: func(item *widget) string { return item.name },
+}
+
+func newOutputFormatCmd(outputFormat string) (*cobra.Command, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String(constants.FlagOutputFormat, outputFormat, "")
+	cmd.SetOut(stdout)
+	return cmd, stdout
+}
+
+func TestPrintArray_Ndjson(t *testing.T) {
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatNdjson)
+	items := []*widget{{"Widgets-1", "first"}, {"Widgets-2", "second\nwith a newline"}}
+
+	err := output.PrintArray(items, cmd, widgetMappers)
+	assert.Nil(t, err)
+
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(stdout.String()))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	assert.Len(t, lines, len(items))
+	for i, line := range lines {
+		var parsed output.IdAndName
+		assert.Nil(t, json.Unmarshal([]byte(line), &parsed))
+		assert.Equal(t, items[i].id, parsed.Id)
+		assert.Equal(t, items[i].name, parsed.Name)
+	}
+}
+
+func TestPrintResource_Ndjson(t *testing.T) {
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatNdjson)
+
+	err := output.PrintResource(&widget{"Widgets-1", "first"}, cmd, widgetMappers)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"Id\":\"Widgets-1\",\"Name\":\"first\"}\n", stdout.String())
+}
+
+func TestPrintResource_Json(t *testing.T) {
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatJson)
+
+	err := output.PrintResource(&widget{"Widgets-1", "first"}, cmd, widgetMappers)
+	assert.Nil(t, err)
+
+	var parsed output.IdAndName
+	assert.Nil(t, json.Unmarshal(stdout.Bytes(), &parsed))
+	assert.Equal(t, output.IdAndName{Id: "Widgets-1", Name: "first"}, parsed)
+}
+
+func TestPrintEnv_QuotesValues(t *testing.T) {
+	stdout := &bytes.Buffer{}
+
+	err := output.PrintEnv(stdout, []output.EnvVar{
+		{Key: "PLAIN", Value: "Accounts-1"},
+		{Key: "SPACES", Value: "my account"},
+		{Key: "QUOTE", Value: "it's"},
+		{Key: "SUBSHELL", Value: "$(rm -rf /); `id`"},
+		{Key: "EMPTY", Value: ""},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "PLAIN=Accounts-1\n"+
+		"SPACES='my account'\n"+
+		"QUOTE=it\\'s\n"+
+		"SUBSHELL='$(rm -rf /); `id`'\n"+
+		"EMPTY=''\n", stdout.String())
+}
+
+func TestPrintResource_Env(t *testing.T) {
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatEnv)
+	mappers := widgetMappers
+	mappers.Env = func(item *widget) []output.EnvVar {
+		return []output.EnvVar{{Key: "WIDGET_ID", Value: item.id}, {Key: "WIDGET_NAME", Value: item.name}}
+	}
+
+	err := output.PrintResource(&widget{"Widgets-1", "first one"}, cmd, mappers)
+	assert.Nil(t, err)
+	assert.Equal(t, "WIDGET_ID=Widgets-1\nWIDGET_NAME='first one'\n", stdout.String())
+}
+
+func TestPrintArray_EnvUnsupported(t *testing.T) {
+	cmd, _ := newOutputFormatCmd(constants.OutputFormatEnv)
+	mappers := widgetMappers
+	mappers.Env = func(item *widget) []output.EnvVar { return nil }
+
+	err := output.PrintArray([]*widget{{"Widgets-1", "first"}}, cmd, mappers)
+	assert.EqualError(t, err, "output in env format is only supported by commands which output a single resource")
+}
+
+func TestPrintArray_Csv(t *testing.T) {
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatCsv)
+	mappers := widgetMappers
+	mappers.Csv = output.TableDefinition[*widget]{
+		Header: []string{"Id", "Name"},
+		Row:    func(item *widget) []string { return []string{item.id, item.name} },
+	}
+	items := []*widget{{"Widgets-1", "plain"}, {"Widgets-2", "one, two"}, {"Widgets-3", `the "best" one`}, {"Widgets-4", "two\nlines"}}
+
+	err := output.PrintArray(items, cmd, mappers)
+	assert.Nil(t, err)
+	assert.Equal(t, "Id,Name\r\n"+
+		"Widgets-1,plain\r\n"+
+		"Widgets-2,\"one, two\"\r\n"+
+		"Widgets-3,\"the \"\"best\"\" one\"\r\n"+
+		"Widgets-4,\"two\r\nlines\"\r\n", stdout.String())
+}
+
+func TestPrintArray_CsvUnsupported(t *testing.T) {
+	cmd, _ := newOutputFormatCmd(constants.OutputFormatCsv)
+
+	err := output.PrintArray([]*widget{{"Widgets-1", "first"}}, cmd, widgetMappers)
+	assert.EqualError(t, err, "command does not support output in CSV format")
+}
+
+func TestPrintArray_JsonIncludesActiveSpace(t *testing.T) {
+	output.ActiveSpace = func() *output.IdAndName { return &output.IdAndName{Id: "Spaces-2", Name: "Integrations"} }
+	defer func() { output.ActiveSpace = nil }()
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatJson)
+
+	err := output.PrintArray([]*widget{{"Widgets-1", "first"}}, cmd, widgetMappers)
+	assert.Nil(t, err)
+
+	var parsed struct {
+		ActiveSpace output.IdAndName
+		Result      []output.IdAndName
+	}
+	assert.Nil(t, json.Unmarshal(stdout.Bytes(), &parsed))
+	assert.Equal(t, output.IdAndName{Id: "Spaces-2", Name: "Integrations"}, parsed.ActiveSpace)
+	assert.Equal(t, []output.IdAndName{{Id: "Widgets-1", Name: "first"}}, parsed.Result)
+}
+
+func TestPrintResource_JsonWithoutActiveSpace(t *testing.T) {
+	output.ActiveSpace = func() *output.IdAndName { return nil } // e.g. a command which never needed a space
+	defer func() { output.ActiveSpace = nil }()
+	cmd, stdout := newOutputFormatCmd(constants.OutputFormatJson)
+
+	err := output.PrintResource(&widget{"Widgets-1", "first"}, cmd, widgetMappers)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"ActiveSpace": null, "Result": {"Id": "Widgets-1", "Name": "first"}}`, stdout.String())
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// IsQuiet is set by --quiet. Commands should keep to their result when it is set: no spinner, no
+// confirmation chatter, no links or automation commands.
+var IsQuiet = false
+
+// Successf tells the user that something was created. Under --quiet only the id is printed, on a line of
+// its own, so that a script can capture it.
+func Successf(out io.Writer, id string, format string, args ...any) error {
+	if IsQuiet {
+		_, err := fmt.Fprintln(out, id)
+		return err
+	}
+	_, err := fmt.Fprintf(out, format, args...)
+	return err
+}
@@ -0,0 +1,37 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuccessf(t *testing.T) {
+	t.Run("prints the message normally", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.Nil(t, output.Successf(out, "Environments-1", "Successfully created environment %s.\n", "Test"))
+		assert.Equal(t, "Successfully created environment Test.\n", out.String())
+	})
+
+	t.Run("prints only the id under --quiet", func(t *testing.T) {
+		output.IsQuiet = true
+		defer func() { output.IsQuiet = false }()
+
+		out := &bytes.Buffer{}
+		assert.Nil(t, output.Successf(out, "Environments-1", "Successfully created environment %s.\n", "Test"))
+		assert.Equal(t, "Environments-1\n", out.String())
+	})
+}
+
+func TestInfof_SuppressedByQuiet(t *testing.T) {
+	output.IsQuiet = true
+	defer func() { output.IsQuiet = false }()
+
+	cmd, stdout, stderr := newInfoCmd()
+	assert.Nil(t, cmd.Execute())
+
+	assert.Equal(t, "result\n", stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
@@ -0,0 +1,30 @@
+package question
+
+import "github.com/OctopusDeploy/cli/pkg/constants"
+
+// ciEnvironmentVariables are set by build servers and CI systems. Not every one of them sets CI, so we look for
+// the ones they do set; add to this as we learn about more of them
+var ciEnvironmentVariables = []string{
+	constants.EnvCI,
+	constants.EnvTeamCityVersion,
+	constants.EnvBuildId,
+	constants.EnvJenkinsUrl,
+	constants.EnvTfBuild,
+	constants.EnvGitHubActions,
+	constants.EnvGitLabCI,
+	constants.EnvBitbucketBuild,
+	constants.EnvBuildkite,
+	constants.EnvCircleCI,
+	constants.EnvCodeBuildId,
+}
+
+// IsRunningInCI returns true if any of the environment variables set by a CI system are present.
+// lookupEnv is normally os.LookupEnv
+func IsRunningInCI(lookupEnv func(key string) (string, bool)) bool {
+	for _, name := range ciEnvironmentVariables {
+		if _, ok := lookupEnv(name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package question_test
+
+import (
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRunningInCI(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"nothing set", map[string]string{"HOME": "/home/me"}, false},
+		{"CI", map[string]string{"CI": "true"}, true},
+		{"TeamCity", map[string]string{"TEAMCITY_VERSION": "2022.10"}, true},
+		{"Jenkins", map[string]string{"BUILD_ID": "42"}, true},
+		{"Azure Pipelines", map[string]string{"TF_BUILD": "True"}, true},
+		{"GitHub Actions", map[string]string{"GITHUB_ACTIONS": "true"}, true},
+		{"set but empty still counts", map[string]string{"CI": ""}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lookupEnv := func(key string) (string, bool) {
+				v, ok := test.env[key]
+				return v, ok
+			}
+			assert.Equal(t, test.want, question.IsRunningInCI(lookupEnv))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package question
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/spf13/cobra"
+)
+
+const FlagResumeDraft = "resume-draft"
+
+// settable is implemented by *flag.Flag[T]; LoadDraft needs it to put the saved values back
+type settable interface {
+	flag.Generatable
+	GetValuePointer() any
+}
+
+func RegisterResumeDraftFlag(cmd *cobra.Command, value *bool) {
+	cmd.Flags().BoolVar(value, FlagResumeDraft, false, "Pre-fill the answers saved when this command was last interrupted.")
+}
+
+// IsInterrupt reports whether err came from the user pressing Ctrl+C at a prompt
+func IsInterrupt(err error) bool {
+	return errors.Is(err, terminal.InterruptErr)
+}
+
+// SaveDraft writes the values of the given flags to the file at path, so that an interrupted interactive
+// command can pick up where it left off. Flags marked as secure are never written, nor are ones with no value yet.
+func SaveDraft(path string, flags ...flag.Generatable) error {
+	draft := map[string]any{}
+	for _, f := range flags {
+		if f.IsSecure() || reflect.ValueOf(f.GetValue()).IsZero() {
+			continue
+		}
+		draft[f.GetName()] = f.GetValue()
+	}
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadDraft fills in any of the given flags which have no value yet from the draft at path, leaving alone
+// anything given on the command line. Secure flags are skipped even if the draft somehow has them.
+func LoadDraft(path string, flags ...flag.Generatable) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("there is no saved draft to resume")
+	}
+	if err != nil {
+		return err
+	}
+	draft := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return fmt.Errorf("the saved draft is not valid and can't be resumed; delete %s to start again", path)
+	}
+	for _, f := range flags {
+		saved, ok := draft[f.GetName()]
+		if !ok || f.IsSecure() || !reflect.ValueOf(f.GetValue()).IsZero() {
+			continue
+		}
+		s, ok := f.(settable)
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(saved, s.GetValuePointer()); err != nil {
+			return fmt.Errorf("the saved draft has an invalid value for --%s; delete %s to start again", f.GetName(), path)
+		}
+	}
+	return nil
+}
+
+// DeleteDraft removes the draft at path, once it's no longer needed. It's fine if there isn't one.
+func DeleteDraft(path string) {
+	_ = os.Remove(path)
+}
@@ -0,0 +1,60 @@
+package question_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util/flag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraft_RoundTripsOnlyNonSensitiveValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drafts", "test.json")
+
+	name := flag.New[string]("name", false)
+	name.Value = "Web deploy"
+	environments := flag.New[[]string]("environment", false)
+	environments.Value = []string{"Environments-1", "Environments-2"}
+	passphrase := flag.New[string]("passphrase", true)
+	passphrase.Value = "hunter2"
+	username := flag.New[string]("username", false) // not answered yet
+
+	err := question.SaveDraft(path, name, environments, passphrase, username)
+	assert.Nil(t, err)
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.NotContains(t, string(data), "passphrase")
+	assert.NotContains(t, string(data), "username")
+
+	resumedName := flag.New[string]("name", false)
+	resumedEnvironments := flag.New[[]string]("environment", false)
+	resumedPassphrase := flag.New[string]("passphrase", true)
+	resumedUsername := flag.New[string]("username", false)
+	err = question.LoadDraft(path, resumedName, resumedEnvironments, resumedPassphrase, resumedUsername)
+	assert.Nil(t, err)
+	assert.Equal(t, "Web deploy", resumedName.Value)
+	assert.Equal(t, []string{"Environments-1", "Environments-2"}, resumedEnvironments.Value)
+	assert.Equal(t, "", resumedPassphrase.Value)
+	assert.Equal(t, "", resumedUsername.Value)
+}
+
+func TestDraft_CommandLineValuesWin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+	name := flag.New[string]("name", false)
+	name.Value = "from draft"
+	assert.Nil(t, question.SaveDraft(path, name))
+
+	resumedName := flag.New[string]("name", false)
+	resumedName.Value = "from command line"
+	assert.Nil(t, question.LoadDraft(path, resumedName))
+	assert.Equal(t, "from command line", resumedName.Value)
+}
+
+func TestDraft_MissingDraft(t *testing.T) {
+	err := question.LoadDraft(filepath.Join(t.TempDir(), "missing.json"), flag.New[string]("name", false))
+	assert.EqualError(t, err, "there is no saved draft to resume")
+}
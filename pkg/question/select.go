@@ -3,6 +3,7 @@ package question
 import (
 	"fmt"
 	"github.com/OctopusDeploy/cli/pkg/util"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/OctopusDeploy/cli/pkg/constants"
@@ -45,7 +46,9 @@ func MultiSelectWithAddMap(ask Asker, message string, items []string, required b
 	return selectedKeys, nil
 }
 
-func SelectMap[T any](ask Asker, message string, items []T, getKey func(item T) string) (T, error) {
+// SelectMap asks the user to pick one of items, showing each as the string from getKey. Any opts, such as
+// survey.WithFilter, are passed through to the prompt
+func SelectMap[T any](ask Asker, message string, items []T, getKey func(item T) string, opts ...survey.AskOpt) (T, error) {
 	if util.Empty(items) {
 		return *new(T), fmt.Errorf("%s - no options available", message)
 	}
@@ -55,7 +58,7 @@ func SelectMap[T any](ask Asker, message string, items []T, getKey func(item T)
 	if err := ask(&survey.Select{
 		Message: message,
 		Options: options,
-	}, &selectedKey); err != nil {
+	}, &selectedKey, opts...); err != nil {
 		return selectedValue, err
 	}
 	selectedValue, ok := optionMap[selectedKey]
@@ -65,6 +68,21 @@ func SelectMap[T any](ask Asker, message string, items []T, getKey func(item T)
 	return selectedValue, nil
 }
 
+// FuzzyFilter is a survey filter which matches when the letters typed appear in value in the same order,
+// though not necessarily next to each other, so 'prdeu' finds 'Production EU'. Case and spaces typed are ignored
+func FuzzyFilter(filter string, value string, _ int) bool {
+	remaining := []rune(strings.ToLower(strings.ReplaceAll(filter, " ", "")))
+	for _, r := range strings.ToLower(value) {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
 func SelectMapWithNew[T any](ask Asker, message string, items []T, getKey func(item T) string) (T, bool, error) {
 	optionMap, options := MakeItemMapAndOptions(items, getKey)
 	var selectedValue T
@@ -22,3 +22,24 @@ func TestSelectMap_NoItems(t *testing.T) {
 	assert.Nil(t, selectedItem)
 	assert.Error(t, err)
 }
+
+func TestFuzzyFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		value  string
+		want   bool
+	}{
+		{"", "Default", true},
+		{"default", "Default", true},
+		{"fault", "Default", true},
+		{"prdeu", "Production EU", true},
+		{"prod eu", "Production EU", true},
+		{"eupr", "Production EU", false},
+		{"staging", "Production EU", false},
+	}
+	for _, test := range tests {
+		t.Run(test.filter+" in "+test.value, func(t *testing.T) {
+			assert.Equal(t, test.want, question.FuzzyFilter(test.filter, test.value, 0))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package selectors
+
+import (
+	"fmt"
+
+	"github.com/OctopusDeploy/cli/pkg/output"
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+)
+
+type GetAllAccountsCallback func() ([]accounts.IAccount, error)
+
+func GetAllAccounts(client *client.Client) ([]accounts.IAccount, error) {
+	return client.Accounts.GetAll()
+}
+
+// IsAccountUsableInEnvironment tells you whether an account may be used for deployments to the given environment.
+// Accounts which are not scoped to any environments can be used everywhere.
+func IsAccountUsableInEnvironment(account accounts.IAccount, environmentID string) bool {
+	environmentIDs := account.GetEnvironmentIDs()
+	return len(environmentIDs) == 0 || util.SliceContains(environmentIDs, environmentID)
+}
+
+// AccountForEnvironmentSelect asks the user to pick one of the accounts which can be used in environment,
+// and returns the ID of the chosen account.
+func AccountForEnvironmentSelect(ask question.Asker, getAllAccountsCallback GetAllAccountsCallback, environment *environments.Environment, message string) (string, error) {
+	allAccounts, err := getAllAccountsCallback()
+	if err != nil {
+		return "", err
+	}
+
+	usableAccounts := util.SliceFilter(allAccounts, func(item accounts.IAccount) bool {
+		return IsAccountUsableInEnvironment(item, environment.GetID())
+	})
+	if len(usableAccounts) == 0 {
+		return "", fmt.Errorf("no accounts can be used in the environment '%s'", environment.Name)
+	}
+
+	selectedAccount, err := Select(ask, message, func() ([]accounts.IAccount, error) { return usableAccounts, nil }, func(item accounts.IAccount) string {
+		if len(item.GetEnvironmentIDs()) == 0 {
+			return fmt.Sprintf("%s %s", item.GetName(), output.Dim("(all environments)"))
+		}
+		return item.GetName()
+	})
+	if err != nil {
+		return "", err
+	}
+	return selectedAccount.GetID(), nil
+}
+
+// CreatableAccountTypes are the types of account the CLI can create, in the order they are offered,
+// along with the name we show for each
+var CreatableAccountTypes = []AccountTypeOption{
+	{accounts.AccountTypeAmazonWebServicesAccount, "AWS Account"},
+	{accounts.AccountTypeAzureServicePrincipal, "Azure Account"},
+	{accounts.AccountTypeGoogleCloudPlatformAccount, "Google Cloud Account"},
+	{accounts.AccountTypeSSHKeyPair, "SSH Key Pair"},
+	{accounts.AccountTypeUsernamePassword, "Username/Password"},
+	{accounts.AccountTypeToken, "Token"},
+}
+
+type AccountTypeOption struct {
+	Type accounts.AccountType
+	Name string
+}
+
+// AccountTypeSelect asks the user which type of account they want, out of those the CLI can create.
+func AccountTypeSelect(ask question.Asker) (accounts.AccountType, error) {
+	selected, err := question.SelectMap(ask, "Account Type", CreatableAccountTypes, func(item AccountTypeOption) string {
+		return item.Name
+	})
+	if err != nil {
+		return "", err
+	}
+	return selected.Type, nil
+}
@@ -3,6 +3,10 @@ package selectors
 import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -56,3 +60,102 @@ func TestSelectForMultipleItem(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, selectedItem.id, "2")
 }
+
+func TestTagsMultiSelect(t *testing.T) {
+	regionTagSet := tagsets.NewTagSet("Region")
+	regionTagSet.Tags = []*tagsets.Tag{
+		{Name: "us-east", CanonicalTagName: "Region/us-east"},
+		{Name: "eu-west", CanonicalTagName: "Region/eu-west"},
+	}
+	tierTagSet := tagsets.NewTagSet("Tier")
+	tierTagSet.Tags = []*tagsets.Tag{
+		{Name: "Gold", CanonicalTagName: "Tier/Gold"},
+	}
+	tagSetsCallback := func() ([]*tagsets.TagSet, error) {
+		return []*tagsets.TagSet{regionTagSet, tierTagSet}, nil
+	}
+
+	pa := []*testutil.PA{
+		testutil.NewMultiSelectPrompt("Select tags", "", []string{"Region/us-east", "Region/eu-west", "Tier/Gold"}, []string{"Region/eu-west", "Tier/Gold"}),
+	}
+	mockAsker, checkRemainingPrompts := testutil.NewMockAsker(t, pa)
+	selectedTags, err := TagsMultiSelect(mockAsker, tagSetsCallback, "Select tags", false)
+	checkRemainingPrompts()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Region/eu-west", "Tier/Gold"}, selectedTags)
+}
+
+func TestTagsMultiSelect_NoTagSets(t *testing.T) {
+	tagSetsCallback := func() ([]*tagsets.TagSet, error) {
+		return []*tagsets.TagSet{}, nil
+	}
+
+	mockAsker, checkRemainingPrompts := testutil.NewMockAsker(t, []*testutil.PA{})
+	selectedTags, err := TagsMultiSelect(mockAsker, tagSetsCallback, "Select tags", false)
+	checkRemainingPrompts()
+	assert.Nil(t, err)
+	assert.Empty(t, selectedTags)
+}
+
+func TestAccountForEnvironmentSelect(t *testing.T) {
+	devEnvironment := environments.NewEnvironment("Development")
+	devEnvironment.ID = "Environments-1"
+
+	newAccount := func(id string, name string, environmentIDs ...string) accounts.IAccount {
+		account, _ := accounts.NewTokenAccount(name, core.NewSensitiveValue(""))
+		account.ID = id
+		account.EnvironmentIDs = environmentIDs
+		return account
+	}
+	scopedToDev := newAccount("Accounts-1", "Dev Token", "Environments-1")
+	scopedToProd := newAccount("Accounts-2", "Prod Token", "Environments-2")
+	unscoped := newAccount("Accounts-3", "Shared Token")
+
+	t.Run("offers scoped and unscoped accounts valid for the environment", func(t *testing.T) {
+		accountsCallback := func() ([]accounts.IAccount, error) {
+			return []accounts.IAccount{scopedToDev, scopedToProd, unscoped}, nil
+		}
+		pa := []*testutil.PA{
+			{
+				Prompt: &survey.Select{
+					Message: "Select an account",
+					Options: []string{"Dev Token", "Shared Token (all environments)"},
+				},
+				Answer: "Shared Token (all environments)",
+			},
+		}
+		mockAsker, checkRemainingPrompts := testutil.NewMockAsker(t, pa)
+		accountID, err := AccountForEnvironmentSelect(mockAsker, accountsCallback, devEnvironment, "Select an account")
+		checkRemainingPrompts()
+		assert.Nil(t, err)
+		assert.Equal(t, "Accounts-3", accountID)
+	})
+
+	t.Run("errors when no accounts can be used in the environment", func(t *testing.T) {
+		accountsCallback := func() ([]accounts.IAccount, error) {
+			return []accounts.IAccount{scopedToProd}, nil
+		}
+		mockAsker, checkRemainingPrompts := testutil.NewMockAsker(t, []*testutil.PA{})
+		accountID, err := AccountForEnvironmentSelect(mockAsker, accountsCallback, devEnvironment, "Select an account")
+		checkRemainingPrompts()
+		assert.EqualError(t, err, "no accounts can be used in the environment 'Development'")
+		assert.Equal(t, "", accountID)
+	})
+}
+
+func TestAccountTypeSelect(t *testing.T) {
+	pa := []*testutil.PA{
+		{
+			Prompt: &survey.Select{
+				Message: "Account Type",
+				Options: []string{"AWS Account", "Azure Account", "Google Cloud Account", "SSH Key Pair", "Username/Password", "Token"},
+			},
+			Answer: "SSH Key Pair",
+		},
+	}
+	mockAsker, checkRemainingPrompts := testutil.NewMockAsker(t, pa)
+	accountType, err := AccountTypeSelect(mockAsker)
+	checkRemainingPrompts()
+	assert.Nil(t, err)
+	assert.Equal(t, accounts.AccountTypeSSHKeyPair, accountType)
+}
@@ -0,0 +1,35 @@
+package selectors
+
+import (
+	"github.com/OctopusDeploy/cli/pkg/question"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tagsets"
+)
+
+type GetAllTagSetsCallback func() ([]*tagsets.TagSet, error)
+
+func GetAllTagSets(client *client.Client) ([]*tagsets.TagSet, error) {
+	return client.TagSets.GetAll()
+}
+
+// TagsMultiSelect lets the user pick from the tags in every tag set, and returns the canonical
+// "Tag Set/Tag" names of the selected tags.
+// A server with no tags has nothing to pick from, so this returns an empty selection rather than an error.
+func TagsMultiSelect(ask question.Asker, getAllTagSetsCallback GetAllTagSetsCallback, message string, required bool) ([]string, error) {
+	tagSets, err := getAllTagSetsCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	var canonicalTagNames []string
+	for _, tagSet := range tagSets {
+		for _, tag := range tagSet.Tags {
+			canonicalTagNames = append(canonicalTagNames, tag.CanonicalTagName)
+		}
+	}
+	if len(canonicalTagNames) == 0 {
+		return []string{}, nil
+	}
+
+	return question.MultiSelectMap(ask, message, canonicalTagNames, func(item string) string { return item }, required)
+}
@@ -0,0 +1,148 @@
+package taskwait
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/clock"
+	"github.com/OctopusDeploy/cli/pkg/constants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FlagWait        = "wait"
+	FlagWaitTimeout = "wait-timeout"
+
+	DefaultTimeout = 10 * time.Minute
+)
+
+// PollInterval is how long Wait sleeps between checks on the tasks. Tests shorten it
+var PollInterval = 5 * time.Second
+
+// Clock is what Wait measures its timeout and waits between checks with. Tests replace it
+var Clock clock.Clock = clock.Real
+
+// ServerTasksCallback loads the server tasks with the given IDs
+type ServerTasksCallback func(taskIDs []string) ([]*tasks.Task, error)
+
+// NewServerTasksCallback returns a ServerTasksCallback which loads tasks from the Octopus Server
+func NewServerTasksCallback(octopus *client.Client) ServerTasksCallback {
+	return func(taskIDs []string) ([]*tasks.Task, error) {
+		resourceTasks, err := octopus.Tasks.Get(tasks.TasksQuery{IDs: taskIDs})
+		if err != nil {
+			return nil, err
+		}
+		return resourceTasks.GetAllPages(octopus.Sling())
+	}
+}
+
+// RegisterFlags adds --wait and --wait-timeout to a command which starts server tasks
+func RegisterFlags(cmd *cobra.Command, wait *bool, timeout *time.Duration) {
+	cmd.Flags().BoolVar(wait, FlagWait, false, "Wait for the server tasks to finish, and fail if any of them don't succeed")
+	cmd.Flags().DurationVar(timeout, FlagWaitTimeout, DefaultTimeout, "With --wait, how long to wait for the server tasks to finish, e.g. 90s or 30m")
+}
+
+// Wait prints the state of each task, then again as each one completes, until they have all completed or
+// timeout has passed or ctx is done. It returns the completed tasks; use CheckSucceeded to find out whether they worked
+func Wait(ctx context.Context, out io.Writer, taskIDs []string, getServerTasks ServerTasksCallback, timeout time.Duration) ([]*tasks.Task, error) {
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("no server task IDs provided, at least one is required")
+	}
+	deadline := Clock.Now().Add(timeout)
+
+	serverTasks, err := getServerTasks(taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(serverTasks) == 0 {
+		return nil, fmt.Errorf("no server tasks found")
+	}
+
+	var completed []*tasks.Task
+	pendingTaskIDs := make([]string, 0)
+	for _, t := range serverTasks {
+		if isCompleted(t) {
+			completed = append(completed, t)
+		} else {
+			pendingTaskIDs = append(pendingTaskIDs, t.ID)
+		}
+		fmt.Fprintf(out, "%s: %s\n", t.Description, t.State)
+	}
+
+	for len(pendingTaskIDs) != 0 {
+		if !Clock.Now().Add(PollInterval).Before(deadline) {
+			return completed, fmt.Errorf("timeout while waiting for pending tasks")
+		}
+		select {
+		case <-ctx.Done():
+			return completed, ctx.Err()
+		case <-Clock.After(PollInterval):
+		}
+
+		serverTasks, err = getServerTasks(pendingTaskIDs)
+		if err != nil {
+			return completed, err
+		}
+		for _, t := range serverTasks {
+			if isCompleted(t) {
+				fmt.Fprintf(out, "%s: %s\n", t.Description, t.State)
+				completed = append(completed, t)
+				pendingTaskIDs = removeTaskID(pendingTaskIDs, t.ID)
+			}
+		}
+	}
+	return completed, nil
+}
+
+// CheckSucceeded returns an error naming every task which didn't finish successfully
+func CheckSucceeded(completed []*tasks.Task) error {
+	var failed []string
+	for _, t := range completed {
+		if t.FinishedSuccessfully == nil || !*t.FinishedSuccessfully {
+			failed = append(failed, fmt.Sprintf("%s (%s)", t.Description, t.State))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d server tasks did not succeed: %s", len(failed), len(completed), strings.Join(failed, ", "))
+}
+
+// WaitAndCheck waits for the tasks and then checks they all succeeded
+func WaitAndCheck(ctx context.Context, out io.Writer, taskIDs []string, getServerTasks ServerTasksCallback, timeout time.Duration) error {
+	completed, err := Wait(ctx, out, taskIDs, getServerTasks, timeout)
+	if err != nil {
+		return err
+	}
+	return CheckSucceeded(completed)
+}
+
+// WaitForCommand is WaitAndCheck for commands with --wait. With a programmatic output format, progress goes
+// to stderr so that stdout only has what the command printed about the tasks it started
+func WaitForCommand(cmd *cobra.Command, outputFormat string, octopus *client.Client, taskIDs []string, timeout time.Duration) error {
+	out := cmd.OutOrStdout()
+	if constants.IsProgrammaticOutputFormat(outputFormat) {
+		out = cmd.ErrOrStderr()
+	}
+	return WaitAndCheck(cmd.Context(), out, taskIDs, NewServerTasksCallback(octopus), timeout)
+}
+
+func isCompleted(t *tasks.Task) bool {
+	return t.IsCompleted != nil && *t.IsCompleted
+}
+
+func removeTaskID(taskIDs []string, taskID string) []string {
+	for i, p := range taskIDs {
+		if p == taskID {
+			taskIDs[i] = taskIDs[len(taskIDs)-1]
+			taskIDs = taskIDs[:len(taskIDs)-1]
+			break
+		}
+	}
+	return taskIDs
+}
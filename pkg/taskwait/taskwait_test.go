@@ -0,0 +1,93 @@
+package taskwait_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/OctopusDeploy/cli/pkg/clock"
+	"github.com/OctopusDeploy/cli/pkg/taskwait"
+	"github.com/OctopusDeploy/cli/test/testutil"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTask(id string, description string, state string, completed bool, succeeded bool) *tasks.Task {
+	task := tasks.NewTask()
+	task.ID = id
+	task.Description = description
+	task.State = state
+	task.IsCompleted = &completed
+	if completed {
+		task.FinishedSuccessfully = &succeeded
+	}
+	return task
+}
+
+func TestWait(t *testing.T) {
+	defer func(interval time.Duration) { taskwait.PollInterval = interval }(taskwait.PollInterval)
+	taskwait.PollInterval = time.Millisecond
+
+	t.Run("polls until every task has completed", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		calls := 0
+		getServerTasks := func(taskIDs []string) ([]*tasks.Task, error) {
+			calls++
+			if calls == 1 {
+				assert.Equal(t, []string{"ServerTasks-1", "ServerTasks-2"}, taskIDs)
+				return []*tasks.Task{
+					newTask("ServerTasks-1", "Deploy to Dev", "Executing", false, false),
+					newTask("ServerTasks-2", "Deploy to Test", "Success", true, true),
+				}, nil
+			}
+			assert.Equal(t, []string{"ServerTasks-1"}, taskIDs)
+			return []*tasks.Task{newTask("ServerTasks-1", "Deploy to Dev", "Success", true, true)}, nil
+		}
+
+		err := taskwait.WaitAndCheck(context.Background(), out, []string{"ServerTasks-1", "ServerTasks-2"}, getServerTasks, time.Minute)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "Deploy to Dev: Executing\nDeploy to Test: Success\nDeploy to Dev: Success\n", out.String())
+	})
+
+	t.Run("reports tasks which did not succeed", func(t *testing.T) {
+		getServerTasks := func(taskIDs []string) ([]*tasks.Task, error) {
+			return []*tasks.Task{
+				newTask("ServerTasks-1", "Deploy to Dev", "Failed", true, false),
+				newTask("ServerTasks-2", "Deploy to Test", "Success", true, true),
+			}, nil
+		}
+
+		err := taskwait.WaitAndCheck(context.Background(), &bytes.Buffer{}, []string{"ServerTasks-1", "ServerTasks-2"}, getServerTasks, time.Minute)
+		assert.EqualError(t, err, "1 of 2 server tasks did not succeed: Deploy to Dev (Failed)")
+	})
+
+	t.Run("gives up after the timeout", func(t *testing.T) {
+		getServerTasks := func(taskIDs []string) ([]*tasks.Task, error) {
+			return []*tasks.Task{newTask("ServerTasks-1", "Deploy to Dev", "Executing", false, false)}, nil
+		}
+
+		defer func(c clock.Clock, interval time.Duration) {
+			taskwait.Clock, taskwait.PollInterval = c, interval
+		}(taskwait.Clock, taskwait.PollInterval)
+		fakeClock := testutil.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		taskwait.Clock, taskwait.PollInterval = fakeClock, 10*time.Second
+
+		_, err := taskwait.Wait(context.Background(), &bytes.Buffer{}, []string{"ServerTasks-1"}, getServerTasks, time.Minute)
+		assert.EqualError(t, err, "timeout while waiting for pending tasks")
+		// the last check is at 50s, as another wait would take it to the full minute
+		assert.Len(t, fakeClock.Waits, 5)
+	})
+
+	t.Run("stops waiting once its context is done", func(t *testing.T) {
+		getServerTasks := func(taskIDs []string) ([]*tasks.Task, error) {
+			return []*tasks.Task{newTask("ServerTasks-1", "Deploy to Dev", "Executing", false, false)}, nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := taskwait.Wait(ctx, &bytes.Buffer{}, []string{"ServerTasks-1"}, getServerTasks, time.Minute)
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
@@ -1,8 +1,12 @@
 package flag
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/spf13/pflag"
 )
 
 type Flag[T any] struct {
@@ -50,6 +54,61 @@ func (f *Flag[T]) IsSecure() bool {
 	return f.Secure
 }
 
+// GetValuePointer lets code which only knows about flags generically, such as drafts, set the value
+func (f *Flag[T]) GetValuePointer() any {
+	return &f.Value
+}
+
+// settable flags can be given a value by code which doesn't know their type, such as ApplyJson
+type settable interface {
+	Generatable
+	GetValuePointer() any
+}
+
+// ApplyJson sets flags from data, a JSON object keyed by flag name, e.g. {"name": "Web deploy"}. Flags given on
+// commandLine are left alone, so they override the JSON. Secure flags can't come from JSON, which is likely to be
+// kept in source control, and names which aren't one of flags are rejected as they're most likely typos.
+func ApplyJson(data []byte, commandLine *pflag.FlagSet, flags ...Generatable) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("it must be a JSON object of flag names and values: %w", err)
+	}
+	known := make(map[string]Generatable, len(flags))
+	var knownNames []string
+	for _, f := range flags {
+		known[f.GetName()] = f
+		if !f.IsSecure() {
+			knownNames = append(knownNames, f.GetName())
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f, ok := known[name]
+		if !ok {
+			return fmt.Errorf("'%s' isn't something it can set; use any of %s", name, strings.Join(knownNames, ", "))
+		}
+		if f.IsSecure() {
+			return fmt.Errorf("'%s' is secret, so it can't be kept there; give it with --%s instead", name, name)
+		}
+		if commandLine != nil && commandLine.Changed(name) {
+			continue
+		}
+		s, ok := f.(settable)
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(values[name], s.GetValuePointer()); err != nil {
+			return fmt.Errorf("'%s' has the wrong kind of value: %w", name, err)
+		}
+	}
+	return nil
+}
+
 func New[T any](name string, secure bool) *Flag[T] {
 	return &Flag[T]{
 		Name:   name,
@@ -2,9 +2,15 @@ package util
 
 import (
 	"bufio"
+	"io"
 	"os"
+
+	"github.com/OctopusDeploy/cli/pkg/validation"
 )
 
+// StdinPath is the value for a file flag (such as --description-file) which reads from stdin rather than a file
+const StdinPath = "-"
+
 func IsCalledFromPipe() bool {
 	fileInfo, _ := os.Stdin.Stat()
 	return fileInfo != nil && fileInfo.Mode()&os.ModeCharDevice == 0
@@ -22,3 +28,14 @@ func ReadValuesFromPipe() []string {
 	}
 	return items
 }
+
+// ReadFileOrStdin returns the contents of the file at path, or everything on stdin if path is StdinPath
+func ReadFileOrStdin(path string, stdin io.Reader) ([]byte, error) {
+	if path == StdinPath {
+		return io.ReadAll(stdin)
+	}
+	if err := validation.IsExistingFile(path); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,33 @@
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OctopusDeploy/cli/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFileOrStdin(t *testing.T) {
+	t.Run("reads from stdin when the path is -", func(t *testing.T) {
+		data, err := util.ReadFileOrStdin(util.StdinPath, strings.NewReader("from stdin"))
+		assert.Nil(t, err)
+		assert.Equal(t, "from stdin", string(data))
+	})
+
+	t.Run("reads the file at the path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "description.txt")
+		assert.Nil(t, os.WriteFile(path, []byte("from a file"), 0600))
+		data, err := util.ReadFileOrStdin(path, strings.NewReader("from stdin"))
+		assert.Nil(t, err)
+		assert.Equal(t, "from a file", string(data))
+	})
+
+	t.Run("errors for a missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing.txt")
+		_, err := util.ReadFileOrStdin(path, strings.NewReader(""))
+		assert.EqualError(t, err, "\""+path+"\" is not a valid file path")
+	})
+}
@@ -188,3 +188,32 @@ func RemoveIndex[T any](s []T, index int) []T {
 
 	return append(s[:index], s[index+1:]...)
 }
+
+// LevenshteinDistance returns how many single character insertions, deletions or substitutions it takes
+// to turn a into b. Comparison is by rune, and is case-sensitive
+func LevenshteinDistance(a string, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			substitution := previous[j-1]
+			if source[i-1] != target[j-1] {
+				substitution++
+			}
+			current[j] = substitution
+			if deletion := previous[j] + 1; deletion < current[j] {
+				current[j] = deletion
+			}
+			if insertion := current[j-1] + 1; insertion < current[j] {
+				current[j] = insertion
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
@@ -423,3 +423,13 @@ func TestRemoveIndex_IndexOutOfBounds_TooLow(t *testing.T) {
 	result := util.RemoveIndex([]string{"a", "b", "c"}, -1)
 	assert.Equal(t, []string{"a", "b", "c"}, result)
 }
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, util.LevenshteinDistance("", ""))
+	assert.Equal(t, 7, util.LevenshteinDistance("", "Default"))
+	assert.Equal(t, 0, util.LevenshteinDistance("Default", "Default"))
+	assert.Equal(t, 2, util.LevenshteinDistance("Defualt", "Default")) // a transposition is two edits
+	assert.Equal(t, 1, util.LevenshteinDistance("Defaut", "Default"))
+	assert.Equal(t, 3, util.LevenshteinDistance("kitten", "sitting"))
+	assert.Equal(t, 1, util.LevenshteinDistance("café", "cafe"))
+}
@@ -0,0 +1,41 @@
+package fixtures
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// NewSshPrivateKey returns a freshly generated, unencrypted private key in PEM form, as found in a key file
+func NewSshPrivateKey() []byte {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// NewEncryptedSshPrivateKey returns a freshly generated private key in PEM form, encrypted with passphrase
+func NewEncryptedSshPrivateKey(passphrase string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	// EncryptPEMBlock is deprecated, but legacy encrypted PEM is still what older ssh-keygen versions write
+	block, err := x509.EncryptPEMBlock(rand.Reader, "EC PRIVATE KEY", der, []byte(passphrase), x509.PEMCipherAES256)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(block)
+}
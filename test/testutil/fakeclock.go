@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a clock.Clock which only moves when it's told to. Waiting on After doesn't take any real time:
+// the clock jumps forward by the wait, which is also recorded in Waits so tests can check how long the code
+// under test would have waited.
+type FakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	Waits []time.Duration
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.Waits = append(c.Waits, d)
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+	return fired
+}
+
+// Advance moves the clock on by d, as if that much time had passed while the code under test was busy
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
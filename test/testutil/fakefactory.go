@@ -79,9 +79,23 @@ func (f *MockFactory) GetSpacedClient(requester apiclient.Requester) (*octopusAp
 func (f *MockFactory) GetCurrentSpace() *spaces.Space {
 	return f.CurrentSpace
 }
+func (f *MockFactory) HasSpace() bool {
+	return f.CurrentSpace != nil
+}
 func (f *MockFactory) GetCurrentHost() string {
 	return serverUrl
 }
+func (f *MockFactory) GetServerVersion(requester apiclient.Requester) (string, error) {
+	systemClient, err := f.GetSystemClient(requester)
+	if err != nil {
+		return "", err
+	}
+	root, err := systemClient.Root.Get()
+	if err != nil {
+		return "", err
+	}
+	return root.Version, nil
+}
 func (f *MockFactory) Spinner() factory.Spinner {
 	return f.RawSpinner
 }
@@ -194,6 +194,8 @@ func (r *RequestWrapper) RespondWithError(err error) {
 func NewRootResource() *octopusApiClient.RootResource {
 	root := octopusApiClient.NewRootResource()
 	root.Links[constants.LinkSpaces] = "/api/spaces{/id}{?skip,ids,take,partialName}"
+	root.Links[constants.LinkTeams] = "/api/teams{/id}{?skip,take,ids,partialName,spaces,includeSystem}"
+	root.Links[constants.LinkUsers] = "/api/users{/id}{?skip,take,ids,filter}"
 
 	// Note: all this stuff typically doesn't appear at the root resource level
 	// has assigned a default space. We don't like default spaces, so the unit tests
@@ -209,5 +211,8 @@ func NewRootResource() *octopusApiClient.RootResource {
 	root.Links[constants.LinkPackages] = "/api/Spaces-1/packages{/id}{?nuGetPackageId,filter,latest,skip,take,includeNotes}"
 	root.Links[constants.LinkLifecycles] = "/api/Spaces-1/lifecycles{/id}{?skip,take,ids,partialName}"
 	root.Links[constants.LinkProjectGroups] = "/api/Spaces-1/projectgroups{/id}{?skip,take,ids,partialName}"
+	root.Links[constants.LinkMachines] = "/api/Spaces-1/machines{/id}{?skip,take,name,ids,partialName,roles,isDisabled,healthStatuses,commStyles,tenantIds,tenantTags,environmentIds,thumbprint,deploymentId,shellNames,deploymentTargetTypes}"
+	root.Links[constants.LinkWorkers] = "/api/Spaces-1/workers{/id}{?skip,take,name,ids,partialName,isDisabled,healthStatuses,commStyles,workerPoolIds,thumbprint}"
+	root.Links[constants.LinkWorkerPools] = "/api/Spaces-1/workerpools{/id}{?skip,take,ids,partialName}"
 	return root
 }